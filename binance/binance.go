@@ -1,29 +1,61 @@
 package binance
 
-import "github.com/cploutarchou/crypto-sdk-suite/binance/futures"
+import (
+	"github.com/cploutarchou/crypto-sdk-suite/binance/delivery"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/spot"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+)
 
 // Binance interface represents the operations available for the Binance API.
 type Binance interface {
 	// Futures returns the interface for Futures operations.
 	Futures() futures.Futures
+	// Spot returns the interface for Spot operations.
+	Spot() spot.Spot
+	// Delivery returns the interface for COIN-M futures operations.
+	Delivery() delivery.Delivery
 }
 
-// binanceImpl represents the implementation of the Binance interface.
+// binanceImpl represents the implementation of the Binance interface. Each
+// API is created once, so its client and rate limiter are shared by every
+// caller; spot and the futures APIs are limited separately, as Binance does.
 type binanceImpl struct {
-	apiKey, apiSecret string
-	isTestnet         bool
+	futures  futures.Futures
+	spot     spot.Spot
+	delivery delivery.Delivery
 }
 
 // New creates a new Binance instance with the provided API key, API secret, and testnet flag.
 func New(apiKey, apiSecret string, isTestnet bool) Binance {
 	return &binanceImpl{
-		apiKey,
-		apiSecret,
-		isTestnet,
+		futures:  futures.New(apiKey, apiSecret, isTestnet),
+		spot:     spot.New(apiKey, apiSecret, isTestnet),
+		delivery: delivery.New(apiKey, apiSecret, isTestnet),
 	}
 }
 
-// Futures returns the Futures interface implementation by creating a new Futures instance.
+// NewWithProvider creates a new Binance instance whose credentials are read
+// from p before every signed request, so keys can be rotated while in use.
+func NewWithProvider(p credentials.Provider, isTestnet bool) Binance {
+	return &binanceImpl{
+		futures:  futures.NewWithProvider(p, isTestnet),
+		spot:     spot.NewWithProvider(p, isTestnet),
+		delivery: delivery.NewWithProvider(p, isTestnet),
+	}
+}
+
+// Futures returns the Futures interface implementation.
 func (b *binanceImpl) Futures() futures.Futures {
-	return futures.New(b.apiKey, b.apiSecret, b.isTestnet)
+	return b.futures
+}
+
+// Spot returns the Spot interface implementation.
+func (b *binanceImpl) Spot() spot.Spot {
+	return b.spot
+}
+
+// Delivery returns the Delivery interface implementation.
+func (b *binanceImpl) Delivery() delivery.Delivery {
+	return b.delivery
 }
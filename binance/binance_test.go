@@ -0,0 +1,15 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinance_SharesClients(t *testing.T) {
+	b := New("key", "secret", true)
+
+	assert.Same(t, b.Futures(), b.Futures())
+	assert.Same(t, b.Spot(), b.Spot())
+	assert.Same(t, b.Delivery(), b.Delivery())
+}
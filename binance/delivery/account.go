@@ -0,0 +1,472 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+)
+
+// Endpoints used in the delivery package for account operations.
+const (
+	positionModeEndpoint          = "/dapi/v1/positionSide/dual"
+	accountInfoEndpoint           = "/dapi/v1/account"
+	balanceEndpoint               = "/dapi/v1/balance"
+	positionRiskEndpoint          = "/dapi/v1/positionRisk"
+	leverageBracketEndpoint       = "/dapi/v2/leverageBracket"
+	adlQuantileEndpoint           = "/dapi/v1/adlQuantile"
+	commissionRateEndpoint        = "/dapi/v1/commissionRate"
+	incomeEndpoint                = "/dapi/v1/income"
+	userTradesEndpoint            = "/dapi/v1/userTrades"
+	marginTypeEndpoint            = "/dapi/v1/marginType"
+	leverageEndpoint              = "/dapi/v1/leverage"
+	positionMarginEndpoint        = "/dapi/v1/positionMargin"
+	positionMarginHistoryEndpoint = "/dapi/v1/positionMargin/history"
+)
+
+// Error codes returned when a setting already has the requested value.
+const (
+	codeNoNeedToChangeMarginType   = -4046
+	codeNoNeedToChangePositionSide = -4059
+)
+
+// MaxIncomeLimit is the largest page of income history.
+const MaxIncomeLimit = 1000
+
+// The following types are shared with USDⓈ-M futures.
+type (
+	ADLQuantile           = futures.ADLQuantile
+	CommissionRate        = futures.CommissionRate
+	Income                = futures.Income
+	PositionMarginChange  = futures.PositionMarginChange
+	PositionMarginHistory = futures.PositionMarginHistory
+)
+
+// Account defines the interface for COIN-M account operations. Balances
+// and margin are in the margin coin of each pair.
+type Account interface {
+	// AccountInfo returns the balances and margin of every coin, and the
+	// positions of every symbol.
+	AccountInfo() (*AccountInfo, error)
+	Balances() ([]Balance, error)
+	// PositionRisk returns the positions margined in marginAsset, or of the
+	// contracts of pair; every position is returned if both are empty.
+	PositionRisk(marginAsset, pair string) ([]PositionRisk, error)
+	// LeverageBrackets returns the brackets of the contracts of pair, or of
+	// every symbol if it is empty.
+	LeverageBrackets(pair string) ([]LeverageBracket, error)
+	// ADLQuantile returns the quantiles of symbol, or of every symbol with a position if it is empty.
+	ADLQuantile(symbol string) ([]ADLQuantile, error)
+	CommissionRate(symbol string) (*CommissionRate, error)
+	// IncomeHistory returns one page of income history.
+	IncomeHistory(req *IncomeRequest) ([]Income, error)
+	// AllIncome returns every page of income history from req.Page on.
+	AllIncome(req *IncomeRequest) ([]Income, error)
+	UserTrades(req *UserTradesRequest) ([]UserTrade, error)
+	// PositionMode reports whether hedge mode is enabled.
+	PositionMode() (bool, error)
+	// ChangePositionMode enables hedge mode, or one-way mode if enable is false.
+	ChangePositionMode(enable bool) error
+	ChangeMarginType(symbol string, marginType MarginType) error
+	ChangeLeverage(symbol string, leverage int) (*LeverageChange, error)
+	// ModifyPositionMargin adds margin to or removes margin from an isolated position.
+	ModifyPositionMargin(req *PositionMarginRequest) (*PositionMarginChange, error)
+	PositionMarginHistory(req *PositionMarginHistoryRequest) ([]PositionMarginHistory, error)
+}
+
+// AccountAsset is the margin of one coin of an account.
+type AccountAsset struct {
+	Asset                  string `json:"asset"`
+	WalletBalance          string `json:"walletBalance"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	MarginBalance          string `json:"marginBalance"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	AvailableBalance       string `json:"availableBalance"`
+	UpdateTime             int64  `json:"updateTime"`
+}
+
+// AccountInfo is the state of a COIN-M account. It lists every symbol in
+// Positions, including those without a position.
+type AccountInfo struct {
+	FeeTier     int               `json:"feeTier"`
+	CanTrade    bool              `json:"canTrade"`
+	CanDeposit  bool              `json:"canDeposit"`
+	CanWithdraw bool              `json:"canWithdraw"`
+	UpdateTime  int64             `json:"updateTime"`
+	Assets      []AccountAsset    `json:"assets"`
+	Positions   []AccountPosition `json:"positions"`
+}
+
+// AccountPosition is a position of AccountInfo. PositionAmt and MaxQty are
+// in contracts, NotionalValue and the margins in the margin coin.
+type AccountPosition struct {
+	Symbol                 string       `json:"symbol"`
+	PositionSide           PositionSide `json:"positionSide"`
+	PositionAmt            string       `json:"positionAmt"`
+	EntryPrice             string       `json:"entryPrice"`
+	BreakEvenPrice         string       `json:"breakEvenPrice"`
+	UnrealizedProfit       string       `json:"unrealizedProfit"`
+	InitialMargin          string       `json:"initialMargin"`
+	MaintMargin            string       `json:"maintMargin"`
+	PositionInitialMargin  string       `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string       `json:"openOrderInitialMargin"`
+	Leverage               string       `json:"leverage"`
+	Isolated               bool         `json:"isolated"`
+	IsolatedWallet         string       `json:"isolatedWallet"`
+	MaxQty                 string       `json:"maxQty"`
+	NotionalValue          string       `json:"notionalValue"`
+	UpdateTime             int64        `json:"updateTime"`
+}
+
+// Balance is the balance of a coin.
+type Balance struct {
+	AccountAlias       string `json:"accountAlias"`
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"` // Balance is the wallet balance.
+	WithdrawAvailable  string `json:"withdrawAvailable"`
+	CrossWalletBalance string `json:"crossWalletBalance"`
+	CrossUnPnl         string `json:"crossUnPnl"`
+	AvailableBalance   string `json:"availableBalance"`
+	UpdateTime         int64  `json:"updateTime"`
+}
+
+// PositionRisk is a position with its liquidation price.
+type PositionRisk struct {
+	Symbol           string       `json:"symbol"`
+	PositionSide     PositionSide `json:"positionSide"`
+	PositionAmt      string       `json:"positionAmt"` // PositionAmt is in contracts, negative for short positions in one-way mode.
+	EntryPrice       string       `json:"entryPrice"`
+	BreakEvenPrice   string       `json:"breakEvenPrice"`
+	MarkPrice        string       `json:"markPrice"`
+	LiquidationPrice string       `json:"liquidationPrice"`
+	UnRealizedProfit string       `json:"unRealizedProfit"`
+	Leverage         string       `json:"leverage"`
+	MaxQty           string       `json:"maxQty"`
+	MarginType       string       `json:"marginType"` // MarginType is "isolated" or "cross".
+	IsolatedMargin   string       `json:"isolatedMargin"`
+	IsolatedWallet   string       `json:"isolatedWallet"`
+	IsAutoAddMargin  string       `json:"isAutoAddMargin"`
+	NotionalValue    string       `json:"notionalValue"`
+	UpdateTime       int64        `json:"updateTime"`
+}
+
+// LeverageBracket is the brackets of a symbol. COIN-M brackets are bounded
+// by quantities of the margin coin rather than by notional values.
+type LeverageBracket struct {
+	Symbol       string    `json:"symbol"`
+	NotionalCoef float64   `json:"notionalCoef"`
+	Brackets     []Bracket `json:"brackets"`
+}
+
+// Bracket is the maximum leverage allowed up to a quantity of margin coin.
+type Bracket struct {
+	Bracket          int     `json:"bracket"`
+	InitialLeverage  int     `json:"initialLeverage"`
+	QtyCap           float64 `json:"qtyCap"`
+	QtyFloor         float64 `json:"qtyFloor"`
+	MaintMarginRatio float64 `json:"maintMarginRatio"`
+	Cum              float64 `json:"cum"` // Cum is the maintenance amount deducted at this bracket.
+}
+
+// UserTrade is a trade of the account. Qty is in contracts and BaseQty in
+// the base coin.
+type UserTrade struct {
+	ID              int64        `json:"id"`
+	OrderID         int64        `json:"orderId"`
+	Symbol          string       `json:"symbol"`
+	Pair            string       `json:"pair"`
+	Side            Side         `json:"side"`
+	PositionSide    PositionSide `json:"positionSide"`
+	Price           string       `json:"price"`
+	Qty             string       `json:"qty"`
+	BaseQty         string       `json:"baseQty"`
+	RealizedPnl     string       `json:"realizedPnl"`
+	MarginAsset     string       `json:"marginAsset"`
+	Commission      string       `json:"commission"`
+	CommissionAsset string       `json:"commissionAsset"`
+	Buyer           bool         `json:"buyer"`
+	Maker           bool         `json:"maker"`
+	Time            int64        `json:"time"`
+}
+
+// LeverageChange is the result of ChangeLeverage. MaxQty is the largest
+// position allowed at the new leverage, in contracts.
+type LeverageChange struct {
+	Symbol   string `json:"symbol"`
+	Leverage int    `json:"leverage"`
+	MaxQty   string `json:"maxQty"`
+}
+
+// IncomeRequest filters the income history. Without StartTime and EndTime,
+// the last 7 days are returned.
+type IncomeRequest struct {
+	Symbol     string
+	IncomeType IncomeType
+	StartTime  time.Time
+	EndTime    time.Time
+	Page       int // Page starts at 1.
+	Limit      int // Limit defaults to 100, with a maximum of MaxIncomeLimit.
+}
+
+func (r *IncomeRequest) values() url.Values {
+	v := url.Values{}
+	setIf(v, "symbol", r.Symbol)
+	setIf(v, "incomeType", string(r.IncomeType))
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt64(v, "page", int64(r.Page))
+	setInt64(v, "limit", int64(r.Limit))
+	return v
+}
+
+// UserTradesRequest filters the trades returned by UserTrades, of Symbol or
+// of every contract of Pair. Trades are returned from FromID on if it is
+// set, otherwise the most recent ones.
+type UserTradesRequest struct {
+	Symbol    string
+	Pair      string
+	OrderID   int64 // OrderID can only be used with Symbol.
+	StartTime time.Time
+	EndTime   time.Time
+	FromID    int64 // FromID cannot be used with Pair.
+	Limit     int   // Limit defaults to 50, with a maximum of 1000.
+}
+
+func (r *UserTradesRequest) values() url.Values {
+	v := url.Values{}
+	setIf(v, "symbol", r.Symbol)
+	setIf(v, "pair", r.Pair)
+	setInt64(v, "orderId", r.OrderID)
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt64(v, "fromId", r.FromID)
+	setInt64(v, "limit", int64(r.Limit))
+	return v
+}
+
+// PositionMarginRequest changes the margin of an isolated position. Amount
+// is in the margin coin.
+type PositionMarginRequest struct {
+	Symbol       string
+	PositionSide PositionSide // PositionSide defaults to BOTH; it is required in hedge mode.
+	Amount       string
+	Type         MarginAdjustment
+}
+
+func (r *PositionMarginRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	setIf(v, "positionSide", string(r.PositionSide))
+	v.Set("amount", r.Amount)
+	v.Set("type", strconv.Itoa(int(r.Type)))
+	return v
+}
+
+// PositionMarginHistoryRequest filters the isolated margin changes of a symbol.
+type PositionMarginHistoryRequest struct {
+	Symbol    string
+	Type      MarginAdjustment // Type is 0 for both directions.
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 50.
+}
+
+func (r *PositionMarginHistoryRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	setInt64(v, "type", int64(r.Type))
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt64(v, "limit", int64(r.Limit))
+	return v
+}
+
+// accountImpl implements Account using Binance COIN-M futures API.
+type accountImpl struct {
+	*client.Client
+}
+
+// NewAccount creates a new Account instance.
+func NewAccount(client *client.Client) Account {
+	return &accountImpl{client}
+}
+
+// signed sends a signed request with params and decodes the response into out.
+func (a *accountImpl) signed(method, endpoint string, params url.Values, out any) error {
+	return a.Do(context.Background(), method, endpoint, params, client.SecuritySigned, out)
+}
+
+func (a *accountImpl) AccountInfo() (*AccountInfo, error) {
+	info := new(AccountInfo)
+	if err := a.signed(http.MethodGet, accountInfoEndpoint, nil, info); err != nil {
+		return nil, fmt.Errorf("failed to get account information: %w", err)
+	}
+	return info, nil
+}
+
+func (a *accountImpl) Balances() ([]Balance, error) {
+	var balances []Balance
+	if err := a.signed(http.MethodGet, balanceEndpoint, nil, &balances); err != nil {
+		return nil, fmt.Errorf("failed to get balances: %w", err)
+	}
+	return balances, nil
+}
+
+func (a *accountImpl) PositionRisk(marginAsset, pair string) ([]PositionRisk, error) {
+	v := url.Values{}
+	setIf(v, "marginAsset", marginAsset)
+	setIf(v, "pair", pair)
+	var positions []PositionRisk
+	if err := a.signed(http.MethodGet, positionRiskEndpoint, v, &positions); err != nil {
+		return nil, fmt.Errorf("failed to get position risk: %w", err)
+	}
+	return positions, nil
+}
+
+func (a *accountImpl) LeverageBrackets(pair string) ([]LeverageBracket, error) {
+	v := url.Values{}
+	setIf(v, "pair", pair)
+	var brackets []LeverageBracket
+	if err := a.signed(http.MethodGet, leverageBracketEndpoint, v, &brackets); err != nil {
+		return nil, fmt.Errorf("failed to get leverage brackets: %w", err)
+	}
+	return brackets, nil
+}
+
+func (a *accountImpl) ADLQuantile(symbol string) ([]ADLQuantile, error) {
+	v := url.Values{}
+	setIf(v, "symbol", symbol)
+	var quantiles []ADLQuantile
+	if err := a.signed(http.MethodGet, adlQuantileEndpoint, v, &quantiles); err != nil {
+		return nil, fmt.Errorf("failed to get ADL quantiles: %w", err)
+	}
+	return quantiles, nil
+}
+
+func (a *accountImpl) CommissionRate(symbol string) (*CommissionRate, error) {
+	rate := new(CommissionRate)
+	if err := a.signed(http.MethodGet, commissionRateEndpoint, url.Values{"symbol": {symbol}}, rate); err != nil {
+		return nil, fmt.Errorf("failed to get commission rate: %w", err)
+	}
+	return rate, nil
+}
+
+func (a *accountImpl) IncomeHistory(req *IncomeRequest) ([]Income, error) {
+	var income []Income
+	if err := a.signed(http.MethodGet, incomeEndpoint, req.values(), &income); err != nil {
+		return nil, fmt.Errorf("failed to get income history: %w", err)
+	}
+	return income, nil
+}
+
+func (a *accountImpl) AllIncome(req *IncomeRequest) ([]Income, error) {
+	page := *req
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	if page.Limit <= 0 {
+		page.Limit = MaxIncomeLimit
+	}
+
+	var income []Income
+	for {
+		res, err := a.IncomeHistory(&page)
+		if err != nil {
+			return nil, err
+		}
+		income = append(income, res...)
+		if len(res) < page.Limit {
+			return income, nil
+		}
+		page.Page++
+	}
+}
+
+func (a *accountImpl) UserTrades(req *UserTradesRequest) ([]UserTrade, error) {
+	if req.Symbol == "" && req.Pair == "" {
+		return nil, errors.New("failed to get user trades: symbol or pair is required")
+	}
+	var trades []UserTrade
+	if err := a.signed(http.MethodGet, userTradesEndpoint, req.values(), &trades); err != nil {
+		return nil, fmt.Errorf("failed to get user trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (a *accountImpl) PositionMode() (bool, error) {
+	var resp struct {
+		DualSidePosition bool `json:"dualSidePosition"`
+	}
+	if err := a.signed(http.MethodGet, positionModeEndpoint, nil, &resp); err != nil {
+		return false, fmt.Errorf("failed to get position mode: %w", err)
+	}
+	return resp.DualSidePosition, nil
+}
+
+func (a *accountImpl) ChangePositionMode(enable bool) error {
+	v := url.Values{"dualSidePosition": {strconv.FormatBool(enable)}}
+	err := a.signed(http.MethodPost, positionModeEndpoint, v, nil)
+	if err = ignoreCode(err, codeNoNeedToChangePositionSide); err != nil {
+		return fmt.Errorf("failed to change position mode: %w", err)
+	}
+	return nil
+}
+
+func (a *accountImpl) ChangeMarginType(symbol string, marginType MarginType) error {
+	v := url.Values{"symbol": {symbol}, "marginType": {string(marginType)}}
+	err := a.signed(http.MethodPost, marginTypeEndpoint, v, nil)
+	if err = ignoreCode(err, codeNoNeedToChangeMarginType); err != nil {
+		return fmt.Errorf("failed to change margin type: %w", err)
+	}
+	return nil
+}
+
+func (a *accountImpl) ChangeLeverage(symbol string, leverage int) (*LeverageChange, error) {
+	v := url.Values{"symbol": {symbol}, "leverage": {strconv.Itoa(leverage)}}
+	change := new(LeverageChange)
+	if err := a.signed(http.MethodPost, leverageEndpoint, v, change); err != nil {
+		return nil, fmt.Errorf("failed to change leverage: %w", err)
+	}
+	return change, nil
+}
+
+func (a *accountImpl) ModifyPositionMargin(req *PositionMarginRequest) (*PositionMarginChange, error) {
+	if req.Type != MarginAdd && req.Type != MarginReduce {
+		return nil, fmt.Errorf("failed to modify position margin: unsupported type %d", req.Type)
+	}
+	change := new(PositionMarginChange)
+	if err := a.signed(http.MethodPost, positionMarginEndpoint, req.values(), change); err != nil {
+		return nil, fmt.Errorf("failed to modify position margin: %w", err)
+	}
+	return change, nil
+}
+
+func (a *accountImpl) PositionMarginHistory(req *PositionMarginHistoryRequest) ([]PositionMarginHistory, error) {
+	var history []PositionMarginHistory
+	if err := a.signed(http.MethodGet, positionMarginHistoryEndpoint, req.values(), &history); err != nil {
+		return nil, fmt.Errorf("failed to get position margin history: %w", err)
+	}
+	return history, nil
+}
+
+// ignoreCode returns nil if err is the API error code, which Binance returns
+// when a setting already has the value it is changed to, and err otherwise.
+func ignoreCode(err error, code int) error {
+	var apiErr *client.BinanceAPIError
+	if errors.As(err, &apiErr) && apiErr.Code == code {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+package delivery
+
+import "github.com/shopspring/decimal"
+
+// COIN-M contracts are worth a fixed amount of USD, their contract size:
+// 100 USD for BTC contracts and 10 USD for the others. Quantities are in
+// contracts, while margin and profit are in the base coin, so the coin
+// value of a quantity depends on the price.
+
+// ContractsToCoin returns the value in coin of contracts of contractSize
+// USD at price.
+func ContractsToCoin(contracts, contractSize, price decimal.Decimal) decimal.Decimal {
+	if price.IsZero() {
+		return decimal.Zero
+	}
+	return contracts.Mul(contractSize).Div(price)
+}
+
+// CoinToContracts returns the whole number of contracts of contractSize
+// USD that amount of coin buys at price, rounded down.
+func CoinToContracts(amount, contractSize, price decimal.Decimal) decimal.Decimal {
+	if contractSize.IsZero() {
+		return decimal.Zero
+	}
+	return amount.Mul(price).Div(contractSize).Floor()
+}
+
+// Notional returns the value in USD of contracts of contractSize USD.
+func Notional(contracts, contractSize decimal.Decimal) decimal.Decimal {
+	return contracts.Mul(contractSize)
+}
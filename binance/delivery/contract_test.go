@@ -0,0 +1,17 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractConversions(t *testing.T) {
+	size, price := decimal.NewFromInt(100), decimal.NewFromInt(50000)
+
+	assert.Equal(t, "0.006", ContractsToCoin(decimal.NewFromInt(3), size, price).String())
+	assert.Equal(t, "2", CoinToContracts(decimal.RequireFromString("0.0059"), size, price).String())
+	assert.Equal(t, "300", Notional(decimal.NewFromInt(3), size).String())
+	assert.True(t, ContractsToCoin(decimal.NewFromInt(3), size, decimal.Zero).IsZero())
+}
@@ -0,0 +1,114 @@
+// Package delivery is the Binance COIN-M futures API, for the inverse
+// perpetual and quarterly contracts margined in coin. It shares the
+// signing, rate limiting and streams of the futures client.
+package delivery
+
+import (
+	"github.com/cploutarchou/crypto-sdk-suite/binance/delivery/market"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/ws"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+)
+
+// Base URLs of the Binance COIN-M futures API.
+const (
+	ProductionBaseURL = "https://dapi.binance.com"
+	TestnetBaseURL    = "https://testnet.binancefuture.com"
+	ProductionWSURL   = "wss://dstream.binance.com"
+	TestnetWSURL      = "wss://dstream.binancefuture.com"
+)
+
+const (
+	serverTimeEndpoint = "/dapi/v1/time"
+	listenKeyEndpoint  = "/dapi/v1/listenKey"
+)
+
+type Delivery interface {
+	Market() market.Market
+	Account() Account
+	Orders() Orders
+	// Streams returns a new client for the WebSocket market streams.
+	Streams() *ws.Client
+	// UserData returns a new user data stream of the account, on a
+	// connection of its own.
+	UserData() *ws.UserDataStream
+	// OrderBook returns a local order book of symbol, streamed on streams
+	// and built from the snapshots of Market.
+	OrderBook(streams *ws.Client, symbol string) *ws.OrderBook
+}
+
+type deliveryImpl struct {
+	client *client.Client
+}
+
+// NewClient returns a client for the COIN-M futures API, configured like
+// config except for the COIN-M URLs, weights and rate limits, which are
+// filled in when they are not set.
+func NewClient(config client.Config, isTestnet bool) *client.Client {
+	if config.BaseURL == "" {
+		config.BaseURL = ProductionBaseURL
+		if isTestnet {
+			config.BaseURL = TestnetBaseURL
+		}
+	}
+	if config.WSBaseURL == "" {
+		config.WSBaseURL = ProductionWSURL
+		if isTestnet {
+			config.WSBaseURL = TestnetWSURL
+		}
+	}
+	if config.TimeEndpoint == "" {
+		config.TimeEndpoint = serverTimeEndpoint
+	}
+	if config.RequestWeight == nil {
+		config.RequestWeight = RequestWeight
+	}
+	if config.Limiter == nil {
+		config.Limiter = client.NewLimiter(DefaultRateLimits...)
+	}
+	return client.NewClient(config)
+}
+
+func New(apiKey, apiSecret string, isTestnet bool) Delivery {
+	return &deliveryImpl{
+		client: NewClient(client.Config{APIKey: apiKey, APISecret: apiSecret}, isTestnet),
+	}
+}
+
+// NewWithProvider creates the COIN-M futures API with credentials read
+// from p before every signed request.
+func NewWithProvider(p credentials.Provider, isTestnet bool) Delivery {
+	return &deliveryImpl{
+		client: NewClient(client.Config{Credentials: p}, isTestnet),
+	}
+}
+
+// NewWithClient creates the COIN-M futures API on c, for clients built
+// with NewClient.
+func NewWithClient(c *client.Client) Delivery {
+	return &deliveryImpl{client: c}
+}
+
+func (d *deliveryImpl) Market() market.Market {
+	return market.NewMarket(d.client)
+}
+
+func (d *deliveryImpl) Account() Account {
+	return NewAccount(d.client)
+}
+
+func (d *deliveryImpl) Orders() Orders {
+	return NewOrders(d.client)
+}
+
+func (d *deliveryImpl) Streams() *ws.Client {
+	return ws.NewClient(d.client.Config().WSBaseURL)
+}
+
+func (d *deliveryImpl) UserData() *ws.UserDataStream {
+	return ws.NewUserDataStreamWithEndpoint(d.client, listenKeyEndpoint)
+}
+
+func (d *deliveryImpl) OrderBook(streams *ws.Client, symbol string) *ws.OrderBook {
+	return ws.NewOrderBook(streams, d.Market(), symbol)
+}
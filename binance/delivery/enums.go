@@ -0,0 +1,86 @@
+package delivery
+
+import "github.com/cploutarchou/crypto-sdk-suite/binance/futures"
+
+// The enumerations of COIN-M futures are those of USDⓈ-M futures.
+type (
+	Side             = futures.Side
+	PositionSide     = futures.PositionSide
+	OrderType        = futures.OrderType
+	TimeInForce      = futures.TimeInForce
+	WorkingType      = futures.WorkingType
+	OrderStatus      = futures.OrderStatus
+	ResponseType     = futures.ResponseType
+	MarginType       = futures.MarginType
+	MarginAdjustment = futures.MarginAdjustment
+	IncomeType       = futures.IncomeType
+)
+
+const (
+	SideBuy  = futures.SideBuy
+	SideSell = futures.SideSell
+)
+
+const (
+	PositionSideBoth  = futures.PositionSideBoth
+	PositionSideLong  = futures.PositionSideLong
+	PositionSideShort = futures.PositionSideShort
+)
+
+const (
+	OrderTypeLimit              = futures.OrderTypeLimit
+	OrderTypeMarket             = futures.OrderTypeMarket
+	OrderTypeStop               = futures.OrderTypeStop
+	OrderTypeStopMarket         = futures.OrderTypeStopMarket
+	OrderTypeTakeProfit         = futures.OrderTypeTakeProfit
+	OrderTypeTakeProfitMarket   = futures.OrderTypeTakeProfitMarket
+	OrderTypeTrailingStopMarket = futures.OrderTypeTrailingStopMarket
+)
+
+// COIN-M futures have no GTD time in force.
+const (
+	TimeInForceGTC = futures.TimeInForceGTC
+	TimeInForceIOC = futures.TimeInForceIOC
+	TimeInForceFOK = futures.TimeInForceFOK
+	TimeInForceGTX = futures.TimeInForceGTX
+)
+
+const (
+	WorkingTypeMarkPrice     = futures.WorkingTypeMarkPrice
+	WorkingTypeContractPrice = futures.WorkingTypeContractPrice
+)
+
+const (
+	OrderStatusNew             = futures.OrderStatusNew
+	OrderStatusPartiallyFilled = futures.OrderStatusPartiallyFilled
+	OrderStatusFilled          = futures.OrderStatusFilled
+	OrderStatusCanceled        = futures.OrderStatusCanceled
+	OrderStatusRejected        = futures.OrderStatusRejected
+	OrderStatusExpired         = futures.OrderStatusExpired
+)
+
+const (
+	ResponseTypeACK    = futures.ResponseTypeACK
+	ResponseTypeResult = futures.ResponseTypeResult
+)
+
+const (
+	MarginTypeIsolated = futures.MarginTypeIsolated
+	MarginTypeCrossed  = futures.MarginTypeCrossed
+)
+
+const (
+	MarginAdd    = futures.MarginAdd
+	MarginReduce = futures.MarginReduce
+)
+
+const (
+	IncomeTransfer            = futures.IncomeTransfer
+	IncomeRealizedPnL         = futures.IncomeRealizedPnL
+	IncomeFundingFee          = futures.IncomeFundingFee
+	IncomeCommission          = futures.IncomeCommission
+	IncomeInsuranceClear      = futures.IncomeInsuranceClear
+	IncomeReferralKickback    = futures.IncomeReferralKickback
+	IncomeCommissionRebate    = futures.IncomeCommissionRebate
+	IncomeDeliveredSettlement = futures.IncomeDeliveredSettlement
+)
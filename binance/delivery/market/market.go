@@ -0,0 +1,440 @@
+// Package market is the market data of the Binance COIN-M futures API.
+package market
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	futuresmarket "github.com/cploutarchou/crypto-sdk-suite/binance/futures/market"
+)
+
+// Endpoints of the COIN-M market data.
+const (
+	pingEndpoint               = "/dapi/v1/ping"
+	serverTimeEndpoint         = "/dapi/v1/time"
+	exchangeInfoEndpoint       = "/dapi/v1/exchangeInfo"
+	depthEndpoint              = "/dapi/v1/depth"
+	tradesEndpoint             = "/dapi/v1/trades"
+	historicalTradesEndpoint   = "/dapi/v1/historicalTrades"
+	aggTradesEndpoint          = "/dapi/v1/aggTrades"
+	premiumIndexEndpoint       = "/dapi/v1/premiumIndex"
+	fundingRateEndpoint        = "/dapi/v1/fundingRate"
+	openInterestEndpoint       = "/dapi/v1/openInterest"
+	klinesEndpoint             = "/dapi/v1/klines"
+	continuousKlinesEndpoint   = "/dapi/v1/continuousKlines"
+	indexPriceKlinesEndpoint   = "/dapi/v1/indexPriceKlines"
+	markPriceKlinesEndpoint    = "/dapi/v1/markPriceKlines"
+	premiumIndexKlinesEndpoint = "/dapi/v1/premiumIndexKlines"
+	ticker24hrEndpoint         = "/dapi/v1/ticker/24hr"
+	tickerPriceEndpoint        = "/dapi/v1/ticker/price"
+	bookTickerEndpoint         = "/dapi/v1/ticker/bookTicker"
+	openInterestHistEndpoint   = "/futures/data/openInterestHist"
+	topAccountRatioEndpoint    = "/futures/data/topLongShortAccountRatio"
+	topPositionRatioEndpoint   = "/futures/data/topLongShortPositionRatio"
+	globalAccountRatioEndpoint = "/futures/data/globalLongShortAccountRatio"
+	takerVolumeEndpoint        = "/futures/data/takerBuySellVol"
+	basisEndpoint              = "/futures/data/basis"
+)
+
+// The following types are shared with USDⓈ-M futures.
+type (
+	Interval     = futuresmarket.Interval
+	Period       = futuresmarket.Period
+	ContractType = futuresmarket.ContractType
+	// OrderBook is a snapshot of the order book of a symbol, in contracts.
+	OrderBook = futuresmarket.OrderBookResponse
+	// Kline is a candlestick. Volume and TakerBuyVolume are in contracts;
+	// QuoteVolume and TakerBuyQuoteVolume hold the volumes in the base coin.
+	Kline = futuresmarket.Kline
+	Basis = futuresmarket.Basis
+)
+
+const (
+	ContractPerpetual      = futuresmarket.ContractPerpetual
+	ContractCurrentQuarter = futuresmarket.ContractCurrentQuarter
+	ContractNextQuarter    = futuresmarket.ContractNextQuarter
+	// ContractAll selects every contract of a pair in the open interest
+	// and taker volume statistics.
+	ContractAll ContractType = "ALL"
+)
+
+// Market defines the interface for COIN-M market data. Quantities are in
+// contracts unless stated otherwise.
+type Market interface {
+	Ping() error
+	ServerTime() (time.Time, error)
+	// ExchangeInfo returns the trading rules of every symbol. The rate
+	// limits it lists replace the ones of the client's limiter.
+	ExchangeInfo() (*ExchangeInfo, error)
+	OrderBook(symbol string, limit int) (*OrderBook, error)
+	RecentTrades(symbol string, limit int) ([]Trade, error)
+	// HistoricalTrades returns trades from fromID on, or the most recent
+	// ones if it is 0. It requires the API key.
+	HistoricalTrades(symbol string, limit int, fromID int64) ([]Trade, error)
+	AggTrades(req *AggTradesRequest) ([]AggTrade, error)
+	Klines(req *KlinesRequest) ([]Kline, error)
+	// ContinuousKlines returns the klines of the contract of req.ContractType
+	// of req.Pair, across its successive deliveries.
+	ContinuousKlines(req *KlinesRequest) ([]Kline, error)
+	// IndexPriceKlines returns the klines of the index price of req.Pair.
+	IndexPriceKlines(req *KlinesRequest) ([]Kline, error)
+	MarkPriceKlines(req *KlinesRequest) ([]Kline, error)
+	PremiumIndexKlines(req *KlinesRequest) ([]Kline, error)
+
+	// PremiumIndex returns the mark prices of symbol, of every contract of
+	// pair if symbol is empty, or of every symbol if both are empty.
+	PremiumIndex(symbol, pair string) ([]PremiumIndex, error)
+	FundingRateHistory(req *FundingRateRequest) ([]FundingRate, error)
+	OpenInterest(symbol string) (*OpenInterest, error)
+	OpenInterestHistory(req *StatisticsRequest) ([]OpenInterestStat, error)
+	TopTraderAccountRatio(req *StatisticsRequest) ([]LongShortRatio, error)
+	TopTraderPositionRatio(req *StatisticsRequest) ([]LongShortRatio, error)
+	GlobalAccountRatio(req *StatisticsRequest) ([]LongShortRatio, error)
+	TakerVolume(req *StatisticsRequest) ([]TakerVolume, error)
+	Basis(req *StatisticsRequest) ([]Basis, error)
+
+	// Tickers24hr returns the tickers of symbol, of every contract of pair
+	// if symbol is empty, or of every symbol if both are empty.
+	Tickers24hr(symbol, pair string) ([]Ticker24hr, error)
+	PriceTickers(symbol, pair string) ([]PriceTicker, error)
+	BookTickers(symbol, pair string) ([]BookTicker, error)
+}
+
+// AggTradesRequest filters the aggregate trades of a symbol. Trades are
+// returned from FromID on if it is set, otherwise the most recent ones.
+type AggTradesRequest struct {
+	Symbol    string
+	FromID    int64
+	StartTime time.Time
+	EndTime   time.Time // EndTime is at most an hour after StartTime.
+	Limit     int       // Limit defaults to 500, with a maximum of 1000.
+}
+
+// KlinesRequest filters klines. Klines and MarkPriceKlines and
+// PremiumIndexKlines are by Symbol, IndexPriceKlines by Pair and
+// ContinuousKlines by Pair and ContractType.
+type KlinesRequest struct {
+	Symbol       string
+	Pair         string
+	ContractType ContractType
+	Interval     Interval
+	StartTime    time.Time
+	EndTime      time.Time
+	Limit        int // Limit defaults to 500, with a maximum of 1500.
+}
+
+func (r *KlinesRequest) values() url.Values {
+	v := url.Values{}
+	setIf(v, "symbol", r.Symbol)
+	setIf(v, "pair", r.Pair)
+	setIf(v, "contractType", string(r.ContractType))
+	v.Set("interval", string(r.Interval))
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt(v, "limit", r.Limit)
+	return v
+}
+
+// FundingRateRequest filters the funding rate history of a perpetual
+// symbol. Without StartTime and EndTime, the most recent rates are returned.
+type FundingRateRequest struct {
+	Symbol    string
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 100, with a maximum of 1000.
+}
+
+// StatisticsRequest filters the statistics endpoints of a pair: open
+// interest history, long/short ratios, taker volume and basis. Only the
+// last 30 days are available.
+type StatisticsRequest struct {
+	Pair string
+	// ContractType is required by the open interest history, taker volume
+	// and basis, and ignored by the long/short ratios.
+	ContractType ContractType
+	Period       Period
+	StartTime    time.Time
+	EndTime      time.Time
+	Limit        int // Limit defaults to 30, with a maximum of 500.
+}
+
+func (r *StatisticsRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("pair", r.Pair)
+	setIf(v, "contractType", string(r.ContractType))
+	v.Set("period", string(r.Period))
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt(v, "limit", r.Limit)
+	return v
+}
+
+type marketImpl struct {
+	*client.Client
+}
+
+// NewMarket creates a new Market instance.
+func NewMarket(client *client.Client) Market {
+	return &marketImpl{client}
+}
+
+// get sends a public GET request with params and decodes the response into out.
+func (m *marketImpl) get(endpoint string, params url.Values, out any) error {
+	return m.Do(context.Background(), http.MethodGet, endpoint, params, client.SecurityNone, out)
+}
+
+func (m *marketImpl) Ping() error {
+	if err := m.get(pingEndpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to ping: %w", err)
+	}
+	return nil
+}
+
+func (m *marketImpl) ServerTime() (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	if err := m.get(serverTimeEndpoint, nil, &resp); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get server time: %w", err)
+	}
+	return time.UnixMilli(resp.ServerTime), nil
+}
+
+func (m *marketImpl) ExchangeInfo() (*ExchangeInfo, error) {
+	info := new(ExchangeInfo)
+	if err := m.get(exchangeInfoEndpoint, nil, info); err != nil {
+		return nil, fmt.Errorf("failed to get exchange info: %w", err)
+	}
+
+	limits := make([]client.RateLimit, 0, len(info.RateLimits))
+	for _, rl := range info.RateLimits {
+		limit, err := client.ParseRateLimit(rl.RateLimitType, rl.Interval, rl.IntervalNum, rl.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rate limits: %w", err)
+		}
+		limits = append(limits, limit)
+	}
+	if len(limits) > 0 {
+		m.Limiter().SetLimits(limits)
+	}
+	return info, nil
+}
+
+func (m *marketImpl) OrderBook(symbol string, limit int) (*OrderBook, error) {
+	v := url.Values{"symbol": {symbol}}
+	setInt(v, "limit", limit)
+	book := new(OrderBook)
+	if err := m.get(depthEndpoint, v, book); err != nil {
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+	return book, nil
+}
+
+func (m *marketImpl) RecentTrades(symbol string, limit int) ([]Trade, error) {
+	v := url.Values{"symbol": {symbol}}
+	setInt(v, "limit", limit)
+	var trades []Trade
+	if err := m.get(tradesEndpoint, v, &trades); err != nil {
+		return nil, fmt.Errorf("failed to get recent trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (m *marketImpl) HistoricalTrades(symbol string, limit int, fromID int64) ([]Trade, error) {
+	v := url.Values{"symbol": {symbol}}
+	setInt(v, "limit", limit)
+	if fromID != 0 {
+		v.Set("fromId", strconv.FormatInt(fromID, 10))
+	}
+	var trades []Trade
+	// Historical trades require the API key, but no signature.
+	err := m.Do(context.Background(), http.MethodGet, historicalTradesEndpoint, v, client.SecurityAPIKey, &trades)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get historical trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (m *marketImpl) AggTrades(req *AggTradesRequest) ([]AggTrade, error) {
+	v := url.Values{"symbol": {req.Symbol}}
+	if req.FromID != 0 {
+		v.Set("fromId", strconv.FormatInt(req.FromID, 10))
+	}
+	setTime(v, "startTime", req.StartTime)
+	setTime(v, "endTime", req.EndTime)
+	setInt(v, "limit", req.Limit)
+	var trades []AggTrade
+	if err := m.get(aggTradesEndpoint, v, &trades); err != nil {
+		return nil, fmt.Errorf("failed to get aggregate trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (m *marketImpl) Klines(req *KlinesRequest) ([]Kline, error) {
+	return m.klines(klinesEndpoint, req, "klines")
+}
+
+func (m *marketImpl) ContinuousKlines(req *KlinesRequest) ([]Kline, error) {
+	return m.klines(continuousKlinesEndpoint, req, "continuous klines")
+}
+
+func (m *marketImpl) IndexPriceKlines(req *KlinesRequest) ([]Kline, error) {
+	return m.klines(indexPriceKlinesEndpoint, req, "index price klines")
+}
+
+func (m *marketImpl) MarkPriceKlines(req *KlinesRequest) ([]Kline, error) {
+	return m.klines(markPriceKlinesEndpoint, req, "mark price klines")
+}
+
+func (m *marketImpl) PremiumIndexKlines(req *KlinesRequest) ([]Kline, error) {
+	return m.klines(premiumIndexKlinesEndpoint, req, "premium index klines")
+}
+
+// klines returns the klines of endpoint, oldest first.
+func (m *marketImpl) klines(endpoint string, req *KlinesRequest, what string) ([]Kline, error) {
+	if err := req.Interval.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", what, err)
+	}
+	var klines []Kline
+	if err := m.get(endpoint, req.values(), &klines); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", what, err)
+	}
+	return klines, nil
+}
+
+func (m *marketImpl) PremiumIndex(symbol, pair string) ([]PremiumIndex, error) {
+	var indexes []PremiumIndex
+	if err := m.get(premiumIndexEndpoint, symbolOrPair(symbol, pair), &indexes); err != nil {
+		return nil, fmt.Errorf("failed to get premium index: %w", err)
+	}
+	return indexes, nil
+}
+
+func (m *marketImpl) FundingRateHistory(req *FundingRateRequest) ([]FundingRate, error) {
+	v := url.Values{"symbol": {req.Symbol}}
+	setTime(v, "startTime", req.StartTime)
+	setTime(v, "endTime", req.EndTime)
+	setInt(v, "limit", req.Limit)
+	var rates []FundingRate
+	if err := m.get(fundingRateEndpoint, v, &rates); err != nil {
+		return nil, fmt.Errorf("failed to get funding rate history: %w", err)
+	}
+	return rates, nil
+}
+
+func (m *marketImpl) OpenInterest(symbol string) (*OpenInterest, error) {
+	interest := new(OpenInterest)
+	if err := m.get(openInterestEndpoint, url.Values{"symbol": {symbol}}, interest); err != nil {
+		return nil, fmt.Errorf("failed to get open interest: %w", err)
+	}
+	return interest, nil
+}
+
+func (m *marketImpl) OpenInterestHistory(req *StatisticsRequest) ([]OpenInterestStat, error) {
+	var stats []OpenInterestStat
+	if err := m.get(openInterestHistEndpoint, req.values(), &stats); err != nil {
+		return nil, fmt.Errorf("failed to get open interest history: %w", err)
+	}
+	return stats, nil
+}
+
+func (m *marketImpl) TopTraderAccountRatio(req *StatisticsRequest) ([]LongShortRatio, error) {
+	return m.ratio(topAccountRatioEndpoint, req, "top trader account ratio")
+}
+
+func (m *marketImpl) TopTraderPositionRatio(req *StatisticsRequest) ([]LongShortRatio, error) {
+	return m.ratio(topPositionRatioEndpoint, req, "top trader position ratio")
+}
+
+func (m *marketImpl) GlobalAccountRatio(req *StatisticsRequest) ([]LongShortRatio, error) {
+	return m.ratio(globalAccountRatioEndpoint, req, "global account ratio")
+}
+
+// ratio returns the long/short ratios of endpoint, which take no contract type.
+func (m *marketImpl) ratio(endpoint string, req *StatisticsRequest, what string) ([]LongShortRatio, error) {
+	v := req.values()
+	v.Del("contractType")
+	var ratios []LongShortRatio
+	if err := m.get(endpoint, v, &ratios); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", what, err)
+	}
+	return ratios, nil
+}
+
+func (m *marketImpl) TakerVolume(req *StatisticsRequest) ([]TakerVolume, error) {
+	var volumes []TakerVolume
+	if err := m.get(takerVolumeEndpoint, req.values(), &volumes); err != nil {
+		return nil, fmt.Errorf("failed to get taker volume: %w", err)
+	}
+	return volumes, nil
+}
+
+func (m *marketImpl) Basis(req *StatisticsRequest) ([]Basis, error) {
+	var basis []Basis
+	if err := m.get(basisEndpoint, req.values(), &basis); err != nil {
+		return nil, fmt.Errorf("failed to get basis: %w", err)
+	}
+	return basis, nil
+}
+
+func (m *marketImpl) Tickers24hr(symbol, pair string) ([]Ticker24hr, error) {
+	var tickers []Ticker24hr
+	if err := m.get(ticker24hrEndpoint, symbolOrPair(symbol, pair), &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get 24hr tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+func (m *marketImpl) PriceTickers(symbol, pair string) ([]PriceTicker, error) {
+	var tickers []PriceTicker
+	if err := m.get(tickerPriceEndpoint, symbolOrPair(symbol, pair), &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get price tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+func (m *marketImpl) BookTickers(symbol, pair string) ([]BookTicker, error) {
+	var tickers []BookTicker
+	if err := m.get(bookTickerEndpoint, symbolOrPair(symbol, pair), &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get book tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+// symbolOrPair returns the parameters of the endpoints queried by symbol
+// or by pair. The symbol wins when both are set.
+func symbolOrPair(symbol, pair string) url.Values {
+	v := url.Values{}
+	if symbol != "" {
+		v.Set("symbol", symbol)
+	} else {
+		setIf(v, "pair", pair)
+	}
+	return v
+}
+
+// setIf sets key unless value is empty.
+func setIf(v url.Values, key, value string) {
+	if value != "" {
+		v.Set(key, value)
+	}
+}
+
+// setInt sets key unless n is 0 or less.
+func setInt(v url.Values, key string, n int) {
+	if n > 0 {
+		v.Set(key, strconv.Itoa(n))
+	}
+}
+
+// setTime sets key to t in milliseconds, unless t is zero.
+func setTime(v url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		v.Set(key, strconv.FormatInt(t.UnixMilli(), 10))
+	}
+}
@@ -0,0 +1,55 @@
+package market
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarket_ExchangeInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/dapi/v1/exchangeInfo", r.URL.Path)
+		_, _ = w.Write([]byte(`{"timezone":"UTC","rateLimits":[
+			{"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":2400}],
+			"symbols":[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","contractType":"PERPETUAL","contractSize":100,"marginAsset":"BTC"},
+			{"symbol":"ETHUSD_250627","pair":"ETHUSD","contractType":"CURRENT_QUARTER","contractSize":10,"deliveryDate":1751011200000}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := client.NewClient(client.Config{BaseURL: srv.URL})
+
+	info, err := NewMarket(c).ExchangeInfo()
+	require.NoError(t, err)
+	perp := info.Symbol("BTCUSD_PERP")
+	require.NotNil(t, perp)
+	assert.True(t, perp.Perpetual())
+	assert.Equal(t, "100", perp.ContractValue().String())
+
+	quarter := info.Symbol("ETHUSD_250627")
+	require.NotNil(t, quarter)
+	assert.False(t, quarter.Perpetual())
+	assert.Equal(t, int64(1751011200000), quarter.Delivery().UnixMilli())
+	assert.Nil(t, info.Symbol("BTCUSDT"))
+
+	usage := c.Limiter().Usage()
+	require.Len(t, usage, 1)
+	assert.Equal(t, 2400, usage[0].Limit)
+}
+
+func TestMarket_PremiumIndexByPair(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/dapi/v1/premiumIndex", r.URL.Path)
+		assert.Equal(t, "BTCUSD", r.URL.Query().Get("pair"))
+		_, _ = w.Write([]byte(`[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","markPrice":"60000","lastFundingRate":"0.0001"},
+			{"symbol":"BTCUSD_250627","pair":"BTCUSD","markPrice":"61000","lastFundingRate":""}]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	indexes, err := NewMarket(client.NewClient(client.Config{BaseURL: srv.URL})).PremiumIndex("", "BTCUSD")
+	require.NoError(t, err)
+	require.Len(t, indexes, 2)
+	assert.Equal(t, "61000", indexes[1].MarkPrice)
+}
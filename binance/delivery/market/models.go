@@ -0,0 +1,224 @@
+package market
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RateLimit is a rate limit listed by the exchange info.
+type RateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int    `json:"intervalNum"`
+	Limit         int    `json:"limit"`
+}
+
+// SymbolFilter is a trading rule of a symbol. Which fields are set depends
+// on FilterType, such as PRICE_FILTER, LOT_SIZE or PERCENT_PRICE.
+type SymbolFilter struct {
+	FilterType        string `json:"filterType"`
+	MinPrice          string `json:"minPrice,omitempty"`
+	MaxPrice          string `json:"maxPrice,omitempty"`
+	TickSize          string `json:"tickSize,omitempty"`
+	MinQty            string `json:"minQty,omitempty"`
+	MaxQty            string `json:"maxQty,omitempty"`
+	StepSize          string `json:"stepSize,omitempty"`
+	Limit             int    `json:"limit,omitempty"`
+	MultiplierUp      string `json:"multiplierUp,omitempty"`
+	MultiplierDown    string `json:"multiplierDown,omitempty"`
+	MultiplierDecimal string `json:"multiplierDecimal,omitempty"`
+}
+
+// SymbolInfo is the trading rules of a symbol. Perpetual symbols end in
+// _PERP, such as BTCUSD_PERP; delivery symbols end in their delivery date,
+// such as BTCUSD_250627.
+type SymbolInfo struct {
+	Symbol       string       `json:"symbol"`
+	Pair         string       `json:"pair"`
+	ContractType ContractType `json:"contractType"`
+	// ContractSize is the value of a contract in USD.
+	ContractSize      int    `json:"contractSize"`
+	DeliveryDate      int64  `json:"deliveryDate"`
+	OnboardDate       int64  `json:"onboardDate"`
+	ContractStatus    string `json:"contractStatus"`
+	MarginAsset       string `json:"marginAsset"`
+	BaseAsset         string `json:"baseAsset"`
+	QuoteAsset        string `json:"quoteAsset"`
+	UnderlyingType    string `json:"underlyingType"`
+	PricePrecision    int    `json:"pricePrecision"`
+	QuantityPrecision int    `json:"quantityPrecision"`
+	// EqualQtyPrecision is the precision of quantities in the base coin.
+	EqualQtyPrecision     int            `json:"equalQtyPrecision"`
+	MaintMarginPercent    string         `json:"maintMarginPercent"`
+	RequiredMarginPercent string         `json:"requiredMarginPercent"`
+	TriggerProtect        string         `json:"triggerProtect"`
+	LiquidationFee        string         `json:"liquidationFee"`
+	MarketTakeBound       string         `json:"marketTakeBound"`
+	MaxMoveOrderLimit     int            `json:"maxMoveOrderLimit"`
+	OrderTypes            []string       `json:"orderTypes"`
+	TimeInForce           []string       `json:"timeInForce"`
+	Filters               []SymbolFilter `json:"filters"`
+}
+
+// Perpetual reports whether s never delivers.
+func (s *SymbolInfo) Perpetual() bool {
+	return s.ContractType == ContractPerpetual
+}
+
+// Delivery returns the time s delivers. It is far in the future for
+// perpetual symbols.
+func (s *SymbolInfo) Delivery() time.Time {
+	return time.UnixMilli(s.DeliveryDate)
+}
+
+// ContractValue returns the value of a contract of s in USD.
+func (s *SymbolInfo) ContractValue() decimal.Decimal {
+	return decimal.NewFromInt(int64(s.ContractSize))
+}
+
+// ExchangeInfo is the trading rules and rate limits of the exchange.
+type ExchangeInfo struct {
+	Timezone   string       `json:"timezone"`
+	ServerTime int64        `json:"serverTime"`
+	RateLimits []RateLimit  `json:"rateLimits"`
+	Symbols    []SymbolInfo `json:"symbols"`
+}
+
+// Symbol returns the rules of symbol, or nil if it is not listed.
+func (e *ExchangeInfo) Symbol(symbol string) *SymbolInfo {
+	for i := range e.Symbols {
+		if e.Symbols[i].Symbol == symbol {
+			return &e.Symbols[i]
+		}
+	}
+	return nil
+}
+
+// Trade is a public trade. Qty is in contracts and BaseQty in the base coin.
+type Trade struct {
+	ID           int64  `json:"id"`
+	Price        string `json:"price"`
+	Qty          string `json:"qty"`
+	BaseQty      string `json:"baseQty"`
+	Time         int64  `json:"time"`
+	IsBuyerMaker bool   `json:"isBuyerMaker"`
+}
+
+// AggTrade is the trades of a taker order at one price.
+type AggTrade struct {
+	ID            int64  `json:"a"`
+	Price         string `json:"p"`
+	Qty           string `json:"q"`
+	FirstTradeID  int64  `json:"f"`
+	LastTradeID   int64  `json:"l"`
+	Time          int64  `json:"T"`
+	WasBuyerMaker bool   `json:"m"`
+}
+
+// PremiumIndex is the mark and index price of a symbol, with the funding
+// rate of perpetual symbols.
+type PremiumIndex struct {
+	Symbol               string `json:"symbol"`
+	Pair                 string `json:"pair"`
+	MarkPrice            string `json:"markPrice"`
+	IndexPrice           string `json:"indexPrice"`
+	EstimatedSettlePrice string `json:"estimatedSettlePrice"`
+	LastFundingRate      string `json:"lastFundingRate"` // LastFundingRate is empty for delivery symbols.
+	InterestRate         string `json:"interestRate"`
+	NextFundingTime      int64  `json:"nextFundingTime"`
+	Time                 int64  `json:"time"`
+}
+
+// FundingRate is a funding rate that was applied to a perpetual symbol.
+type FundingRate struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime int64  `json:"fundingTime"`
+}
+
+// OpenInterest is the present open interest of a symbol, in contracts.
+type OpenInterest struct {
+	Symbol       string       `json:"symbol"`
+	Pair         string       `json:"pair"`
+	ContractType ContractType `json:"contractType"`
+	OpenInterest string       `json:"openInterest"`
+	Time         int64        `json:"time"`
+}
+
+// OpenInterestStat is the open interest of a pair's contracts over a
+// period: in contracts, and valued in the base coin.
+type OpenInterestStat struct {
+	Pair                 string       `json:"pair"`
+	ContractType         ContractType `json:"contractType"`
+	SumOpenInterest      string       `json:"sumOpenInterest"`
+	SumOpenInterestValue string       `json:"sumOpenInterestValue"`
+	Timestamp            int64        `json:"timestamp"`
+}
+
+// LongShortRatio is the ratio of long to short accounts or positions of a
+// pair over a period.
+type LongShortRatio struct {
+	Pair           string `json:"pair"`
+	LongShortRatio string `json:"longShortRatio"`
+	// LongAccount and ShortAccount are shares of 1; the position ratio
+	// names them LongPosition and ShortPosition.
+	LongAccount   string `json:"longAccount"`
+	ShortAccount  string `json:"shortAccount"`
+	LongPosition  string `json:"longPosition"`
+	ShortPosition string `json:"shortPosition"`
+	Timestamp     int64  `json:"timestamp"`
+}
+
+// TakerVolume is the volume bought and sold by takers over a period, in
+// contracts and valued in the base coin.
+type TakerVolume struct {
+	Pair              string       `json:"pair"`
+	ContractType      ContractType `json:"contractType"`
+	TakerBuyVol       string       `json:"takerBuyVol"`
+	TakerSellVol      string       `json:"takerSellVol"`
+	TakerBuyVolValue  string       `json:"takerBuyVolValue"`
+	TakerSellVolValue string       `json:"takerSellVolValue"`
+	Timestamp         int64        `json:"timestamp"`
+}
+
+// Ticker24hr is the price change statistics of a symbol over the last 24
+// hours. Volume is in contracts and BaseVolume in the base coin.
+type Ticker24hr struct {
+	Symbol             string `json:"symbol"`
+	Pair               string `json:"pair"`
+	PriceChange        string `json:"priceChange"`
+	PriceChangePercent string `json:"priceChangePercent"`
+	WeightedAvgPrice   string `json:"weightedAvgPrice"`
+	LastPrice          string `json:"lastPrice"`
+	LastQty            string `json:"lastQty"`
+	OpenPrice          string `json:"openPrice"`
+	HighPrice          string `json:"highPrice"`
+	LowPrice           string `json:"lowPrice"`
+	Volume             string `json:"volume"`
+	BaseVolume         string `json:"baseVolume"`
+	OpenTime           int64  `json:"openTime"`
+	CloseTime          int64  `json:"closeTime"`
+	FirstID            int64  `json:"firstId"`
+	LastID             int64  `json:"lastId"`
+	Count              int64  `json:"count"`
+}
+
+// PriceTicker is the last price of a symbol.
+type PriceTicker struct {
+	Symbol string `json:"symbol"`
+	Pair   string `json:"ps"`
+	Price  string `json:"price"`
+	Time   int64  `json:"time"`
+}
+
+// BookTicker is the best bid and ask of a symbol.
+type BookTicker struct {
+	Symbol   string `json:"symbol"`
+	Pair     string `json:"pair"`
+	BidPrice string `json:"bidPrice"`
+	BidQty   string `json:"bidQty"`
+	AskPrice string `json:"askPrice"`
+	AskQty   string `json:"askQty"`
+	Time     int64  `json:"time"`
+}
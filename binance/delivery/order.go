@@ -0,0 +1,492 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+)
+
+// Endpoints used for order management.
+const (
+	orderEndpoint           = "/dapi/v1/order"
+	batchOrdersEndpoint     = "/dapi/v1/batchOrders"
+	allOpenOrdersEndpoint   = "/dapi/v1/allOpenOrders"
+	openOrdersEndpoint      = "/dapi/v1/openOrders"
+	allOrdersEndpoint       = "/dapi/v1/allOrders"
+	countdownCancelEndpoint = "/dapi/v1/countdownCancelAll"
+)
+
+// MaxBatchOrders is the largest number of orders a batch request accepts.
+const MaxBatchOrders = 5
+
+// ErrInvalidOrder is returned when an order request is missing a parameter
+// its type requires, before it is sent.
+var ErrInvalidOrder = errors.New("invalid order")
+
+// Orders defines the interface for COIN-M order management. Quantities are
+// numbers of contracts.
+type Orders interface {
+	NewOrder(req *NewOrderRequest) (*Order, error)
+	ModifyOrder(req *ModifyOrderRequest) (*Order, error)
+	CancelOrder(query OrderQuery) (*Order, error)
+	// CancelAllOpenOrders cancels every open order of symbol.
+	CancelAllOpenOrders(symbol string) error
+	QueryOrder(query OrderQuery) (*Order, error)
+	// OpenOrders returns the open orders of symbol, of every contract of
+	// pair if symbol is empty, or of every symbol if both are empty.
+	OpenOrders(symbol, pair string) ([]Order, error)
+	AllOrders(req *AllOrdersRequest) ([]Order, error)
+	// NewBatchOrders places up to MaxBatchOrders orders. The results are in
+	// the order of reqs; each one holds the order or the reason it failed.
+	NewBatchOrders(reqs []NewOrderRequest) ([]BatchResult, error)
+	ModifyBatchOrders(reqs []ModifyOrderRequest) ([]BatchResult, error)
+	// CancelBatchOrders cancels up to 10 orders of symbol by ID.
+	CancelBatchOrders(symbol string, orderIDs []int64) ([]BatchResult, error)
+	// AutoCancelAllOpenOrders cancels every open order of symbol unless it is
+	// called again within countdown. A countdown of 0 stops the timer.
+	AutoCancelAllOpenOrders(symbol string, countdown time.Duration) error
+}
+
+// NewOrderRequest is a new order. The parameters required depend on Type:
+//
+//   - LIMIT: TimeInForce, Quantity and Price.
+//   - MARKET: Quantity.
+//   - STOP and TAKE_PROFIT: Quantity, Price and StopPrice.
+//   - STOP_MARKET and TAKE_PROFIT_MARKET: StopPrice, and Quantity unless ClosePosition is set.
+//   - TRAILING_STOP_MARKET: Quantity and CallbackRate.
+type NewOrderRequest struct {
+	Symbol           string // Symbol is a perpetual such as BTCUSD_PERP, or a delivery contract such as BTCUSD_250627.
+	Side             Side
+	PositionSide     PositionSide // PositionSide defaults to BOTH; it is required in hedge mode.
+	Type             OrderType
+	TimeInForce      TimeInForce
+	Quantity         string // Quantity is a whole number of contracts.
+	Price            string
+	ReduceOnly       bool // ReduceOnly cannot be used in hedge mode.
+	NewClientOrderID string
+	StopPrice        string
+	// ClosePosition closes the whole position when a STOP_MARKET or
+	// TAKE_PROFIT_MARKET order triggers. It excludes Quantity and ReduceOnly.
+	ClosePosition    bool
+	ActivationPrice  string // ActivationPrice of a TRAILING_STOP_MARKET order; defaults to the last price.
+	CallbackRate     string // CallbackRate of a TRAILING_STOP_MARKET order, in percent from 0.1 to 10.
+	WorkingType      WorkingType
+	PriceProtect     bool
+	NewOrderRespType ResponseType
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if r is missing a
+// parameter its type requires.
+func (r *NewOrderRequest) Validate() error {
+	missing := func(param string) error {
+		return fmt.Errorf("%w: %s orders require %s", ErrInvalidOrder, r.Type, param)
+	}
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	if r.Side != SideBuy && r.Side != SideSell {
+		return fmt.Errorf("%w: unsupported side %q", ErrInvalidOrder, string(r.Side))
+	}
+	if r.ClosePosition {
+		if r.Type != OrderTypeStopMarket && r.Type != OrderTypeTakeProfitMarket {
+			return fmt.Errorf("%w: closePosition is only supported by STOP_MARKET and TAKE_PROFIT_MARKET orders", ErrInvalidOrder)
+		}
+		if r.Quantity != "" || r.ReduceOnly {
+			return fmt.Errorf("%w: closePosition cannot be used with quantity or reduceOnly", ErrInvalidOrder)
+		}
+	} else if r.Quantity == "" {
+		return missing("quantity")
+	}
+	switch r.TimeInForce {
+	case "", TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForceGTX:
+	default:
+		return fmt.Errorf("%w: unsupported time in force %q", ErrInvalidOrder, string(r.TimeInForce))
+	}
+
+	switch r.Type {
+	case OrderTypeLimit:
+		if r.TimeInForce == "" {
+			return missing("timeInForce")
+		}
+		if r.Price == "" {
+			return missing("price")
+		}
+	case OrderTypeMarket:
+	case OrderTypeStop, OrderTypeTakeProfit:
+		if r.Price == "" {
+			return missing("price")
+		}
+		if r.StopPrice == "" {
+			return missing("stopPrice")
+		}
+	case OrderTypeStopMarket, OrderTypeTakeProfitMarket:
+		if r.StopPrice == "" {
+			return missing("stopPrice")
+		}
+	case OrderTypeTrailingStopMarket:
+		if r.CallbackRate == "" {
+			return missing("callbackRate")
+		}
+	default:
+		return fmt.Errorf("%w: unsupported order type %q", ErrInvalidOrder, string(r.Type))
+	}
+	return nil
+}
+
+// values returns the request parameters of r.
+func (r *NewOrderRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	v.Set("side", string(r.Side))
+	v.Set("type", string(r.Type))
+	setIf(v, "positionSide", string(r.PositionSide))
+	setIf(v, "timeInForce", string(r.TimeInForce))
+	setIf(v, "quantity", r.Quantity)
+	setIf(v, "price", r.Price)
+	setIf(v, "newClientOrderId", r.NewClientOrderID)
+	setIf(v, "stopPrice", r.StopPrice)
+	setIf(v, "activationPrice", r.ActivationPrice)
+	setIf(v, "callbackRate", r.CallbackRate)
+	setIf(v, "workingType", string(r.WorkingType))
+	setIf(v, "newOrderRespType", string(r.NewOrderRespType))
+	if r.ReduceOnly {
+		v.Set("reduceOnly", "true")
+	}
+	if r.ClosePosition {
+		v.Set("closePosition", "true")
+	}
+	if r.PriceProtect {
+		v.Set("priceProtect", "TRUE")
+	}
+	return v
+}
+
+// ModifyOrderRequest changes the price or quantity of an open LIMIT order.
+// The order is identified by OrderID or OrigClientOrderID.
+type ModifyOrderRequest struct {
+	Symbol            string
+	OrderID           int64
+	OrigClientOrderID string
+	Side              Side
+	Quantity          string // Quantity is optional if Price is set.
+	Price             string // Price is optional if Quantity is set.
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if r is incomplete.
+func (r *ModifyOrderRequest) Validate() error {
+	if err := (OrderQuery{Symbol: r.Symbol, OrderID: r.OrderID, OrigClientOrderID: r.OrigClientOrderID}).Validate(); err != nil {
+		return err
+	}
+	if r.Side != SideBuy && r.Side != SideSell {
+		return fmt.Errorf("%w: unsupported side %q", ErrInvalidOrder, string(r.Side))
+	}
+	if r.Quantity == "" && r.Price == "" {
+		return fmt.Errorf("%w: quantity or price is required", ErrInvalidOrder)
+	}
+	return nil
+}
+
+func (r *ModifyOrderRequest) values() url.Values {
+	v := OrderQuery{Symbol: r.Symbol, OrderID: r.OrderID, OrigClientOrderID: r.OrigClientOrderID}.values()
+	v.Set("side", string(r.Side))
+	setIf(v, "quantity", r.Quantity)
+	setIf(v, "price", r.Price)
+	return v
+}
+
+// OrderQuery identifies an order by OrderID or OrigClientOrderID.
+type OrderQuery struct {
+	Symbol            string
+	OrderID           int64
+	OrigClientOrderID string
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if q identifies no order.
+func (q OrderQuery) Validate() error {
+	if q.Symbol == "" {
+		return fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	if q.OrderID == 0 && q.OrigClientOrderID == "" {
+		return fmt.Errorf("%w: orderId or origClientOrderId is required", ErrInvalidOrder)
+	}
+	return nil
+}
+
+func (q OrderQuery) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", q.Symbol)
+	setInt64(v, "orderId", q.OrderID)
+	setIf(v, "origClientOrderId", q.OrigClientOrderID)
+	return v
+}
+
+// AllOrdersRequest filters the orders returned by AllOrders, of Symbol or
+// of every contract of Pair. Orders are returned from OrderID on if it is
+// set, otherwise the most recent ones.
+type AllOrdersRequest struct {
+	Symbol    string
+	Pair      string
+	OrderID   int64 // OrderID can only be used with Symbol.
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 50, with a maximum of 100.
+}
+
+func (r *AllOrdersRequest) values() url.Values {
+	v := url.Values{}
+	setIf(v, "symbol", r.Symbol)
+	setIf(v, "pair", r.Pair)
+	setInt64(v, "orderId", r.OrderID)
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt64(v, "limit", int64(r.Limit))
+	return v
+}
+
+// Order is the state of an order. Quantities are in contracts, and CumBase
+// is the filled value in the base coin.
+type Order struct {
+	OrderID       int64        `json:"orderId"`
+	Symbol        string       `json:"symbol"`
+	Pair          string       `json:"pair"`
+	Status        OrderStatus  `json:"status"`
+	ClientOrderID string       `json:"clientOrderId"`
+	Price         string       `json:"price"`
+	AvgPrice      string       `json:"avgPrice"`
+	OrigQty       string       `json:"origQty"`
+	ExecutedQty   string       `json:"executedQty"`
+	CumQty        string       `json:"cumQty"`
+	CumBase       string       `json:"cumBase"`
+	TimeInForce   TimeInForce  `json:"timeInForce"`
+	Type          OrderType    `json:"type"`
+	OrigType      OrderType    `json:"origType"`
+	ReduceOnly    bool         `json:"reduceOnly"`
+	ClosePosition bool         `json:"closePosition"`
+	Side          Side         `json:"side"`
+	PositionSide  PositionSide `json:"positionSide"`
+	StopPrice     string       `json:"stopPrice"`
+	WorkingType   WorkingType  `json:"workingType"`
+	PriceProtect  bool         `json:"priceProtect"`
+	ActivatePrice string       `json:"activatePrice"`
+	PriceRate     string       `json:"priceRate"`
+	Time          int64        `json:"time"`
+	UpdateTime    int64        `json:"updateTime"`
+}
+
+// BatchResult is the outcome of one order of a batch request.
+type BatchResult struct {
+	Order *Order
+	Err   error
+}
+
+// batchItem is one element of a batch response: an order or an error.
+type batchItem struct {
+	Order
+	Code *int   `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// ordersImpl implements Orders using Binance COIN-M futures API.
+type ordersImpl struct {
+	*client.Client
+}
+
+// NewOrders creates a new Orders instance.
+func NewOrders(client *client.Client) Orders {
+	return &ordersImpl{client}
+}
+
+// signed sends a signed request with params and decodes the response into out.
+func (o *ordersImpl) signed(method, endpoint string, params url.Values, out any) error {
+	return o.Do(context.Background(), method, endpoint, params, client.SecuritySigned, out)
+}
+
+func (o *ordersImpl) NewOrder(req *NewOrderRequest) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var order Order
+	if err := o.signed(http.MethodPost, orderEndpoint, req.values(), &order); err != nil {
+		return nil, fmt.Errorf("failed to place order: %w", err)
+	}
+	return &order, nil
+}
+
+func (o *ordersImpl) ModifyOrder(req *ModifyOrderRequest) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var order Order
+	if err := o.signed(http.MethodPut, orderEndpoint, req.values(), &order); err != nil {
+		return nil, fmt.Errorf("failed to modify order: %w", err)
+	}
+	return &order, nil
+}
+
+func (o *ordersImpl) CancelOrder(query OrderQuery) (*Order, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	var order Order
+	if err := o.signed(http.MethodDelete, orderEndpoint, query.values(), &order); err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+	return &order, nil
+}
+
+func (o *ordersImpl) CancelAllOpenOrders(symbol string) error {
+	if err := o.signed(http.MethodDelete, allOpenOrdersEndpoint, url.Values{"symbol": {symbol}}, nil); err != nil {
+		return fmt.Errorf("failed to cancel open orders: %w", err)
+	}
+	return nil
+}
+
+func (o *ordersImpl) QueryOrder(query OrderQuery) (*Order, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	var order Order
+	if err := o.signed(http.MethodGet, orderEndpoint, query.values(), &order); err != nil {
+		return nil, fmt.Errorf("failed to query order: %w", err)
+	}
+	return &order, nil
+}
+
+func (o *ordersImpl) OpenOrders(symbol, pair string) ([]Order, error) {
+	v := url.Values{}
+	setIf(v, "symbol", symbol)
+	setIf(v, "pair", pair)
+	var orders []Order
+	if err := o.signed(http.MethodGet, openOrdersEndpoint, v, &orders); err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+	return orders, nil
+}
+
+func (o *ordersImpl) AllOrders(req *AllOrdersRequest) ([]Order, error) {
+	if req.Symbol == "" && req.Pair == "" {
+		return nil, fmt.Errorf("%w: symbol or pair is required", ErrInvalidOrder)
+	}
+	var orders []Order
+	if err := o.signed(http.MethodGet, allOrdersEndpoint, req.values(), &orders); err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	return orders, nil
+}
+
+func (o *ordersImpl) NewBatchOrders(reqs []NewOrderRequest) ([]BatchResult, error) {
+	if len(reqs) == 0 || len(reqs) > MaxBatchOrders {
+		return nil, fmt.Errorf("%w: a batch holds 1 to %d orders", ErrInvalidOrder, MaxBatchOrders)
+	}
+	batch := make([]url.Values, len(reqs))
+	for i := range reqs {
+		if err := reqs[i].Validate(); err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		batch[i] = reqs[i].values()
+	}
+	return o.batch(http.MethodPost, "batchOrders", batch, "failed to place orders")
+}
+
+func (o *ordersImpl) ModifyBatchOrders(reqs []ModifyOrderRequest) ([]BatchResult, error) {
+	if len(reqs) == 0 || len(reqs) > MaxBatchOrders {
+		return nil, fmt.Errorf("%w: a batch holds 1 to %d orders", ErrInvalidOrder, MaxBatchOrders)
+	}
+	batch := make([]url.Values, len(reqs))
+	for i := range reqs {
+		if err := reqs[i].Validate(); err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		batch[i] = reqs[i].values()
+	}
+	return o.batch(http.MethodPut, "batchOrders", batch, "failed to modify orders")
+}
+
+func (o *ordersImpl) CancelBatchOrders(symbol string, orderIDs []int64) ([]BatchResult, error) {
+	const maxCancel = 10
+	if len(orderIDs) == 0 || len(orderIDs) > maxCancel {
+		return nil, fmt.Errorf("%w: a batch cancels 1 to %d orders", ErrInvalidOrder, maxCancel)
+	}
+	ids, err := json.Marshal(orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	v := url.Values{"symbol": {symbol}, "orderIdList": {string(ids)}}
+	var items []batchItem
+	if err := o.signed(http.MethodDelete, batchOrdersEndpoint, v, &items); err != nil {
+		return nil, fmt.Errorf("failed to cancel orders: %w", err)
+	}
+	return batchResults(items), nil
+}
+
+// batch sends the orders of batch as the JSON list parameter param.
+func (o *ordersImpl) batch(method, param string, batch []url.Values, failure string) ([]BatchResult, error) {
+	list := make([]map[string]string, len(batch))
+	for i, v := range batch {
+		list[i] = make(map[string]string, len(v))
+		for key := range v {
+			list[i][key] = v.Get(key)
+		}
+	}
+	encoded, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	var items []batchItem
+	if err := o.signed(method, batchOrdersEndpoint, url.Values{param: {string(encoded)}}, &items); err != nil {
+		return nil, fmt.Errorf("%s: %w", failure, err)
+	}
+	return batchResults(items), nil
+}
+
+func batchResults(items []batchItem) []BatchResult {
+	results := make([]BatchResult, len(items))
+	for i := range items {
+		if items[i].Code != nil && *items[i].Code != 0 {
+			results[i].Err = fmt.Errorf("order rejected with code %d: %s", *items[i].Code, items[i].Msg)
+			continue
+		}
+		order := items[i].Order
+		results[i].Order = &order
+	}
+	return results
+}
+
+func (o *ordersImpl) AutoCancelAllOpenOrders(symbol string, countdown time.Duration) error {
+	v := url.Values{
+		"symbol":        {symbol},
+		"countdownTime": {strconv.FormatInt(countdown.Milliseconds(), 10)},
+	}
+	if err := o.signed(http.MethodPost, countdownCancelEndpoint, v, nil); err != nil {
+		return fmt.Errorf("failed to set auto-cancel countdown: %w", err)
+	}
+	return nil
+}
+
+// setIf sets key to value unless value is empty.
+func setIf(v url.Values, key, value string) {
+	if value != "" {
+		v.Set(key, value)
+	}
+}
+
+// setInt64 sets key to n unless n is 0.
+func setInt64(v url.Values, key string, n int64) {
+	if n != 0 {
+		v.Set(key, strconv.FormatInt(n, 10))
+	}
+}
+
+// setTime sets key to t in milliseconds unless t is zero.
+func setTime(v url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		v.Set(key, strconv.FormatInt(t.UnixMilli(), 10))
+	}
+}
@@ -0,0 +1,63 @@
+package delivery
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a COIN-M client whose requests are answered by handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+	return NewClient(clienttest.NewServerConfig(t, handler), false)
+}
+
+func TestOrders_NewOrder(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/dapi/v1/order", r.URL.Path)
+		params := clienttest.SignedParams(t, r)
+		assert.Equal(t, "BTCUSD_PERP", params.Get("symbol"))
+		assert.Equal(t, "3", params.Get("quantity"))
+		assert.Equal(t, "GTX", params.Get("timeInForce"))
+		_, _ = w.Write([]byte(`{"orderId":9,"symbol":"BTCUSD_PERP","pair":"BTCUSD","status":"NEW",
+			"origQty":"3","executedQty":"0","cumBase":"0","type":"LIMIT","side":"BUY"}`))
+	})
+	orders := NewOrders(c)
+
+	req := &NewOrderRequest{
+		Symbol: "BTCUSD_PERP", Side: SideBuy, Type: OrderTypeLimit,
+		TimeInForce: "GTD", Quantity: "3", Price: "60000",
+	}
+	_, err := orders.NewOrder(req)
+	require.ErrorIs(t, err, ErrInvalidOrder)
+
+	req.TimeInForce = TimeInForceGTX
+	order, err := orders.NewOrder(req)
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSD", order.Pair)
+	assert.Equal(t, OrderStatusNew, order.Status)
+	assert.Equal(t, 1, c.Limiter().Usage()[1].Used)
+}
+
+func TestOrders_AllOrdersByPair(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/dapi/v1/allOrders", r.URL.Path)
+		assert.Equal(t, "BTCUSD", r.URL.Query().Get("pair"))
+		assert.Empty(t, r.URL.Query().Get("symbol"))
+		_, _ = w.Write([]byte(`[{"orderId":1,"symbol":"BTCUSD_PERP"},{"orderId":2,"symbol":"BTCUSD_250627"}]`))
+	})
+	orders := NewOrders(c)
+
+	_, err := orders.AllOrders(&AllOrdersRequest{})
+	require.ErrorIs(t, err, ErrInvalidOrder)
+
+	res, err := orders.AllOrders(&AllOrdersRequest{Pair: "BTCUSD"})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "BTCUSD_250627", res[1].Symbol)
+	assert.Equal(t, 40, c.Limiter().Usage()[0].Used)
+}
@@ -0,0 +1,124 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+)
+
+// DefaultRateLimits are the COIN-M limits in force when exchange info has
+// not been loaded.
+var DefaultRateLimits = []client.RateLimit{
+	{Type: client.RateLimitRequestWeight, Interval: time.Minute, Limit: 2400},
+	{Type: client.RateLimitOrders, Interval: time.Minute, Limit: 1200},
+}
+
+// endpointWeights are the weights of the COIN-M endpoints that do not
+// weigh 1, keyed by method and path. Weights depending on the parameters
+// are computed by RequestWeight.
+var endpointWeights = map[string]int{
+	"GET /dapi/v1/trades":              5,
+	"GET /dapi/v1/historicalTrades":    20,
+	"GET /dapi/v1/aggTrades":           20,
+	"GET /dapi/v1/premiumIndex":        10,
+	"GET /dapi/v1/account":             5,
+	"GET /dapi/v1/income":              20,
+	"GET /dapi/v1/commissionRate":      20,
+	"GET /dapi/v1/adlQuantile":         5,
+	"GET /dapi/v1/positionSide/dual":   30,
+	"POST /dapi/v1/batchOrders":        5,
+	"PUT /dapi/v1/batchOrders":         5,
+	"POST /dapi/v1/countdownCancelAll": 10,
+}
+
+// symbolWeights are the weights of the endpoints that weigh more when
+// queried by pair or for every symbol: the weight with a symbol, then
+// without.
+var symbolWeights = map[string][2]int{
+	"GET /dapi/v1/ticker/24hr":       {1, 40},
+	"GET /dapi/v1/ticker/price":      {1, 2},
+	"GET /dapi/v1/ticker/bookTicker": {2, 5},
+	"GET /dapi/v1/openOrders":        {1, 40},
+	"GET /dapi/v1/allOrders":         {20, 40},
+	"GET /dapi/v1/userTrades":        {20, 40},
+}
+
+// klineEndpoints weigh by the number of klines requested.
+var klineEndpoints = map[string]bool{
+	"GET /dapi/v1/klines":             true,
+	"GET /dapi/v1/continuousKlines":   true,
+	"GET /dapi/v1/indexPriceKlines":   true,
+	"GET /dapi/v1/markPriceKlines":    true,
+	"GET /dapi/v1/premiumIndexKlines": true,
+}
+
+// RequestWeight returns the weight of a COIN-M request and the number of
+// orders it places.
+func RequestWeight(method, path string, params url.Values) (weight, orders int) {
+	key := method + " " + path
+	switch key {
+	case "POST /dapi/v1/order", "PUT /dapi/v1/order":
+		orders = 1
+	case "POST /dapi/v1/batchOrders", "PUT /dapi/v1/batchOrders":
+		var batch []json.RawMessage
+		if err := json.Unmarshal([]byte(params.Get("batchOrders")), &batch); err == nil {
+			orders = len(batch)
+		}
+	}
+
+	switch {
+	case key == "GET /dapi/v1/depth":
+		return depthWeight(params.Get("limit")), orders
+	case klineEndpoints[key]:
+		return klineWeight(params.Get("limit")), orders
+	}
+	if w, ok := endpointWeights[key]; ok {
+		return w, orders
+	}
+	if w, ok := symbolWeights[key]; ok {
+		if params.Get("symbol") != "" {
+			return w[0], orders
+		}
+		return w[1], orders
+	}
+	return 1, orders
+}
+
+// depthWeight returns the weight of an order book request of limit levels.
+func depthWeight(limit string) int {
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		n = 500 // 500 is the default limit.
+	}
+	switch {
+	case n <= 50:
+		return 2
+	case n <= 100:
+		return 5
+	case n <= 500:
+		return 10
+	default:
+		return 20
+	}
+}
+
+// klineWeight returns the weight of a kline request of limit klines.
+func klineWeight(limit string) int {
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		n = 500 // 500 is the default limit.
+	}
+	switch {
+	case n < 100:
+		return 1
+	case n < 500:
+		return 2
+	case n <= 1000:
+		return 5
+	default:
+		return 10
+	}
+}
@@ -1,20 +1,172 @@
 package futures
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
 )
 
 // Endpoints used in the futures package.
 const (
-	changePositionModeEndpoint = "/fapi/v1/positionSide/dual"
+	changePositionModeEndpoint    = "/fapi/v1/positionSide/dual"
+	accountInfoEndpoint           = "/fapi/v2/account"
+	accountInfoV3Endpoint         = "/fapi/v3/account"
+	balanceEndpoint               = "/fapi/v2/balance"
+	positionRiskEndpoint          = "/fapi/v2/positionRisk"
+	leverageBracketEndpoint       = "/fapi/v1/leverageBracket"
+	adlQuantileEndpoint           = "/fapi/v1/adlQuantile"
+	commissionRateEndpoint        = "/fapi/v1/commissionRate"
+	incomeEndpoint                = "/fapi/v1/income"
+	userTradesEndpoint            = "/fapi/v1/userTrades"
+	multiAssetsMarginEndpoint     = "/fapi/v1/multiAssetsMargin"
+	marginTypeEndpoint            = "/fapi/v1/marginType"
+	leverageEndpoint              = "/fapi/v1/leverage"
+	positionMarginEndpoint        = "/fapi/v1/positionMargin"
+	positionMarginHistoryEndpoint = "/fapi/v1/positionMargin/history"
 )
 
+// Error codes returned when a setting already has the requested value.
+const (
+	codeNoNeedToChangeMarginType   = -4046
+	codeNoNeedToChangePositionSide = -4059
+	codeNoNeedToChangeMultiAssets  = -4171
+)
+
+// MaxIncomeLimit is the largest page of income history.
+const MaxIncomeLimit = 1000
+
 // Account defines the interface for account operations.
 type Account interface {
+	// AccountInfo returns the balances and margin of the account, and the
+	// positions of every symbol.
+	AccountInfo() (*AccountInfo, error)
+	// AccountInfoV3 returns the balances and margin of the account, and its
+	// open positions only.
+	AccountInfoV3() (*AccountInfoV3, error)
+	Balances() ([]Balance, error)
+	// PositionRisk returns the positions of symbol, or of every symbol if it is empty.
+	PositionRisk(symbol string) ([]PositionRisk, error)
+	// LeverageBrackets returns the brackets of symbol, or of every symbol if it is empty.
+	LeverageBrackets(symbol string) ([]LeverageBracket, error)
+	// ADLQuantile returns the quantiles of symbol, or of every symbol with a position if it is empty.
+	ADLQuantile(symbol string) ([]ADLQuantile, error)
+	CommissionRate(symbol string) (*CommissionRate, error)
+	// IncomeHistory returns one page of income history.
+	IncomeHistory(req *IncomeRequest) ([]Income, error)
+	// AllIncome returns every page of income history from req.Page on.
+	AllIncome(req *IncomeRequest) ([]Income, error)
+	UserTrades(req *UserTradesRequest) ([]UserTrade, error)
+	// PositionMode reports whether hedge mode is enabled.
+	PositionMode() (bool, error)
+	// ChangePositionMode enables hedge mode, or one-way mode if enable is false.
 	ChangePositionMode(enable bool) error
+	// MultiAssetsMode reports whether multi-assets mode is enabled.
+	MultiAssetsMode() (bool, error)
+	ChangeMultiAssetsMode(enable bool) error
+	ChangeMarginType(symbol string, marginType MarginType) error
+	ChangeLeverage(symbol string, leverage int) (*LeverageChange, error)
+	// ModifyPositionMargin adds margin to or removes margin from an isolated position.
+	ModifyPositionMargin(req *PositionMarginRequest) (*PositionMarginChange, error)
+	PositionMarginHistory(req *PositionMarginHistoryRequest) ([]PositionMarginHistory, error)
+}
+
+// IncomeRequest filters the income history. Without StartTime and EndTime,
+// the last 7 days are returned.
+type IncomeRequest struct {
+	Symbol     string
+	IncomeType IncomeType
+	StartTime  time.Time
+	EndTime    time.Time
+	Page       int // Page starts at 1.
+	Limit      int // Limit defaults to 100, with a maximum of MaxIncomeLimit.
+}
+
+func (r *IncomeRequest) values() url.Values {
+	v := url.Values{}
+	setIf(v, "symbol", r.Symbol)
+	setIf(v, "incomeType", string(r.IncomeType))
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	if r.Page > 0 {
+		v.Set("page", strconv.Itoa(r.Page))
+	}
+	if r.Limit > 0 {
+		v.Set("limit", strconv.Itoa(r.Limit))
+	}
+	return v
+}
+
+// UserTradesRequest filters the trades returned by UserTrades. Trades are
+// returned from FromID on if it is set, otherwise the most recent ones.
+type UserTradesRequest struct {
+	Symbol    string
+	OrderID   int64 // OrderID can only be used with Symbol.
+	StartTime time.Time
+	EndTime   time.Time
+	FromID    int64
+	Limit     int // Limit defaults to 500, with a maximum of 1000.
+}
+
+func (r *UserTradesRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	if r.OrderID != 0 {
+		v.Set("orderId", strconv.FormatInt(r.OrderID, 10))
+	}
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	if r.FromID != 0 {
+		v.Set("fromId", strconv.FormatInt(r.FromID, 10))
+	}
+	if r.Limit > 0 {
+		v.Set("limit", strconv.Itoa(r.Limit))
+	}
+	return v
+}
+
+// PositionMarginRequest changes the margin of an isolated position.
+type PositionMarginRequest struct {
+	Symbol       string
+	PositionSide PositionSide // PositionSide defaults to BOTH; it is required in hedge mode.
+	Amount       string
+	Type         MarginAdjustment
+}
+
+func (r *PositionMarginRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	setIf(v, "positionSide", string(r.PositionSide))
+	v.Set("amount", r.Amount)
+	v.Set("type", strconv.Itoa(int(r.Type)))
+	return v
+}
+
+// PositionMarginHistoryRequest filters the isolated margin changes of a symbol.
+type PositionMarginHistoryRequest struct {
+	Symbol    string
+	Type      MarginAdjustment // Type is 0 for both directions.
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 500.
+}
+
+func (r *PositionMarginHistoryRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	if r.Type != 0 {
+		v.Set("type", strconv.Itoa(int(r.Type)))
+	}
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	if r.Limit > 0 {
+		v.Set("limit", strconv.Itoa(r.Limit))
+	}
+	return v
 }
 
 // accountImpl implements Account interface using Binance futures API.
@@ -29,14 +181,198 @@ func NewAccount(client *client.Client) Account {
 	}
 }
 
+func (a *accountImpl) AccountInfo() (*AccountInfo, error) {
+	var info AccountInfo
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, accountInfoEndpoint, "", &info); err != nil {
+		return nil, fmt.Errorf("failed to get account information: %w", err)
+	}
+	return &info, nil
+}
+
+func (a *accountImpl) AccountInfoV3() (*AccountInfoV3, error) {
+	var info AccountInfoV3
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, accountInfoV3Endpoint, "", &info); err != nil {
+		return nil, fmt.Errorf("failed to get account information: %w", err)
+	}
+	return &info, nil
+}
+
+func (a *accountImpl) Balances() ([]Balance, error) {
+	var balances []Balance
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, balanceEndpoint, "", &balances); err != nil {
+		return nil, fmt.Errorf("failed to get balances: %w", err)
+	}
+	return balances, nil
+}
+
+func (a *accountImpl) PositionRisk(symbol string) ([]PositionRisk, error) {
+	v := url.Values{}
+	setIf(v, "symbol", symbol)
+	var positions []PositionRisk
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, positionRiskEndpoint, v.Encode(), &positions); err != nil {
+		return nil, fmt.Errorf("failed to get position risk: %w", err)
+	}
+	return positions, nil
+}
+
+func (a *accountImpl) LeverageBrackets(symbol string) ([]LeverageBracket, error) {
+	v := url.Values{}
+	setIf(v, "symbol", symbol)
+	var brackets []LeverageBracket
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, leverageBracketEndpoint, v.Encode(), &brackets); err != nil {
+		return nil, fmt.Errorf("failed to get leverage brackets: %w", err)
+	}
+	return brackets, nil
+}
+
+func (a *accountImpl) ADLQuantile(symbol string) ([]ADLQuantile, error) {
+	v := url.Values{}
+	setIf(v, "symbol", symbol)
+	var quantiles []ADLQuantile
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, adlQuantileEndpoint, v.Encode(), &quantiles); err != nil {
+		return nil, fmt.Errorf("failed to get ADL quantiles: %w", err)
+	}
+	return quantiles, nil
+}
+
+func (a *accountImpl) CommissionRate(symbol string) (*CommissionRate, error) {
+	var rate CommissionRate
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, commissionRateEndpoint, url.Values{"symbol": {symbol}}.Encode(), &rate); err != nil {
+		return nil, fmt.Errorf("failed to get commission rate: %w", err)
+	}
+	return &rate, nil
+}
+
+func (a *accountImpl) IncomeHistory(req *IncomeRequest) ([]Income, error) {
+	var income []Income
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, incomeEndpoint, req.values().Encode(), &income); err != nil {
+		return nil, fmt.Errorf("failed to get income history: %w", err)
+	}
+	return income, nil
+}
+
+func (a *accountImpl) AllIncome(req *IncomeRequest) ([]Income, error) {
+	page := *req
+	if page.Page <= 0 {
+		page.Page = 1
+	}
+	if page.Limit <= 0 {
+		page.Limit = MaxIncomeLimit
+	}
+
+	var income []Income
+	for {
+		res, err := a.IncomeHistory(&page)
+		if err != nil {
+			return nil, err
+		}
+		income = append(income, res...)
+		if len(res) < page.Limit {
+			return income, nil
+		}
+		page.Page++
+	}
+}
+
+func (a *accountImpl) UserTrades(req *UserTradesRequest) ([]UserTrade, error) {
+	if req.Symbol == "" {
+		return nil, errors.New("failed to get user trades: symbol is required")
+	}
+	var trades []UserTrade
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, userTradesEndpoint, req.values().Encode(), &trades); err != nil {
+		return nil, fmt.Errorf("failed to get user trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (a *accountImpl) PositionMode() (bool, error) {
+	var resp struct {
+		DualSidePosition bool `json:"dualSidePosition"`
+	}
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, changePositionModeEndpoint, "", &resp); err != nil {
+		return false, fmt.Errorf("failed to get position mode: %w", err)
+	}
+	return resp.DualSidePosition, nil
+}
+
 // ChangePositionMode toggles the position mode for a futures account.
 func (a *accountImpl) ChangePositionMode(enable bool) error {
-	endpoint := changePositionModeEndpoint
 	data := fmt.Sprintf("dualSidePosition=%v", enable)
-	var resp any
-	err := a.MakeAuthenticatedRequest(http.MethodPost, endpoint, data, resp)
-	if err != nil {
+	err := a.MakeAuthenticatedRequest(http.MethodPost, changePositionModeEndpoint, data, nil)
+	if err = ignoreCode(err, codeNoNeedToChangePositionSide); err != nil {
 		return fmt.Errorf("failed to change position mode: %w", err)
 	}
 	return nil
 }
+
+func (a *accountImpl) MultiAssetsMode() (bool, error) {
+	var resp struct {
+		MultiAssetsMargin bool `json:"multiAssetsMargin"`
+	}
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, multiAssetsMarginEndpoint, "", &resp); err != nil {
+		return false, fmt.Errorf("failed to get multi-assets mode: %w", err)
+	}
+	return resp.MultiAssetsMargin, nil
+}
+
+func (a *accountImpl) ChangeMultiAssetsMode(enable bool) error {
+	data := fmt.Sprintf("multiAssetsMargin=%v", enable)
+	err := a.MakeAuthenticatedRequest(http.MethodPost, multiAssetsMarginEndpoint, data, nil)
+	if err = ignoreCode(err, codeNoNeedToChangeMultiAssets); err != nil {
+		return fmt.Errorf("failed to change multi-assets mode: %w", err)
+	}
+	return nil
+}
+
+func (a *accountImpl) ChangeMarginType(symbol string, marginType MarginType) error {
+	data := url.Values{"symbol": {symbol}, "marginType": {string(marginType)}}.Encode()
+	err := a.MakeAuthenticatedRequest(http.MethodPost, marginTypeEndpoint, data, nil)
+	if err = ignoreCode(err, codeNoNeedToChangeMarginType); err != nil {
+		return fmt.Errorf("failed to change margin type: %w", err)
+	}
+	return nil
+}
+
+func (a *accountImpl) ChangeLeverage(symbol string, leverage int) (*LeverageChange, error) {
+	data := url.Values{"symbol": {symbol}, "leverage": {strconv.Itoa(leverage)}}.Encode()
+	var change LeverageChange
+	if err := a.MakeAuthenticatedRequest(http.MethodPost, leverageEndpoint, data, &change); err != nil {
+		return nil, fmt.Errorf("failed to change leverage: %w", err)
+	}
+	return &change, nil
+}
+
+func (a *accountImpl) ModifyPositionMargin(req *PositionMarginRequest) (*PositionMarginChange, error) {
+	if req.Type != MarginAdd && req.Type != MarginReduce {
+		return nil, fmt.Errorf("failed to modify position margin: unsupported type %d", req.Type)
+	}
+	var change PositionMarginChange
+	if err := a.MakeAuthenticatedRequest(http.MethodPost, positionMarginEndpoint, req.values().Encode(), &change); err != nil {
+		return nil, fmt.Errorf("failed to modify position margin: %w", err)
+	}
+	return &change, nil
+}
+
+func (a *accountImpl) PositionMarginHistory(req *PositionMarginHistoryRequest) ([]PositionMarginHistory, error) {
+	var history []PositionMarginHistory
+	if err := a.MakeAuthenticatedRequest(http.MethodGet, positionMarginHistoryEndpoint, req.values().Encode(), &history); err != nil {
+		return nil, fmt.Errorf("failed to get position margin history: %w", err)
+	}
+	return history, nil
+}
+
+// ignoreCode returns nil if err is the API error code, which Binance returns
+// when a setting already has the value it is changed to, and err otherwise.
+func ignoreCode(err error, code int) error {
+	var apiErr *client.BinanceAPIError
+	if errors.As(err, &apiErr) && apiErr.Code == code {
+		return nil
+	}
+	return err
+}
+
+func setTime(v url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		v.Set(key, strconv.FormatInt(t.UnixMilli(), 10))
+	}
+}
@@ -0,0 +1,69 @@
+package futures
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_AllIncome(t *testing.T) {
+	var pages []string
+	c := clienttest.NewServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		assert.Equal(t, "FUNDING_FEE", r.URL.Query().Get("incomeType"))
+		entries := []string{`{"symbol":"BTCUSDT","incomeType":"FUNDING_FEE","income":"-0.1","asset":"USDT","tranId":1}`}
+		if page != "3" {
+			entries = append(entries, entries[0])
+		}
+		_, _ = fmt.Fprintf(w, "[%s]", strings.Join(entries, ","))
+	})
+
+	income, err := NewAccount(c).AllIncome(&IncomeRequest{IncomeType: IncomeFundingFee, Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, income, 5)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+	assert.Equal(t, IncomeFundingFee, income[0].IncomeType)
+	assert.Equal(t, int64(1), income[0].TranID)
+}
+
+func TestAccount_ChangeMarginType(t *testing.T) {
+	code := -4046
+	c := clienttest.NewServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "ISOLATED", r.PostForm.Get("marginType"))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, `{"code":%d,"msg":"error"}`, code)
+	})
+	account := NewAccount(c)
+
+	// The margin type already being the requested one is not an error.
+	require.NoError(t, account.ChangeMarginType("BTCUSDT", MarginTypeIsolated))
+
+	code = -4047
+	assert.Error(t, account.ChangeMarginType("BTCUSDT", MarginTypeIsolated))
+}
+
+func TestAccount_PositionRisk(t *testing.T) {
+	c := clienttest.NewServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/fapi/v2/positionRisk", r.URL.Path)
+		assert.Equal(t, "BTCUSDT", r.URL.Query().Get("symbol"))
+		_, _ = w.Write([]byte(`[{"symbol":"BTCUSDT","positionSide":"BOTH","positionAmt":"-0.5","entryPrice":"30000",
+			"markPrice":"29000","liquidationPrice":"45000","unRealizedProfit":"500","leverage":"10","marginType":"cross",
+			"isAutoAddMargin":"false","updateTime":1700000000000}]`))
+	})
+
+	positions, err := NewAccount(c).PositionRisk("BTCUSDT")
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	assert.Equal(t, PositionSideBoth, positions[0].PositionSide)
+	assert.Equal(t, "-0.5", positions[0].PositionAmt)
+	assert.Equal(t, "45000", positions[0].LiquidationPrice)
+	assert.Equal(t, "cross", positions[0].MarginType)
+}
@@ -0,0 +1,216 @@
+package futures
+
+// MarginSummary holds the margin totals of an account. Totals are in USDT,
+// or in USD for multi-assets accounts.
+type MarginSummary struct {
+	TotalInitialMargin          string `json:"totalInitialMargin"`
+	TotalMaintMargin            string `json:"totalMaintMargin"`
+	TotalWalletBalance          string `json:"totalWalletBalance"`
+	TotalUnrealizedProfit       string `json:"totalUnrealizedProfit"`
+	TotalMarginBalance          string `json:"totalMarginBalance"`
+	TotalPositionInitialMargin  string `json:"totalPositionInitialMargin"`
+	TotalOpenOrderInitialMargin string `json:"totalOpenOrderInitialMargin"`
+	TotalCrossWalletBalance     string `json:"totalCrossWalletBalance"`
+	TotalCrossUnPnl             string `json:"totalCrossUnPnl"`
+	AvailableBalance            string `json:"availableBalance"`
+	MaxWithdrawAmount           string `json:"maxWithdrawAmount"`
+}
+
+// AccountAsset is the margin of one asset of an account.
+type AccountAsset struct {
+	Asset                  string `json:"asset"`
+	WalletBalance          string `json:"walletBalance"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	MarginBalance          string `json:"marginBalance"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	AvailableBalance       string `json:"availableBalance"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	MarginAvailable        bool   `json:"marginAvailable"` // MarginAvailable reports whether the asset can be used as margin in multi-assets mode.
+	UpdateTime             int64  `json:"updateTime"`
+}
+
+// AccountInfo is the account information returned by the v2 endpoint. It
+// lists every symbol in Positions, including those without a position.
+type AccountInfo struct {
+	MarginSummary
+	FeeTier           int               `json:"feeTier"`
+	CanTrade          bool              `json:"canTrade"`
+	CanDeposit        bool              `json:"canDeposit"`
+	CanWithdraw       bool              `json:"canWithdraw"`
+	MultiAssetsMargin bool              `json:"multiAssetsMargin"`
+	TradeGroupID      int64             `json:"tradeGroupId"`
+	UpdateTime        int64             `json:"updateTime"`
+	Assets            []AccountAsset    `json:"assets"`
+	Positions         []AccountPosition `json:"positions"`
+}
+
+// AccountPosition is a position of AccountInfo.
+type AccountPosition struct {
+	Symbol                 string       `json:"symbol"`
+	PositionSide           PositionSide `json:"positionSide"`
+	PositionAmt            string       `json:"positionAmt"`
+	EntryPrice             string       `json:"entryPrice"`
+	BreakEvenPrice         string       `json:"breakEvenPrice"`
+	UnrealizedProfit       string       `json:"unrealizedProfit"`
+	InitialMargin          string       `json:"initialMargin"`
+	MaintMargin            string       `json:"maintMargin"`
+	PositionInitialMargin  string       `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string       `json:"openOrderInitialMargin"`
+	Leverage               string       `json:"leverage"`
+	Isolated               bool         `json:"isolated"`
+	MaxNotional            string       `json:"maxNotional"`
+	BidNotional            string       `json:"bidNotional"`
+	AskNotional            string       `json:"askNotional"`
+	UpdateTime             int64        `json:"updateTime"`
+}
+
+// AccountInfoV3 is the account information returned by the v3 endpoint. It
+// only lists open positions, and symbols with open orders.
+type AccountInfoV3 struct {
+	MarginSummary
+	Assets    []AccountAsset      `json:"assets"`
+	Positions []AccountPositionV3 `json:"positions"`
+}
+
+// AccountPositionV3 is a position of AccountInfoV3.
+type AccountPositionV3 struct {
+	Symbol           string       `json:"symbol"`
+	PositionSide     PositionSide `json:"positionSide"`
+	PositionAmt      string       `json:"positionAmt"`
+	UnrealizedProfit string       `json:"unrealizedProfit"`
+	IsolatedMargin   string       `json:"isolatedMargin"`
+	Notional         string       `json:"notional"`
+	IsolatedWallet   string       `json:"isolatedWallet"`
+	InitialMargin    string       `json:"initialMargin"`
+	MaintMargin      string       `json:"maintMargin"`
+	UpdateTime       int64        `json:"updateTime"`
+}
+
+// Balance is the balance of an asset.
+type Balance struct {
+	AccountAlias       string `json:"accountAlias"`
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"` // Balance is the wallet balance.
+	CrossWalletBalance string `json:"crossWalletBalance"`
+	CrossUnPnl         string `json:"crossUnPnl"`
+	AvailableBalance   string `json:"availableBalance"`
+	MaxWithdrawAmount  string `json:"maxWithdrawAmount"`
+	MarginAvailable    bool   `json:"marginAvailable"`
+	UpdateTime         int64  `json:"updateTime"`
+}
+
+// PositionRisk is a position with its liquidation price.
+type PositionRisk struct {
+	Symbol           string       `json:"symbol"`
+	PositionSide     PositionSide `json:"positionSide"`
+	PositionAmt      string       `json:"positionAmt"` // PositionAmt is negative for short positions in one-way mode.
+	EntryPrice       string       `json:"entryPrice"`
+	BreakEvenPrice   string       `json:"breakEvenPrice"`
+	MarkPrice        string       `json:"markPrice"`
+	LiquidationPrice string       `json:"liquidationPrice"`
+	UnRealizedProfit string       `json:"unRealizedProfit"`
+	Leverage         string       `json:"leverage"`
+	MaxNotionalValue string       `json:"maxNotionalValue"`
+	MarginType       string       `json:"marginType"` // MarginType is "isolated" or "cross".
+	IsolatedMargin   string       `json:"isolatedMargin"`
+	IsolatedWallet   string       `json:"isolatedWallet"`
+	IsAutoAddMargin  string       `json:"isAutoAddMargin"`
+	Notional         string       `json:"notional"`
+	UpdateTime       int64        `json:"updateTime"`
+}
+
+// LeverageBracket is the notional brackets of a symbol.
+type LeverageBracket struct {
+	Symbol string `json:"symbol"`
+	// NotionalCoef is the user's bracket multiplier; it is only returned
+	// when the brackets of a single symbol are requested.
+	NotionalCoef float64   `json:"notionalCoef"`
+	Brackets     []Bracket `json:"brackets"`
+}
+
+// Bracket is the maximum leverage allowed up to a notional value.
+type Bracket struct {
+	Bracket          int     `json:"bracket"`
+	InitialLeverage  int     `json:"initialLeverage"`
+	NotionalCap      float64 `json:"notionalCap"`
+	NotionalFloor    float64 `json:"notionalFloor"`
+	MaintMarginRatio float64 `json:"maintMarginRatio"`
+	Cum              float64 `json:"cum"` // Cum is the maintenance amount deducted at this bracket.
+}
+
+// ADLQuantile is the auto-deleveraging queue position of a symbol's
+// positions, from 0 to 4; the higher, the sooner positions are deleveraged.
+type ADLQuantile struct {
+	Symbol string `json:"symbol"`
+	// Quantiles is keyed by "BOTH" in one-way mode, and by "LONG", "SHORT"
+	// and "HEDGE" in hedge mode, where HEDGE is only informational.
+	Quantiles map[string]int `json:"adlQuantile"`
+}
+
+// CommissionRate is the user's commission rate for a symbol.
+type CommissionRate struct {
+	Symbol              string `json:"symbol"`
+	MakerCommissionRate string `json:"makerCommissionRate"`
+	TakerCommissionRate string `json:"takerCommissionRate"`
+}
+
+// Income is an entry of the income history.
+type Income struct {
+	Symbol     string     `json:"symbol"`
+	IncomeType IncomeType `json:"incomeType"`
+	Income     string     `json:"income"`
+	Asset      string     `json:"asset"`
+	Info       string     `json:"info"`
+	Time       int64      `json:"time"`
+	TranID     int64      `json:"tranId"`
+	TradeID    string     `json:"tradeId"`
+}
+
+// UserTrade is a trade of the account.
+type UserTrade struct {
+	ID              int64        `json:"id"`
+	OrderID         int64        `json:"orderId"`
+	Symbol          string       `json:"symbol"`
+	Side            Side         `json:"side"`
+	PositionSide    PositionSide `json:"positionSide"`
+	Price           string       `json:"price"`
+	Qty             string       `json:"qty"`
+	QuoteQty        string       `json:"quoteQty"`
+	RealizedPnl     string       `json:"realizedPnl"`
+	Commission      string       `json:"commission"`
+	CommissionAsset string       `json:"commissionAsset"`
+	Buyer           bool         `json:"buyer"`
+	Maker           bool         `json:"maker"`
+	Time            int64        `json:"time"`
+}
+
+// LeverageChange is the result of ChangeLeverage.
+type LeverageChange struct {
+	Symbol           string `json:"symbol"`
+	Leverage         int    `json:"leverage"`
+	MaxNotionalValue string `json:"maxNotionalValue"`
+}
+
+// PositionMarginChange is the result of ModifyPositionMargin.
+type PositionMarginChange struct {
+	Amount float64          `json:"amount"`
+	Type   MarginAdjustment `json:"type"`
+	Code   int              `json:"code"`
+	Msg    string           `json:"msg"`
+}
+
+// PositionMarginHistory is an isolated margin change.
+type PositionMarginHistory struct {
+	Symbol       string           `json:"symbol"`
+	PositionSide PositionSide     `json:"positionSide"`
+	Type         MarginAdjustment `json:"type"`
+	DeltaType    string           `json:"deltaType"` // DeltaType tells changes made by the user from automatic ones.
+	Amount       string           `json:"amount"`
+	Asset        string           `json:"asset"`
+	Time         int64            `json:"time"`
+}
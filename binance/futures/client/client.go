@@ -1,19 +1,43 @@
 package client
 
 import (
-	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
-	"sync"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/constants"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+	"github.com/cploutarchou/crypto-sdk-suite/logger"
+)
+
+// DefaultTimeout bounds requests sent by the default HTTP client.
+const DefaultTimeout = 30 * time.Second
+
+// apiKeyHeader is the header the API key is sent in.
+const apiKeyHeader = "X-MBX-APIKEY"
+
+// Security is the authentication an endpoint requires.
+type Security int
+
+const (
+	// SecurityNone marks public endpoints.
+	SecurityNone Security = iota
+	// SecurityAPIKey marks endpoints that need the API key but no signature,
+	// such as the user data stream ones.
+	SecurityAPIKey
+	// SecuritySigned marks endpoints that need a timestamp and a signature.
+	SecuritySigned
 )
 
 // Config stores configuration for the API client.
@@ -22,12 +46,56 @@ type Config struct {
 	APISecret string
 	BaseURL   string
 	WSBaseURL string
+	// Credentials, when set, is read before every signed request instead of
+	// APIKey and APISecret, so keys can be rotated while the client is in use.
+	Credentials credentials.Provider
+	// RecvWindow is how long after its timestamp a signed request is valid;
+	// 0 leaves it to the server's default of 5 seconds.
+	RecvWindow time.Duration
+	// HTTPClient sends the requests; nil uses a client with DefaultTimeout.
+	HTTPClient *http.Client
+	// Limiter holds requests back to stay within the rate limits; nil uses
+	// a limiter with DefaultRateLimits.
+	Limiter *Limiter
+	// RequestWeight returns the weight of a request and the number of orders
+	// it places; nil uses the futures weights of RequestWeight.
+	RequestWeight func(method, path string, params url.Values) (weight, orders int)
+	// TimeEndpoint is the endpoint SyncTime reads the server time from;
+	// empty uses the futures one.
+	TimeEndpoint string
 }
 
 // Client represents a client for Binance's futures trading.
 type Client struct {
-	sync.Mutex
-	config Config
+	config     Config
+	httpClient *http.Client
+	timeOffset atomic.Int64 // timeOffset is the server time minus the local time, in milliseconds.
+	limiter    *Limiter
+	logger     logger.Interface
+}
+
+// NewClient creates a new client instance from config.
+func NewClient(config Config) *Client {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	limiter := config.Limiter
+	if limiter == nil {
+		limiter = NewLimiter()
+	}
+	if config.RequestWeight == nil {
+		config.RequestWeight = RequestWeight
+	}
+	if config.TimeEndpoint == "" {
+		config.TimeEndpoint = constants.ServerTimeEndpoint
+	}
+	return &Client{
+		config:     config,
+		httpClient: httpClient,
+		limiter:    limiter,
+		logger:     logger.Nop,
+	}
 }
 
 // NewFuturesClient creates a new client instance.
@@ -39,85 +107,222 @@ func NewFuturesClient(apiKey, apiSecret string, isTestnet bool) *Client {
 		baseURL, wsBaseURL = constants.ProductionBaseURL, constants.ProductionWSURL
 	}
 
-	config := Config{
+	return NewClient(Config{
 		APIKey:    apiKey,
 		APISecret: apiSecret,
 		BaseURL:   baseURL,
 		WSBaseURL: wsBaseURL,
-	}
+	})
+}
 
-	return &Client{
-		config: config,
+// NewFuturesClientWithProvider creates a new client instance whose
+// credentials are read from p before every signed request.
+func NewFuturesClientWithProvider(p credentials.Provider, isTestnet bool) *Client {
+	c := NewFuturesClient("", "", isTestnet)
+	c.config.Credentials = p
+	return c
+}
+
+// Config returns the configuration of the client.
+func (c *Client) Config() Config {
+	return c.config
+}
+
+// SetHTTPClient sets the HTTP client requests are sent with. It should be
+// called before the client is used.
+func (c *Client) SetHTTPClient(h *http.Client) {
+	c.httpClient = h
+}
+
+// SetRecvWindow sets how long after its timestamp a signed request is valid.
+// It should be called before the client is used.
+func (c *Client) SetRecvWindow(d time.Duration) {
+	c.config.RecvWindow = d
+}
+
+// SetLogger sets the logger requests are reported to at DEBUG level; nil
+// discards the logs. It should be called before the client is used.
+func (c *Client) SetLogger(l logger.Interface) {
+	if l == nil {
+		l = logger.Nop
 	}
+	c.logger = l
 }
 
-// MakeAuthenticatedRequest creates an authenticated request to the API.
-func (c *Client) MakeAuthenticatedRequest(method, endpoint, bodyData string, responseData any) error {
-	timestamp := time.Now().UnixNano() / int64(time.Millisecond)
-	var data, signature string
-	signature = c.createSignature(data)
-	if len(bodyData) > 0 {
-		data = fmt.Sprintf("%s&timestamp=%d", bodyData, timestamp)
+// Limiter returns the rate limiter of the client, for example to read the
+// current usage.
+func (c *Client) Limiter() *Limiter {
+	return c.limiter
+}
+
+// TimeOffset returns the difference between the server's clock and the
+// local one, as measured by the last SyncTime.
+func (c *Client) TimeOffset() time.Duration {
+	return time.Duration(c.timeOffset.Load()) * time.Millisecond
+}
+
+// SyncTime measures the offset between the server's clock and the local one.
+// Signed requests are timestamped with the server's time from then on.
+func (c *Client) SyncTime(ctx context.Context) error {
+	var resp struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	start := time.Now()
+	if err := c.Do(ctx, http.MethodGet, c.config.TimeEndpoint, nil, SecurityNone, &resp); err != nil {
+		return fmt.Errorf("failed to sync time: %w", err)
 	}
-	reqURL := fmt.Sprintf("%s%s%s&signature=%s", c.config.BaseURL, endpoint, data, signature)
+	// The server read its clock about halfway through the round trip.
+	local := start.Add(time.Since(start) / 2).UnixMilli()
+	c.timeOffset.Store(resp.ServerTime - local)
+	return nil
+}
 
-	req, err := http.NewRequest(method, reqURL, bytes.NewBufferString(bodyData))
+// credentials returns the API key and secret to sign the next request with.
+func (c *Client) credentials() (credentials.Credentials, error) {
+	if c.config.Credentials == nil {
+		return credentials.Credentials{APIKey: c.config.APIKey, APISecret: c.config.APISecret}, nil
+	}
+	creds, err := c.config.Credentials.Credentials()
 	if err != nil {
-		log.Printf("Error creating new request: %v", err)
-		return err
+		return credentials.Credentials{}, fmt.Errorf("failed to get credentials: %w", err)
 	}
+	return creds, nil
+}
 
-	req.Header.Set("X-MBX-APIKEY", c.config.APIKey)
-	req.Header.Set("Content-Type", "application/json")
+// Do sends a request to endpoint and decodes the response into out, unless
+// out is nil. Parameters are sent in the query string of GET and DELETE
+// requests and in the form-encoded body of the others; parameters already in
+// endpoint are kept. Signed requests are timestamped and signed over all of
+// their parameters. Errors returned by the API are *BinanceAPIError values.
+//
+// A signed request rejected because its timestamp is outside the receive
+// window is sent once more after resynchronizing the clock.
+func (c *Client) Do(ctx context.Context, method, endpoint string, params url.Values, security Security, out any) error {
+	err := c.do(ctx, method, endpoint, params, security, out)
+	var apiErr *BinanceAPIError
+	if security == SecuritySigned && errors.As(err, &apiErr) && apiErr.Code == CodeInvalidTimestamp {
+		if syncErr := c.SyncTime(ctx); syncErr != nil {
+			return err
+		}
+		err = c.do(ctx, method, endpoint, params, security, out)
+	}
+	return err
+}
 
-	resp, err := http.DefaultClient.Do(req)
+func (c *Client) do(ctx context.Context, method, endpoint string, params url.Values, security Security, out any) error {
+	path, values, err := mergeParams(endpoint, params)
 	if err != nil {
-		log.Printf("Error sending request: %v", err)
 		return err
 	}
+	weight, orders := c.config.RequestWeight(method, path, values)
+	if err := c.limiter.Wait(ctx, weight, orders); err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, method, path, values, security)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Error("request failed", "method", method, "path", req.URL.Path, "error", err)
+		return fmt.Errorf("failed to send request: %w", err)
+	}
 	defer resp.Body.Close()
+	c.logger.Debug("request sent", "method", method, "path", req.URL.Path, "status", resp.StatusCode, "duration", time.Since(start))
+	c.limiter.Update(resp)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("Error reading response body: %v", err)
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := parseAPIError(resp, body); err != nil {
 		return err
 	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
 
-	return json.Unmarshal(body, &responseData)
+// mergeParams splits the path of endpoint from its parameters and adds
+// params to them.
+func mergeParams(endpoint string, params url.Values) (string, url.Values, error) {
+	path, rawQuery, _ := strings.Cut(endpoint, "?")
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse endpoint %q: %w", endpoint, err)
+	}
+	for key, vs := range params {
+		values[key] = append(values[key], vs...)
+	}
+	return path, values, nil
 }
 
-// MakeRequestWithoutSignature handles making a non-authenticated API request.
-func (c *Client) MakeRequestWithoutSignature(method, endpoint string, responseData any) error {
-	c.Lock()
-	defer c.Unlock()
+// newRequest builds the HTTP request of Do for path and its parameters.
+func (c *Client) newRequest(ctx context.Context, method, path string, values url.Values, security Security) (*http.Request, error) {
+	var creds credentials.Credentials
+	var err error
+	if security != SecurityNone {
+		if creds, err = c.credentials(); err != nil {
+			return nil, err
+		}
+	}
 
-	reqURL := fmt.Sprintf("%s%s", c.config.BaseURL, endpoint)
+	payload := values.Encode()
+	if security == SecuritySigned {
+		if c.config.RecvWindow > 0 {
+			values.Set("recvWindow", strconv.FormatInt(c.config.RecvWindow.Milliseconds(), 10))
+		}
+		values.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli()+c.timeOffset.Load(), 10))
+		payload = values.Encode()
+		payload += "&signature=" + sign(creds.APISecret, payload)
+	}
 
-	req, err := http.NewRequest(method, reqURL, http.NoBody)
-	if err != nil {
-		log.Printf("Error creating new request: %v", err)
-		return err
+	inQuery := method == http.MethodGet || method == http.MethodDelete
+	reqURL := c.config.BaseURL + path
+	body := io.Reader(http.NoBody)
+	if inQuery && payload != "" {
+		reqURL += "?" + payload
+	} else if !inQuery {
+		body = strings.NewReader(payload)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
-		log.Printf("Error sending request: %v", err)
-		return err
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	defer resp.Body.Close()
+	if !inQuery {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if security != SecurityNone {
+		req.Header.Set(apiKeyHeader, creds.APIKey)
+	}
+	return req, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// MakeAuthenticatedRequest sends a signed request with the form-encoded
+// parameters of bodyData and decodes the response into responseData.
+func (c *Client) MakeAuthenticatedRequest(method, endpoint, bodyData string, responseData any) error {
+	params, err := url.ParseQuery(bodyData)
 	if err != nil {
-		log.Printf("Error reading response body: %v", err)
-		return err
+		return fmt.Errorf("failed to parse parameters: %w", err)
 	}
+	return c.Do(context.Background(), method, endpoint, params, SecuritySigned, responseData)
+}
 
-	return json.Unmarshal(body, &responseData)
+// MakeRequestWithoutSignature handles making a non-authenticated API request.
+func (c *Client) MakeRequestWithoutSignature(method, endpoint string, responseData any) error {
+	return c.Do(context.Background(), method, endpoint, nil, SecurityNone, responseData)
 }
 
-// createSignature generates the HMAC SHA256 signature for the request.
-func (c *Client) createSignature(data string) string {
-	h := hmac.New(sha256.New, []byte(c.config.APISecret))
-	h.Write([]byte(data))
+// sign returns the hex-encoded HMAC SHA256 signature of payload.
+func sign(secret, payload string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(payload))
 	return hex.EncodeToString(h.Sum(nil))
 }
@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SignedRequests(t *testing.T) {
+	var got []url.Values
+	c := clienttest.NewServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, clienttest.SignedParams(t, r))
+		_, _ = w.Write([]byte(`{"orderId":1}`))
+	})
+	c.SetRecvWindow(2 * time.Second)
+
+	var out struct {
+		OrderID int64 `json:"orderId"`
+	}
+	params := url.Values{"symbol": {"BTCUSDT"}, "side": {"BUY"}}
+	require.NoError(t, c.Do(context.Background(), http.MethodPost, "/fapi/v1/order", params, client.SecuritySigned, &out))
+	assert.Equal(t, int64(1), out.OrderID)
+	require.NoError(t, c.MakeAuthenticatedRequest(http.MethodGet, "/fapi/v1/order?symbol=BTCUSDT", "orderId=1", nil))
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "BUY", got[0].Get("side"))
+	assert.Equal(t, "2000", got[0].Get("recvWindow"))
+	assert.NotEmpty(t, got[0].Get("timestamp"))
+	assert.Equal(t, "BTCUSDT", got[1].Get("symbol"))
+	assert.Equal(t, "1", got[1].Get("orderId"))
+}
+
+func TestClient_APIError(t *testing.T) {
+	c := clienttest.NewServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+	})
+
+	err := c.MakeRequestWithoutSignature(http.MethodGet, "/fapi/v1/depth?symbol=NOPE", &struct{}{})
+	var apiErr *client.BinanceAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, -1121, apiErr.Code)
+	assert.Equal(t, "Invalid symbol.", apiErr.Message)
+}
+
+func TestClient_ResyncsTimestamp(t *testing.T) {
+	const serverAhead = time.Hour
+	var calls atomic.Int32
+	c := clienttest.NewServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		serverTime := time.Now().Add(serverAhead).UnixMilli()
+		if r.URL.Path == "/fapi/v1/time" {
+			_, _ = w.Write([]byte(`{"serverTime":` + strconv.FormatInt(serverTime, 10) + `}`))
+			return
+		}
+		calls.Add(1)
+		ts, err := strconv.ParseInt(clienttest.SignedParams(t, r).Get("timestamp"), 10, 64)
+		require.NoError(t, err)
+		if serverTime-ts > time.Minute.Milliseconds() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	require.NoError(t, c.Do(context.Background(), http.MethodGet, "/fapi/v2/account", nil, client.SecuritySigned, nil))
+	assert.Equal(t, int32(2), calls.Load())
+	assert.InDelta(t, serverAhead.Seconds(), c.TimeOffset().Seconds(), 5)
+}
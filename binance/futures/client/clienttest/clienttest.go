@@ -0,0 +1,64 @@
+// Package clienttest provides a local REST server for testing the Binance
+// APIs built on the futures client without network access.
+package clienttest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Credentials of the clients returned by NewServerConfig and NewServerClient.
+const (
+	APIKey    = "key"
+	APISecret = "secret"
+)
+
+// NewServerConfig starts a server whose requests are answered by handler,
+// shut down when the test finishes, and returns the configuration of a
+// client of it.
+func NewServerConfig(t testing.TB, handler http.HandlerFunc) client.Config {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return client.Config{APIKey: APIKey, APISecret: APISecret, BaseURL: srv.URL, HTTPClient: srv.Client()}
+}
+
+// NewServerClient returns a client whose requests are answered by handler.
+func NewServerClient(t testing.TB, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+	return client.NewClient(NewServerConfig(t, handler))
+}
+
+// SignedParams checks that r is sent with APIKey, timestamped and signed
+// with APISecret, and returns its parameters without the signature.
+func SignedParams(t testing.TB, r *http.Request) url.Values {
+	t.Helper()
+	raw := r.URL.RawQuery
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		raw = string(body)
+	}
+	payload, signature, ok := strings.Cut(raw, "&signature=")
+	require.True(t, ok, "request is not signed")
+	mac := hmac.New(sha256.New, []byte(APISecret))
+	mac.Write([]byte(payload))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+	assert.Equal(t, APIKey, r.Header.Get("X-MBX-APIKEY"))
+
+	params, err := url.ParseQuery(payload)
+	require.NoError(t, err)
+	assert.NotEmpty(t, params.Get("timestamp"))
+	return params
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Error codes the client handles itself.
+const (
+	// CodeInvalidTimestamp is returned when a request's timestamp is outside
+	// the receive window, usually because the local clock drifted.
+	CodeInvalidTimestamp = -1021
+)
+
+// BinanceAPIError is an error returned by the Binance API.
+type BinanceAPIError struct {
+	StatusCode int    // StatusCode is the HTTP status of the response.
+	Code       int    `json:"code"` // Code is Binance's error code, or 0 if the body carried none.
+	Message    string `json:"msg"`
+}
+
+func (e *BinanceAPIError) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("binance: HTTP %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("binance: code %d: %s", e.Code, e.Message)
+}
+
+// parseAPIError returns the error carried by a response, or nil if it
+// succeeded. Besides HTTP errors, successful responses holding a negative
+// code are errors too.
+func parseAPIError(resp *http.Response, body []byte) error {
+	apiErr := &BinanceAPIError{StatusCode: resp.StatusCode}
+	isObject := bytes.HasPrefix(bytes.TrimSpace(body), []byte("{"))
+	if isObject {
+		_ = json.Unmarshal(body, apiErr)
+	}
+	if resp.StatusCode < http.StatusBadRequest && apiErr.Code >= 0 {
+		return nil
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = string(bytes.TrimSpace(body))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+	}
+	return apiErr
+}
@@ -0,0 +1,396 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Types of rate limits.
+const (
+	RateLimitRequestWeight = "REQUEST_WEIGHT"
+	RateLimitOrders        = "ORDERS"
+)
+
+// Headers reporting usage; they are suffixed with the interval, such as "1M".
+const (
+	usedWeightHeader = "x-mbx-used-weight-"
+	orderCountHeader = "x-mbx-order-count-"
+)
+
+// DefaultRetryAfter is how long requests are held back after a 429 or 418
+// response without a Retry-After header.
+const DefaultRetryAfter = time.Minute
+
+// RateLimit is a limit of the weight or the number of orders sent in a
+// window of Interval. Windows are aligned to multiples of Interval.
+type RateLimit struct {
+	Type     string
+	Interval time.Duration
+	Limit    int
+}
+
+// DefaultRateLimits are the futures limits in force when exchange info
+// has not been loaded.
+var DefaultRateLimits = []RateLimit{
+	{Type: RateLimitRequestWeight, Interval: time.Minute, Limit: 2400},
+	{Type: RateLimitOrders, Interval: 10 * time.Second, Limit: 300},
+	{Type: RateLimitOrders, Interval: time.Minute, Limit: 1200},
+}
+
+// ParseRateLimit converts a limit of the exchange info, such as
+// ("REQUEST_WEIGHT", "MINUTE", 1, 2400).
+func ParseRateLimit(limitType, interval string, intervalNum, limit int) (RateLimit, error) {
+	units := map[string]time.Duration{
+		"SECOND": time.Second,
+		"MINUTE": time.Minute,
+		"HOUR":   time.Hour,
+		"DAY":    24 * time.Hour,
+	}
+	unit, ok := units[interval]
+	if !ok || intervalNum <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit interval %d %s", intervalNum, interval)
+	}
+	return RateLimit{Type: limitType, Interval: time.Duration(intervalNum) * unit, Limit: limit}, nil
+}
+
+// Usage is the amount of a rate limit used in the current window.
+type Usage struct {
+	RateLimit
+	Used int
+}
+
+// window tracks the usage of a rate limit.
+type window struct {
+	limit RateLimit
+	start time.Time
+	used  int
+}
+
+// current returns the usage of the window at now, starting a new window
+// if the previous one ended.
+func (w *window) current(now time.Time) int {
+	if start := now.Truncate(w.limit.Interval); start.After(w.start) {
+		w.start, w.used = start, 0
+	}
+	return w.used
+}
+
+// Limiter holds requests back so that they stay within Binance's request
+// weight and order limits. Usage is counted locally from the weight of
+// each endpoint and corrected with the usage the server reports in its
+// response headers. After a 429 or 418 response, every request waits for
+// the time given by the Retry-After header.
+//
+// A Limiter is safe for concurrent use.
+type Limiter struct {
+	mu         sync.Mutex
+	windows    []*window
+	retryAfter time.Time
+	now        func() time.Time
+}
+
+// NewLimiter returns a limiter enforcing limits, or DefaultRateLimits if
+// none are given.
+func NewLimiter(limits ...RateLimit) *Limiter {
+	l := &Limiter{now: time.Now}
+	if len(limits) == 0 {
+		limits = DefaultRateLimits
+	}
+	l.SetLimits(limits)
+	return l
+}
+
+// SetLimits replaces the limits enforced, keeping the usage of the windows
+// that remain.
+func (l *Limiter) SetLimits(limits []RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	windows := make([]*window, 0, len(limits))
+	for _, limit := range limits {
+		w := &window{limit: limit}
+		if old := l.find(limit.Type, limit.Interval); old != nil {
+			w.start, w.used = old.start, old.used
+		}
+		windows = append(windows, w)
+	}
+	l.windows = windows
+}
+
+// find returns the window of the limit of limitType over interval, or nil.
+func (l *Limiter) find(limitType string, interval time.Duration) *window {
+	for _, w := range l.windows {
+		if w.limit.Type == limitType && w.limit.Interval == interval {
+			return w
+		}
+	}
+	return nil
+}
+
+// Usage returns the usage of every limit.
+func (l *Limiter) Usage() []Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	usage := make([]Usage, 0, len(l.windows))
+	for _, w := range l.windows {
+		usage = append(usage, Usage{RateLimit: w.limit, Used: w.current(now)})
+	}
+	return usage
+}
+
+// RetryAfter returns the time requests are held back until after a 429 or
+// 418 response; it is zero if they are not.
+func (l *Limiter) RetryAfter() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.retryAfter.Before(l.now()) {
+		return time.Time{}
+	}
+	return l.retryAfter
+}
+
+// Wait blocks until a request of the given weight and number of orders
+// fits within the limits, then counts it. It returns early with an error
+// if ctx is done.
+func (l *Limiter) Wait(ctx context.Context, weight, orders int) error {
+	for {
+		delay := l.reserve(weight, orders)
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("failed to wait for rate limit: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve counts a request and returns 0 if it fits within the limits, or
+// how long to wait before trying again if it does not.
+func (l *Limiter) reserve(weight, orders int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Before(l.retryAfter) {
+		return l.retryAfter.Sub(now)
+	}
+
+	var delay time.Duration
+	for _, w := range l.windows {
+		cost := w.cost(weight, orders)
+		used := w.current(now)
+		// A request costing more than the limit is let through in an empty window.
+		if cost > 0 && used > 0 && used+cost > w.limit.Limit {
+			delay = max(delay, w.start.Add(w.limit.Interval).Sub(now))
+		}
+	}
+	if delay > 0 {
+		return delay
+	}
+	for _, w := range l.windows {
+		w.used += w.cost(weight, orders)
+	}
+	return 0
+}
+
+// cost returns what a request uses of the limit of w.
+func (w *window) cost(weight, orders int) int {
+	switch w.limit.Type {
+	case RateLimitRequestWeight:
+		return weight
+	case RateLimitOrders:
+		return orders
+	default:
+		return 0
+	}
+}
+
+// Update corrects the usage with the one reported by the headers of resp,
+// and holds requests back if it is a 429 or 418 response.
+func (l *Limiter) Update(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	for key, values := range resp.Header {
+		limitType, interval, ok := parseUsageHeader(key)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		used, err := strconv.Atoi(values[0])
+		if err != nil {
+			continue
+		}
+		if w := l.find(limitType, interval); w != nil {
+			w.current(now)
+			w.used = used
+		}
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusTeapot {
+		return
+	}
+	retryAfter := DefaultRetryAfter
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	if until := now.Add(retryAfter); until.After(l.retryAfter) {
+		l.retryAfter = until
+	}
+}
+
+// parseUsageHeader parses the name of a usage header, such as
+// X-Mbx-Used-Weight-1m.
+func parseUsageHeader(key string) (limitType string, interval time.Duration, ok bool) {
+	key = strings.ToLower(key)
+	var suffix string
+	switch {
+	case strings.HasPrefix(key, usedWeightHeader):
+		limitType, suffix = RateLimitRequestWeight, strings.TrimPrefix(key, usedWeightHeader)
+	case strings.HasPrefix(key, orderCountHeader):
+		limitType, suffix = RateLimitOrders, strings.TrimPrefix(key, orderCountHeader)
+	default:
+		return "", 0, false
+	}
+	if len(suffix) < 2 {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(suffix[:len(suffix)-1])
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+	units := map[byte]time.Duration{'s': time.Second, 'm': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour}
+	unit, ok := units[suffix[len(suffix)-1]]
+	if !ok {
+		return "", 0, false
+	}
+	return limitType, time.Duration(n) * unit, true
+}
+
+// endpointWeights are the weights of the endpoints that do not weigh 1,
+// keyed by method and path. Weights depending on the parameters are
+// computed by RequestWeight.
+var endpointWeights = map[string]int{
+	"GET /fapi/v1/trades":                           5,
+	"GET /fapi/v1/historicalTrades":                 20,
+	"GET /fapi/v1/aggTrades":                        20,
+	"GET /fapi/v1/allOrders":                        5,
+	"GET /fapi/v1/userTrades":                       5,
+	"GET /fapi/v1/income":                           30,
+	"GET /fapi/v1/commissionRate":                   20,
+	"GET /fapi/v1/adlQuantile":                      5,
+	"GET /fapi/v1/positionSide/dual":                30,
+	"GET /fapi/v1/multiAssetsMargin":                30,
+	"GET /fapi/v2/account":                          5,
+	"GET /fapi/v3/account":                          5,
+	"GET /fapi/v2/balance":                          5,
+	"GET /fapi/v3/balance":                          5,
+	"GET /fapi/v2/positionRisk":                     5,
+	"GET /fapi/v3/positionRisk":                     5,
+	"POST /fapi/v1/order":                           0,
+	"POST /fapi/v1/batchOrders":                     5,
+	"PUT /fapi/v1/batchOrders":                      5,
+	"GET /futures/data/openInterestHist":            0,
+	"GET /futures/data/topLongShortAccountRatio":    0,
+	"GET /futures/data/topLongShortPositionRatio":   0,
+	"GET /futures/data/globalLongShortAccountRatio": 0,
+	"GET /futures/data/takerlongshortRatio":         0,
+	"GET /futures/data/basis":                       0,
+}
+
+// symbolWeights are the weights of the endpoints that weigh more when
+// queried for every symbol: the weight with a symbol, then without.
+var symbolWeights = map[string][2]int{
+	"GET /fapi/v1/ticker/24hr":       {1, 40},
+	"GET /fapi/v1/ticker/price":      {1, 2},
+	"GET /fapi/v2/ticker/price":      {1, 2},
+	"GET /fapi/v1/ticker/bookTicker": {2, 5},
+	"GET /fapi/v1/openOrders":        {1, 40},
+}
+
+// klineEndpoints weigh by the number of klines requested.
+var klineEndpoints = map[string]bool{
+	"/fapi/v1/klines":             true,
+	"/fapi/v1/continuousKlines":   true,
+	"/fapi/v1/indexPriceKlines":   true,
+	"/fapi/v1/markPriceKlines":    true,
+	"/fapi/v1/premiumIndexKlines": true,
+}
+
+// RequestWeight returns the weight of a request and the number of orders
+// it places.
+func RequestWeight(method, path string, params url.Values) (weight, orders int) {
+	key := method + " " + path
+	switch {
+	case path == "/fapi/v1/depth":
+		weight = depthWeight(params.Get("limit"))
+	case klineEndpoints[path] && method == http.MethodGet:
+		weight = klineWeight(params.Get("limit"))
+	default:
+		weight = 1
+		if w, ok := endpointWeights[key]; ok {
+			weight = w
+		} else if w, ok := symbolWeights[key]; ok {
+			weight = w[1]
+			if params.Get("symbol") != "" {
+				weight = w[0]
+			}
+		}
+	}
+
+	switch key {
+	case "POST /fapi/v1/order", "PUT /fapi/v1/order":
+		orders = 1
+	case "POST /fapi/v1/batchOrders", "PUT /fapi/v1/batchOrders":
+		var batch []json.RawMessage
+		if err := json.Unmarshal([]byte(params.Get("batchOrders")), &batch); err == nil {
+			orders = len(batch)
+		}
+	}
+	return weight, orders
+}
+
+// depthWeight returns the weight of an order book request of limit levels.
+func depthWeight(limit string) int {
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		n = 500 // 500 is the default limit.
+	}
+	switch {
+	case n <= 50:
+		return 2
+	case n <= 100:
+		return 5
+	case n <= 500:
+		return 10
+	default:
+		return 20
+	}
+}
+
+// klineWeight returns the weight of a kline request of limit klines.
+func klineWeight(limit string) int {
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		n = 500 // 500 is the default limit.
+	}
+	switch {
+	case n < 100:
+		return 1
+	case n < 500:
+		return 2
+	case n <= 1000:
+		return 5
+	default:
+		return 10
+	}
+}
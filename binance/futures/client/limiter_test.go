@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestWeight(t *testing.T) {
+	tests := []struct {
+		method, path string
+		params       url.Values
+		weight       int
+		orders       int
+	}{
+		{http.MethodGet, "/fapi/v1/depth", url.Values{"limit": {"50"}}, 2, 0},
+		{http.MethodGet, "/fapi/v1/depth", url.Values{"limit": {"1000"}}, 20, 0},
+		{http.MethodGet, "/fapi/v1/depth", nil, 10, 0},
+		{http.MethodGet, "/fapi/v1/klines", url.Values{"limit": {"1500"}}, 10, 0},
+		{http.MethodGet, "/fapi/v1/ticker/24hr", url.Values{"symbol": {"BTCUSDT"}}, 1, 0},
+		{http.MethodGet, "/fapi/v1/ticker/24hr", nil, 40, 0},
+		{http.MethodGet, "/fapi/v2/account", nil, 5, 0},
+		{http.MethodPost, "/fapi/v1/order", nil, 0, 1},
+		{http.MethodPost, "/fapi/v1/batchOrders", url.Values{"batchOrders": {`[{},{},{}]`}}, 5, 3},
+		{http.MethodGet, "/fapi/v1/time", nil, 1, 0},
+	}
+	for _, tt := range tests {
+		weight, orders := RequestWeight(tt.method, tt.path, tt.params)
+		assert.Equal(t, tt.weight, weight, "%s %s %v", tt.method, tt.path, tt.params)
+		assert.Equal(t, tt.orders, orders, "%s %s %v", tt.method, tt.path, tt.params)
+	}
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+	l := NewLimiter(RateLimit{Type: RateLimitRequestWeight, Interval: time.Minute, Limit: 10})
+	l.now = func() time.Time { return now }
+
+	require.NoError(t, l.Wait(context.Background(), 6, 0))
+	assert.Equal(t, 30*time.Second, l.reserve(6, 0))
+	assert.Equal(t, 6, l.Usage()[0].Used)
+
+	// The server's count replaces the local one.
+	l.Update(&http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Mbx-Used-Weight-1m": {"2"}}})
+	assert.Equal(t, 2, l.Usage()[0].Used)
+	assert.Zero(t, l.reserve(6, 0))
+
+	// A new window starts empty.
+	now = now.Add(30 * time.Second)
+	assert.Zero(t, l.Usage()[0].Used)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l.Update(&http.Response{StatusCode: http.StatusTeapot, Header: http.Header{"Retry-After": {"120"}}})
+	assert.Equal(t, now.Add(2*time.Minute), l.RetryAfter())
+	assert.ErrorIs(t, l.Wait(ctx, 1, 0), context.Canceled)
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-MBX-USED-WEIGHT-1M", "2400")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"code":-1003,"msg":"Too many requests"}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient(Config{BaseURL: srv.URL, HTTPClient: srv.Client()})
+
+	err := c.Do(context.Background(), http.MethodGet, "/fapi/v1/depth", url.Values{"limit": {"5"}}, SecurityNone, nil)
+	var apiErr *BinanceAPIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, -1003, apiErr.Code)
+	assert.Equal(t, 2400, c.Limiter().Usage()[0].Used)
+	assert.WithinDuration(t, time.Now().Add(7*time.Second), c.Limiter().RetryAfter(), time.Second)
+
+	// Further requests are held back instead of being sent.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, c.Do(ctx, http.MethodGet, "/fapi/v1/time", nil, SecurityNone, nil), context.DeadlineExceeded)
+}
@@ -13,10 +13,10 @@ const (
 // WebSocket URLs for Binance Futures API.
 const (
 	// ProductionWSURL is the WebSocket URL for the Binance Futures production environment.
-	ProductionWSURL = "wss://fstream.binancefuture.com"
+	ProductionWSURL = "wss://fstream.binance.com"
 
 	// TestnetWSURL is the WebSocket URL for the Binance Futures testnet environment.
-	TestnetWSURL = "wss://fstream.testnet.binancefuture.com"
+	TestnetWSURL = "wss://stream.binancefuture.com"
 )
 
 // API Endpoints for Binance Futures.
@@ -0,0 +1,123 @@
+package futures
+
+// Side is the side of an order.
+type Side string
+
+const (
+	SideBuy  Side = "BUY"
+	SideSell Side = "SELL"
+)
+
+// PositionSide is the position an order belongs to. It is BOTH in one-way
+// mode and LONG or SHORT in hedge mode.
+type PositionSide string
+
+const (
+	PositionSideBoth  PositionSide = "BOTH"
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+)
+
+// OrderType is the type of order.
+type OrderType string
+
+const (
+	OrderTypeLimit              OrderType = "LIMIT"
+	OrderTypeMarket             OrderType = "MARKET"
+	OrderTypeStop               OrderType = "STOP"
+	OrderTypeStopMarket         OrderType = "STOP_MARKET"
+	OrderTypeTakeProfit         OrderType = "TAKE_PROFIT"
+	OrderTypeTakeProfitMarket   OrderType = "TAKE_PROFIT_MARKET"
+	OrderTypeTrailingStopMarket OrderType = "TRAILING_STOP_MARKET"
+)
+
+// TimeInForce is how long an order remains active.
+type TimeInForce string
+
+const (
+	TimeInForceGTC TimeInForce = "GTC" // Good till cancelled.
+	TimeInForceIOC TimeInForce = "IOC" // Immediate or cancel.
+	TimeInForceFOK TimeInForce = "FOK" // Fill or kill.
+	TimeInForceGTX TimeInForce = "GTX" // Good till crossing, or post only.
+	TimeInForceGTD TimeInForce = "GTD" // Good till date.
+)
+
+// WorkingType is the price conditional orders are triggered by.
+type WorkingType string
+
+const (
+	WorkingTypeMarkPrice     WorkingType = "MARK_PRICE"
+	WorkingTypeContractPrice WorkingType = "CONTRACT_PRICE"
+)
+
+// OrderStatus is the state of an order.
+type OrderStatus string
+
+const (
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCanceled        OrderStatus = "CANCELED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
+	OrderStatusExpiredInMatch  OrderStatus = "EXPIRED_IN_MATCH"
+)
+
+// ResponseType is how much of a new order is returned.
+type ResponseType string
+
+const (
+	ResponseTypeACK    ResponseType = "ACK"
+	ResponseTypeResult ResponseType = "RESULT"
+)
+
+// SelfTradePrevention is what happens when an order would trade against
+// another order of the same account.
+type SelfTradePrevention string
+
+const (
+	SelfTradePreventionNone        SelfTradePrevention = "NONE"
+	SelfTradePreventionExpireTaker SelfTradePrevention = "EXPIRE_TAKER"
+	SelfTradePreventionExpireMaker SelfTradePrevention = "EXPIRE_MAKER"
+	SelfTradePreventionExpireBoth  SelfTradePrevention = "EXPIRE_BOTH"
+)
+
+// MarginType is how the margin of a symbol's positions is shared.
+type MarginType string
+
+const (
+	MarginTypeIsolated MarginType = "ISOLATED"
+	MarginTypeCrossed  MarginType = "CROSSED"
+)
+
+// MarginAdjustment is the direction of an isolated margin change.
+type MarginAdjustment int
+
+const (
+	MarginAdd    MarginAdjustment = 1
+	MarginReduce MarginAdjustment = 2
+)
+
+// IncomeType is the kind of an income history entry.
+type IncomeType string
+
+const (
+	IncomeTransfer                IncomeType = "TRANSFER"
+	IncomeWelcomeBonus            IncomeType = "WELCOME_BONUS"
+	IncomeRealizedPnL             IncomeType = "REALIZED_PNL"
+	IncomeFundingFee              IncomeType = "FUNDING_FEE"
+	IncomeCommission              IncomeType = "COMMISSION"
+	IncomeInsuranceClear          IncomeType = "INSURANCE_CLEAR"
+	IncomeReferralKickback        IncomeType = "REFERRAL_KICKBACK"
+	IncomeCommissionRebate        IncomeType = "COMMISSION_REBATE"
+	IncomeAPIRebate               IncomeType = "API_REBATE"
+	IncomeContestReward           IncomeType = "CONTEST_REWARD"
+	IncomeCrossCollateralTransfer IncomeType = "CROSS_COLLATERAL_TRANSFER"
+	IncomeOptionsPremiumFee       IncomeType = "OPTIONS_PREMIUM_FEE"
+	IncomeOptionsSettleProfit     IncomeType = "OPTIONS_SETTLE_PROFIT"
+	IncomeInternalTransfer        IncomeType = "INTERNAL_TRANSFER"
+	IncomeAutoExchange            IncomeType = "AUTO_EXCHANGE"
+	IncomeDeliveredSettlement     IncomeType = "DELIVERED_SETTELMENT" // Binance's spelling.
+	IncomeCoinSwapDeposit         IncomeType = "COIN_SWAP_DEPOSIT"
+	IncomeCoinSwapWithdraw        IncomeType = "COIN_SWAP_WITHDRAW"
+)
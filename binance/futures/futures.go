@@ -3,11 +3,22 @@ package futures
 import (
 	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
 	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/market"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/ws"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
 )
 
 type Futures interface {
 	Market() market.Market
 	Account() Account
+	Orders() Orders
+	// Streams returns a new client for the WebSocket market streams.
+	Streams() *ws.Client
+	// UserData returns a new user data stream of the account, on a
+	// connection of its own.
+	UserData() *ws.UserDataStream
+	// OrderBook returns a local order book of symbol, streamed on streams
+	// and built from the snapshots of Market.
+	OrderBook(streams *ws.Client, symbol string) *ws.OrderBook
 }
 
 type futureImpl struct {
@@ -20,10 +31,40 @@ func New(apiKey, apiSecret string, isTestnet bool) Futures {
 	}
 }
 
+// NewWithProvider creates the futures API with credentials read from p
+// before every signed request.
+func NewWithProvider(p credentials.Provider, isTestnet bool) Futures {
+	return &futureImpl{
+		client: client.NewFuturesClientWithProvider(p, isTestnet),
+	}
+}
+
+// NewWithClient creates the futures API on c, for clients built with
+// client.NewClient.
+func NewWithClient(c *client.Client) Futures {
+	return &futureImpl{client: c}
+}
+
 func (f *futureImpl) Account() Account {
 	return NewAccount(f.client)
 }
 
+func (f *futureImpl) Orders() Orders {
+	return NewOrders(f.client)
+}
+
 func (f *futureImpl) Market() market.Market {
 	return market.NewMarket(f.client)
 }
+
+func (f *futureImpl) Streams() *ws.Client {
+	return ws.NewClient(f.client.Config().WSBaseURL)
+}
+
+func (f *futureImpl) UserData() *ws.UserDataStream {
+	return ws.NewUserDataStream(f.client)
+}
+
+func (f *futureImpl) OrderBook(streams *ws.Client, symbol string) *ws.OrderBook {
+	return ws.NewOrderBook(streams, f.Market(), symbol)
+}
@@ -0,0 +1,409 @@
+package market
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Endpoints of the futures-specific market data.
+const (
+	premiumIndexEndpoint       = "/fapi/v1/premiumIndex"
+	fundingRateEndpoint        = "/fapi/v1/fundingRate"
+	fundingInfoEndpoint        = "/fapi/v1/fundingInfo"
+	openInterestEndpoint       = "/fapi/v1/openInterest"
+	openInterestHistEndpoint   = "/futures/data/openInterestHist"
+	topAccountRatioEndpoint    = "/futures/data/topLongShortAccountRatio"
+	topPositionRatioEndpoint   = "/futures/data/topLongShortPositionRatio"
+	globalAccountRatioEndpoint = "/futures/data/globalLongShortAccountRatio"
+	takerVolumeEndpoint        = "/futures/data/takerlongshortRatio"
+	basisEndpoint              = "/futures/data/basis"
+	continuousKlinesEndpoint   = "/fapi/v1/continuousKlines"
+	indexPriceKlinesEndpoint   = "/fapi/v1/indexPriceKlines"
+	markPriceKlinesEndpoint    = "/fapi/v1/markPriceKlines"
+	ticker24hrEndpoint         = "/fapi/v1/ticker/24hr"
+	tickerPriceEndpoint        = "/fapi/v2/ticker/price"
+	bookTickerEndpoint         = "/fapi/v1/ticker/bookTicker"
+)
+
+// Period is the interval of the statistics endpoints.
+type Period string
+
+const (
+	Period5m  Period = "5m"
+	Period15m Period = "15m"
+	Period30m Period = "30m"
+	Period1h  Period = "1h"
+	Period2h  Period = "2h"
+	Period4h  Period = "4h"
+	Period6h  Period = "6h"
+	Period12h Period = "12h"
+	Period1d  Period = "1d"
+)
+
+// ContractType is the kind of contract of a pair.
+type ContractType string
+
+const (
+	ContractPerpetual      ContractType = "PERPETUAL"
+	ContractCurrentQuarter ContractType = "CURRENT_QUARTER"
+	ContractNextQuarter    ContractType = "NEXT_QUARTER"
+)
+
+// PremiumIndex is the mark price and funding rate of a symbol.
+type PremiumIndex struct {
+	Symbol               string `json:"symbol"`
+	MarkPrice            string `json:"markPrice"`
+	IndexPrice           string `json:"indexPrice"`
+	EstimatedSettlePrice string `json:"estimatedSettlePrice"` // EstimatedSettlePrice is only meaningful in the last hour before settlement.
+	LastFundingRate      string `json:"lastFundingRate"`
+	InterestRate         string `json:"interestRate"`
+	NextFundingTime      int64  `json:"nextFundingTime"`
+	Time                 int64  `json:"time"`
+}
+
+// FundingRate is a funding rate that was applied.
+type FundingRate struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime int64  `json:"fundingTime"`
+	MarkPrice   string `json:"markPrice"`
+}
+
+// FundingInfo is the funding configuration of a symbol whose funding rate
+// was adjusted. Symbols absent from FundingInfo use the defaults.
+type FundingInfo struct {
+	Symbol                   string `json:"symbol"`
+	AdjustedFundingRateCap   string `json:"adjustedFundingRateCap"`
+	AdjustedFundingRateFloor string `json:"adjustedFundingRateFloor"`
+	FundingIntervalHours     int    `json:"fundingIntervalHours"`
+}
+
+// OpenInterest is the present open interest of a symbol.
+type OpenInterest struct {
+	Symbol       string `json:"symbol"`
+	OpenInterest string `json:"openInterest"`
+	Time         int64  `json:"time"`
+}
+
+// OpenInterestStat is the open interest of a symbol over a period.
+type OpenInterestStat struct {
+	Symbol               string `json:"symbol"`
+	SumOpenInterest      string `json:"sumOpenInterest"`
+	SumOpenInterestValue string `json:"sumOpenInterestValue"`
+	Timestamp            int64  `json:"timestamp"`
+}
+
+// LongShortRatio is the ratio of long to short accounts or positions of a
+// symbol over a period. LongAccount and ShortAccount are shares of 1.
+type LongShortRatio struct {
+	Symbol         string `json:"symbol"`
+	LongShortRatio string `json:"longShortRatio"`
+	LongAccount    string `json:"longAccount"`
+	ShortAccount   string `json:"shortAccount"`
+	Timestamp      int64  `json:"timestamp"`
+}
+
+// TakerVolume is the volume bought and sold by takers over a period.
+type TakerVolume struct {
+	BuySellRatio string `json:"buySellRatio"`
+	BuyVol       string `json:"buyVol"`
+	SellVol      string `json:"sellVol"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+// Basis is the difference between the futures and index prices of a pair
+// over a period.
+type Basis struct {
+	Pair                string       `json:"pair"`
+	ContractType        ContractType `json:"contractType"`
+	FuturesPrice        string       `json:"futuresPrice"`
+	IndexPrice          string       `json:"indexPrice"`
+	Basis               string       `json:"basis"`
+	BasisRate           string       `json:"basisRate"`
+	AnnualizedBasisRate string       `json:"annualizedBasisRate"`
+	Timestamp           int64        `json:"timestamp"`
+}
+
+// Ticker24hr is the price change statistics of a symbol over the last 24 hours.
+type Ticker24hr struct {
+	Symbol             string `json:"symbol"`
+	PriceChange        string `json:"priceChange"`
+	PriceChangePercent string `json:"priceChangePercent"`
+	WeightedAvgPrice   string `json:"weightedAvgPrice"`
+	LastPrice          string `json:"lastPrice"`
+	LastQty            string `json:"lastQty"`
+	OpenPrice          string `json:"openPrice"`
+	HighPrice          string `json:"highPrice"`
+	LowPrice           string `json:"lowPrice"`
+	Volume             string `json:"volume"`
+	QuoteVolume        string `json:"quoteVolume"`
+	OpenTime           int64  `json:"openTime"`
+	CloseTime          int64  `json:"closeTime"`
+	FirstID            int64  `json:"firstId"`
+	LastID             int64  `json:"lastId"`
+	Count              int64  `json:"count"`
+}
+
+// PriceTicker is the last price of a symbol.
+type PriceTicker struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+	Time   int64  `json:"time"`
+}
+
+// BookTicker is the best bid and ask of a symbol.
+type BookTicker struct {
+	Symbol   string `json:"symbol"`
+	BidPrice string `json:"bidPrice"`
+	BidQty   string `json:"bidQty"`
+	AskPrice string `json:"askPrice"`
+	AskQty   string `json:"askQty"`
+	Time     int64  `json:"time"`
+}
+
+// FundingRateRequest filters the funding rate history. Without StartTime
+// and EndTime, the most recent rates are returned.
+type FundingRateRequest struct {
+	Symbol    string // Symbol is optional.
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 100, with a maximum of 1000.
+}
+
+// StatisticsRequest filters the statistics endpoints: open interest
+// history, long/short ratios and taker volume. Only the last 30 days are
+// available.
+type StatisticsRequest struct {
+	Symbol    string
+	Period    Period
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 30, with a maximum of 500.
+}
+
+func (r *StatisticsRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	v.Set("period", string(r.Period))
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt(v, "limit", r.Limit)
+	return v
+}
+
+// BasisRequest filters the basis of a pair.
+type BasisRequest struct {
+	Pair         string
+	ContractType ContractType
+	Period       Period
+	StartTime    time.Time
+	EndTime      time.Time
+	Limit        int // Limit defaults to 30, with a maximum of 500.
+}
+
+// MarkPrice returns the mark price and funding rate of symbol.
+func (m *marketImpl) MarkPrice(symbol string) (*PremiumIndex, error) {
+	var index PremiumIndex
+	if err := m.get(premiumIndexEndpoint, url.Values{"symbol": {symbol}}, &index); err != nil {
+		return nil, fmt.Errorf("failed to get mark price: %w", err)
+	}
+	return &index, nil
+}
+
+// MarkPrices returns the mark prices and funding rates of every symbol.
+func (m *marketImpl) MarkPrices() ([]PremiumIndex, error) {
+	var indexes []PremiumIndex
+	if err := m.get(premiumIndexEndpoint, nil, &indexes); err != nil {
+		return nil, fmt.Errorf("failed to get mark prices: %w", err)
+	}
+	return indexes, nil
+}
+
+// FundingRateHistory returns the funding rates applied, oldest first.
+func (m *marketImpl) FundingRateHistory(req *FundingRateRequest) ([]FundingRate, error) {
+	v := url.Values{}
+	setIf(v, "symbol", req.Symbol)
+	setTime(v, "startTime", req.StartTime)
+	setTime(v, "endTime", req.EndTime)
+	setInt(v, "limit", req.Limit)
+	var rates []FundingRate
+	if err := m.get(fundingRateEndpoint, v, &rates); err != nil {
+		return nil, fmt.Errorf("failed to get funding rate history: %w", err)
+	}
+	return rates, nil
+}
+
+// FundingInfo returns the funding configuration of the symbols whose
+// funding rate cap, floor or interval was adjusted.
+func (m *marketImpl) FundingInfo() ([]FundingInfo, error) {
+	var info []FundingInfo
+	if err := m.get(fundingInfoEndpoint, nil, &info); err != nil {
+		return nil, fmt.Errorf("failed to get funding info: %w", err)
+	}
+	return info, nil
+}
+
+// OpenInterest returns the present open interest of symbol.
+func (m *marketImpl) OpenInterest(symbol string) (*OpenInterest, error) {
+	var oi OpenInterest
+	if err := m.get(openInterestEndpoint, url.Values{"symbol": {symbol}}, &oi); err != nil {
+		return nil, fmt.Errorf("failed to get open interest: %w", err)
+	}
+	return &oi, nil
+}
+
+// OpenInterestHistory returns the open interest of a symbol per period.
+func (m *marketImpl) OpenInterestHistory(req *StatisticsRequest) ([]OpenInterestStat, error) {
+	var stats []OpenInterestStat
+	if err := m.get(openInterestHistEndpoint, req.values(), &stats); err != nil {
+		return nil, fmt.Errorf("failed to get open interest history: %w", err)
+	}
+	return stats, nil
+}
+
+// TopTraderAccountRatio returns the long/short ratio of the accounts of the
+// top 20% traders by margin balance.
+func (m *marketImpl) TopTraderAccountRatio(req *StatisticsRequest) ([]LongShortRatio, error) {
+	var ratios []LongShortRatio
+	if err := m.get(topAccountRatioEndpoint, req.values(), &ratios); err != nil {
+		return nil, fmt.Errorf("failed to get top trader account ratio: %w", err)
+	}
+	return ratios, nil
+}
+
+// TopTraderPositionRatio returns the long/short ratio of the positions of
+// the top 20% traders by margin balance.
+func (m *marketImpl) TopTraderPositionRatio(req *StatisticsRequest) ([]LongShortRatio, error) {
+	var ratios []LongShortRatio
+	if err := m.get(topPositionRatioEndpoint, req.values(), &ratios); err != nil {
+		return nil, fmt.Errorf("failed to get top trader position ratio: %w", err)
+	}
+	return ratios, nil
+}
+
+// GlobalAccountRatio returns the long/short ratio of every account with a
+// position.
+func (m *marketImpl) GlobalAccountRatio(req *StatisticsRequest) ([]LongShortRatio, error) {
+	var ratios []LongShortRatio
+	if err := m.get(globalAccountRatioEndpoint, req.values(), &ratios); err != nil {
+		return nil, fmt.Errorf("failed to get global account ratio: %w", err)
+	}
+	return ratios, nil
+}
+
+// TakerVolume returns the volume bought and sold by takers per period.
+func (m *marketImpl) TakerVolume(req *StatisticsRequest) ([]TakerVolume, error) {
+	var volumes []TakerVolume
+	if err := m.get(takerVolumeEndpoint, req.values(), &volumes); err != nil {
+		return nil, fmt.Errorf("failed to get taker volume: %w", err)
+	}
+	return volumes, nil
+}
+
+// Basis returns the basis of a pair per period.
+func (m *marketImpl) Basis(req *BasisRequest) ([]Basis, error) {
+	v := url.Values{}
+	v.Set("pair", req.Pair)
+	v.Set("contractType", string(req.ContractType))
+	v.Set("period", string(req.Period))
+	setTime(v, "startTime", req.StartTime)
+	setTime(v, "endTime", req.EndTime)
+	setInt(v, "limit", req.Limit)
+	var basis []Basis
+	if err := m.get(basisEndpoint, v, &basis); err != nil {
+		return nil, fmt.Errorf("failed to get basis: %w", err)
+	}
+	return basis, nil
+}
+
+// ContinuousKlines returns the klines of a pair's contract of contractType,
+// across its successive deliveries.
+func (m *marketImpl) ContinuousKlines(pair string, contractType ContractType, interval Interval, startTime, endTime int64, limit int) ([]Kline, error) {
+	v := klineValues(interval, startTime, endTime, limit)
+	v.Set("pair", pair)
+	v.Set("contractType", string(contractType))
+	var klines []Kline
+	if err := m.get(continuousKlinesEndpoint, v, &klines); err != nil {
+		return nil, fmt.Errorf("failed to get continuous klines: %w", err)
+	}
+	return klines, nil
+}
+
+// IndexPriceKlines returns the klines of the index price of pair. Volumes
+// are always zero.
+func (m *marketImpl) IndexPriceKlines(pair string, interval Interval, startTime, endTime int64, limit int) ([]Kline, error) {
+	v := klineValues(interval, startTime, endTime, limit)
+	v.Set("pair", pair)
+	var klines []Kline
+	if err := m.get(indexPriceKlinesEndpoint, v, &klines); err != nil {
+		return nil, fmt.Errorf("failed to get index price klines: %w", err)
+	}
+	return klines, nil
+}
+
+// MarkPriceKlines returns the klines of the mark price of symbol. Volumes
+// are always zero.
+func (m *marketImpl) MarkPriceKlines(symbol string, interval Interval, startTime, endTime int64, limit int) ([]Kline, error) {
+	v := klineValues(interval, startTime, endTime, limit)
+	v.Set("symbol", symbol)
+	var klines []Kline
+	if err := m.get(markPriceKlinesEndpoint, v, &klines); err != nil {
+		return nil, fmt.Errorf("failed to get mark price klines: %w", err)
+	}
+	return klines, nil
+}
+
+// Ticker24hr returns the price change statistics of symbol.
+func (m *marketImpl) Ticker24hr(symbol string) (*Ticker24hr, error) {
+	var ticker Ticker24hr
+	if err := m.get(ticker24hrEndpoint, url.Values{"symbol": {symbol}}, &ticker); err != nil {
+		return nil, fmt.Errorf("failed to get 24hr ticker: %w", err)
+	}
+	return &ticker, nil
+}
+
+// Tickers24hr returns the price change statistics of every symbol.
+func (m *marketImpl) Tickers24hr() ([]Ticker24hr, error) {
+	var tickers []Ticker24hr
+	if err := m.get(ticker24hrEndpoint, nil, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get 24hr tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+// PriceTicker returns the last price of symbol.
+func (m *marketImpl) PriceTicker(symbol string) (*PriceTicker, error) {
+	var ticker PriceTicker
+	if err := m.get(tickerPriceEndpoint, url.Values{"symbol": {symbol}}, &ticker); err != nil {
+		return nil, fmt.Errorf("failed to get price ticker: %w", err)
+	}
+	return &ticker, nil
+}
+
+// PriceTickers returns the last price of every symbol.
+func (m *marketImpl) PriceTickers() ([]PriceTicker, error) {
+	var tickers []PriceTicker
+	if err := m.get(tickerPriceEndpoint, nil, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get price tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+// BookTicker returns the best bid and ask of symbol.
+func (m *marketImpl) BookTicker(symbol string) (*BookTicker, error) {
+	var ticker BookTicker
+	if err := m.get(bookTickerEndpoint, url.Values{"symbol": {symbol}}, &ticker); err != nil {
+		return nil, fmt.Errorf("failed to get book ticker: %w", err)
+	}
+	return &ticker, nil
+}
+
+// BookTickers returns the best bid and ask of every symbol.
+func (m *marketImpl) BookTickers() ([]BookTicker, error) {
+	var tickers []BookTicker
+	if err := m.get(bookTickerEndpoint, nil, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get book tickers: %w", err)
+	}
+	return tickers, nil
+}
@@ -0,0 +1,33 @@
+package market
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarket_OpenInterestHistory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/futures/data/openInterestHist", r.URL.Path)
+		assert.Equal(t, "limit=2&period=1h&startTime=1700000000000&symbol=BTCUSDT", r.URL.RawQuery)
+		_, _ = w.Write([]byte(`[{"symbol":"BTCUSDT","sumOpenInterest":"100.5","sumOpenInterestValue":"3015000","timestamp":1700000000000}]`))
+	}))
+	t.Cleanup(srv.Close)
+	m := NewMarket(client.NewClient(client.Config{BaseURL: srv.URL}))
+
+	stats, err := m.OpenInterestHistory(&StatisticsRequest{
+		Symbol:    "BTCUSDT",
+		Period:    Period1h,
+		StartTime: time.UnixMilli(1700000000000),
+		Limit:     2,
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "100.5", stats[0].SumOpenInterest)
+	assert.Equal(t, int64(1700000000000), stats[0].Timestamp)
+}
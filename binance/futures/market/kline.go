@@ -0,0 +1,108 @@
+package market
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Interval is the length of a kline.
+type Interval string
+
+const (
+	OneMinute      Interval = "1m"
+	ThreeMinutes   Interval = "3m"
+	FiveMinutes    Interval = "5m"
+	FifteenMinutes Interval = "15m"
+	ThirtyMinutes  Interval = "30m"
+	OneHour        Interval = "1h"
+	TwoHours       Interval = "2h"
+	FourHours      Interval = "4h"
+	SixHours       Interval = "6h"
+	EightHours     Interval = "8h"
+	TwelveHours    Interval = "12h"
+	OneDay         Interval = "1d"
+	ThreeDays      Interval = "3d"
+	OneWeek        Interval = "1w"
+	OneMonth       Interval = "1M"
+)
+
+// intervals maps the intervals Binance supports to their length. A month is
+// counted as 30 days.
+var intervals = map[Interval]time.Duration{
+	OneMinute:      time.Minute,
+	ThreeMinutes:   3 * time.Minute,
+	FiveMinutes:    5 * time.Minute,
+	FifteenMinutes: 15 * time.Minute,
+	ThirtyMinutes:  30 * time.Minute,
+	OneHour:        time.Hour,
+	TwoHours:       2 * time.Hour,
+	FourHours:      4 * time.Hour,
+	SixHours:       6 * time.Hour,
+	EightHours:     8 * time.Hour,
+	TwelveHours:    12 * time.Hour,
+	OneDay:         24 * time.Hour,
+	ThreeDays:      3 * 24 * time.Hour,
+	OneWeek:        7 * 24 * time.Hour,
+	OneMonth:       30 * 24 * time.Hour,
+}
+
+// Validate returns an error if Binance does not support i.
+func (i Interval) Validate() error {
+	if _, ok := intervals[i]; !ok {
+		return fmt.Errorf("unsupported interval %q", string(i))
+	}
+	return nil
+}
+
+// Duration returns the length of i, or 0 if it is not supported.
+func (i Interval) Duration() time.Duration {
+	return intervals[i]
+}
+
+// klineFields is the number of fields of a kline row used by Kline.
+const klineFields = 11
+
+// Kline is a candlestick. The volumes of index and mark price klines are
+// always zero.
+type Kline struct {
+	OpenTime            time.Time
+	CloseTime           time.Time
+	Open                decimal.Decimal
+	High                decimal.Decimal
+	Low                 decimal.Decimal
+	Close               decimal.Decimal
+	Volume              decimal.Decimal
+	QuoteVolume         decimal.Decimal
+	Trades              int64
+	TakerBuyVolume      decimal.Decimal
+	TakerBuyQuoteVolume decimal.Decimal
+}
+
+// UnmarshalJSON decodes a kline from the array Binance sends:
+// [openTime, open, high, low, close, volume, closeTime, quoteVolume,
+// trades, takerBuyVolume, takerBuyQuoteVolume, ignore].
+func (k *Kline) UnmarshalJSON(data []byte) error {
+	var row []json.RawMessage
+	if err := json.Unmarshal(data, &row); err != nil {
+		return fmt.Errorf("failed to decode kline: %w", err)
+	}
+	if len(row) < klineFields {
+		return fmt.Errorf("failed to decode kline: %d fields, want %d", len(row), klineFields)
+	}
+
+	var openTime, closeTime int64
+	fields := []any{
+		&openTime, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume,
+		&closeTime, &k.QuoteVolume, &k.Trades, &k.TakerBuyVolume, &k.TakerBuyQuoteVolume,
+	}
+	for i, field := range fields {
+		if err := json.Unmarshal(row[i], field); err != nil {
+			return fmt.Errorf("failed to decode kline field %d: %w", i, err)
+		}
+	}
+	k.OpenTime, k.CloseTime = time.UnixMilli(openTime), time.UnixMilli(closeTime)
+	return nil
+}
@@ -0,0 +1,36 @@
+package market
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKline_UnmarshalJSON(t *testing.T) {
+	var klines []Kline
+	err := json.Unmarshal([]byte(`[[1700000000000,"100.1","102","99.5","101","12.5",1700000059999,"1262.5",42,"6","606","0"]]`), &klines)
+	require.NoError(t, err)
+	require.Len(t, klines, 1)
+
+	k := klines[0]
+	assert.Equal(t, int64(1700000000000), k.OpenTime.UnixMilli())
+	assert.Equal(t, int64(1700000059999), k.CloseTime.UnixMilli())
+	assert.True(t, k.Open.Equal(decimal.RequireFromString("100.1")))
+	assert.True(t, k.Low.Equal(decimal.RequireFromString("99.5")))
+	assert.True(t, k.QuoteVolume.Equal(decimal.RequireFromString("1262.5")))
+	assert.Equal(t, int64(42), k.Trades)
+	assert.True(t, k.TakerBuyQuoteVolume.Equal(decimal.NewFromInt(606)))
+
+	assert.Error(t, json.Unmarshal([]byte(`[[1700000000000,"100.1"]]`), &klines))
+}
+
+func TestInterval_Validate(t *testing.T) {
+	assert.NoError(t, EightHours.Validate())
+	assert.Equal(t, 4*time.Hour, FourHours.Duration())
+	assert.Error(t, Interval("7m").Validate())
+	assert.Zero(t, Interval("7m").Duration())
+}
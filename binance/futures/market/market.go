@@ -1,14 +1,23 @@
 package market
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
 	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/constants"
 	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/models"
 )
 
+// Endpoints of the market data not listed in constants.
+const (
+	historicalTradesEndpoint = "/fapi/v1/historicalTrades"
+	aggTradesEndpoint        = "/fapi/v1/aggTrades"
+	klinesEndpoint           = "/fapi/v1/klines"
+)
+
 // Market defines the interface for market operations.
 type Market interface {
 	Ping() (any, error)
@@ -18,7 +27,29 @@ type Market interface {
 	RecentTradesList(symbol string, limit int) ([]Trade, error)
 	OldTradesLookup(symbol string, limit int, fromId int64) ([]Trade, error)
 	CompressedAggregateTradesList(symbol string, fromId, startTime, endTime int64, limit int) ([]AggregateTrade, error)
-	KlineCandlestickData(symbol string, interval Interval, startTime, endTime int64, limit int) ([][]any, error)
+	KlineCandlestickData(symbol string, interval Interval, startTime, endTime int64, limit int) ([]Kline, error)
+	ContinuousKlines(pair string, contractType ContractType, interval Interval, startTime, endTime int64, limit int) ([]Kline, error)
+	IndexPriceKlines(pair string, interval Interval, startTime, endTime int64, limit int) ([]Kline, error)
+	MarkPriceKlines(symbol string, interval Interval, startTime, endTime int64, limit int) ([]Kline, error)
+
+	MarkPrice(symbol string) (*PremiumIndex, error)
+	MarkPrices() ([]PremiumIndex, error)
+	FundingRateHistory(req *FundingRateRequest) ([]FundingRate, error)
+	FundingInfo() ([]FundingInfo, error)
+	OpenInterest(symbol string) (*OpenInterest, error)
+	OpenInterestHistory(req *StatisticsRequest) ([]OpenInterestStat, error)
+	TopTraderAccountRatio(req *StatisticsRequest) ([]LongShortRatio, error)
+	TopTraderPositionRatio(req *StatisticsRequest) ([]LongShortRatio, error)
+	GlobalAccountRatio(req *StatisticsRequest) ([]LongShortRatio, error)
+	TakerVolume(req *StatisticsRequest) ([]TakerVolume, error)
+	Basis(req *BasisRequest) ([]Basis, error)
+
+	Ticker24hr(symbol string) (*Ticker24hr, error)
+	Tickers24hr() ([]Ticker24hr, error)
+	PriceTicker(symbol string) (*PriceTicker, error)
+	PriceTickers() ([]PriceTicker, error)
+	BookTicker(symbol string) (*BookTicker, error)
+	BookTickers() ([]BookTicker, error)
 }
 
 type marketImpl struct {
@@ -30,15 +61,6 @@ func NewMarket(client *client.Client) Market {
 	return &marketImpl{client}
 }
 
-// buildEndpoint creates a formatted API endpoint string.
-func buildEndpoint(base string, symbol string, params ...any) string {
-	endpoint := fmt.Sprintf(base, symbol)
-	for _, param := range params {
-		endpoint += fmt.Sprintf("&%s", param)
-	}
-	return endpoint
-}
-
 // Ping checks the connectivity to the Binance API server.
 func (m *marketImpl) Ping() (any, error) {
 	var responseData struct{}
@@ -56,21 +78,34 @@ func (m *marketImpl) CheckServerTime() (int64, error) {
 	return responseData.ServerTime, nil
 }
 
-// GetExchangeInfo fetches exchange information from the Binance API.
+// GetExchangeInfo fetches exchange information from the Binance API. The
+// rate limits it lists replace the ones of the client's limiter.
 func (m *marketImpl) GetExchangeInfo() (*models.ExchangeInfo, error) {
 	var response models.ExchangeInfo
 	if err := m.MakeRequestWithoutSignature(http.MethodGet, constants.ExchangeInfoEndpoint, &response); err != nil {
 		return nil, err
 	}
 
+	limits := make([]client.RateLimit, 0, len(response.RateLimits))
+	for _, rl := range response.RateLimits {
+		limit, err := client.ParseRateLimit(rl.RateLimitType, rl.Interval, rl.IntervalNum, rl.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rate limits: %w", err)
+		}
+		limits = append(limits, limit)
+	}
+	if len(limits) > 0 {
+		m.Limiter().SetLimits(limits)
+	}
 	return &response, nil
 }
 
 // OrderBook retrieves the order book for a specific symbol.
 func (m *marketImpl) OrderBook(symbol string, limit int) (*OrderBookResponse, error) {
-	endpoint := buildEndpoint("/fapi/v1/depth?symbol=%s", symbol, fmt.Sprintf("limit=%d", limit))
+	v := url.Values{"symbol": {symbol}}
+	setInt(v, "limit", limit)
 	response := new(OrderBookResponse)
-	if err := m.MakeRequestWithoutSignature(http.MethodGet, endpoint, response); err != nil {
+	if err := m.get(constants.OrderBookEndpoint, v, response); err != nil {
 		return nil, fmt.Errorf("failed to get order book: %w", err)
 	}
 	return response, nil
@@ -78,9 +113,10 @@ func (m *marketImpl) OrderBook(symbol string, limit int) (*OrderBookResponse, er
 
 // RecentTradesList retrieves the recent trades for a specific symbol.
 func (m *marketImpl) RecentTradesList(symbol string, limit int) ([]Trade, error) {
-	endpoint := buildEndpoint("/fapi/v1/trades?symbol=%s&limit=%d", symbol, fmt.Sprintf("limit=%d", limit))
+	v := url.Values{"symbol": {symbol}}
+	setInt(v, "limit", limit)
 	var trades []Trade
-	if err := m.MakeRequestWithoutSignature(http.MethodGet, endpoint, &trades); err != nil {
+	if err := m.get(constants.RecentTradesEndpoint, v, &trades); err != nil {
 		return nil, fmt.Errorf("failed to get recent trades: %w", err)
 	}
 	return trades, nil
@@ -88,75 +124,39 @@ func (m *marketImpl) RecentTradesList(symbol string, limit int) ([]Trade, error)
 
 // OldTradesLookup retrieves older market historical trades for a specific symbol.
 func (m *marketImpl) OldTradesLookup(symbol string, limit int, fromId int64) ([]Trade, error) {
-	var params []any
-
-	if limit != -1 {
-		params = append(params, fmt.Sprintf("limit=%d", limit))
-	}
-	if fromId != -1 {
-		params = append(params, fmt.Sprintf("fromId=%d", fromId))
-	}
-
-	endpoint := buildEndpoint("/fapi/v1/historicalTrades?symbol=%s", symbol, params...)
+	v := url.Values{"symbol": {symbol}}
+	setOptional(v, "limit", int64(limit))
+	setOptional(v, "fromId", fromId)
 	var trades []Trade
-
-	if err := m.MakeAuthenticatedRequest(http.MethodGet, endpoint, "", &trades); err != nil {
+	// Historical trades require the API key, but no signature.
+	err := m.Do(context.Background(), http.MethodGet, historicalTradesEndpoint, v, client.SecurityAPIKey, &trades)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get historical trades: %w", err)
 	}
-
 	return trades, nil
 }
 
 // CompressedAggregateTradesList retrieves compressed, aggregate market trades for a specific symbol.
 func (m *marketImpl) CompressedAggregateTradesList(symbol string, fromID, startTime, endTime int64, limit int) ([]AggregateTrade, error) {
-	var params []any
-
-	if fromID != -1 {
-		params = append(params, fmt.Sprintf("fromId=%d", fromID))
-	}
-	if startTime != -1 {
-		params = append(params, fmt.Sprintf("startTime=%d", startTime))
-	}
-	if endTime != -1 {
-		params = append(params, fmt.Sprintf("endTime=%d", endTime))
-	}
-	if limit != -1 {
-		params = append(params, fmt.Sprintf("limit=%d", limit))
-	}
-
-	endpoint := buildEndpoint("/fapi/v1/aggTrades?symbol=%s", symbol, params...)
+	v := url.Values{"symbol": {symbol}}
+	setOptional(v, "fromId", fromID)
+	setOptional(v, "startTime", startTime)
+	setOptional(v, "endTime", endTime)
+	setOptional(v, "limit", int64(limit))
 	var aggTrades []AggregateTrade
-
-	if err := m.MakeRequestWithoutSignature(http.MethodGet, endpoint, &aggTrades); err != nil {
+	if err := m.get(aggTradesEndpoint, v, &aggTrades); err != nil {
 		return nil, fmt.Errorf("failed to get aggregate trades: %w", err)
 	}
-
 	return aggTrades, nil
 }
 
-// KlineCandlestickData retrieves kline candlestick data for a specific symbol.
-func (m *marketImpl) KlineCandlestickData(symbol string, interval Interval, startTime, endTime int64, limit int) ([][]any, error) {
-	var params []any
-
-	if interval != "" {
-		params = append(params, fmt.Sprintf("interval=%s", interval))
-	}
-	if startTime != -1 {
-		params = append(params, fmt.Sprintf("startTime=%d", startTime))
-	}
-	if endTime != -1 {
-		params = append(params, fmt.Sprintf("endTime=%d", endTime))
-	}
-	if limit != -1 {
-		params = append(params, fmt.Sprintf("limit=%d", limit))
-	}
-
-	endpoint := buildEndpoint("/fapi/v1/klines?symbol=%s", symbol, params...)
-	var klines [][]any
-
-	if err := m.MakeRequestWithoutSignature(http.MethodGet, endpoint, &klines); err != nil {
+// KlineCandlestickData retrieves kline candlestick data for a specific symbol, oldest first.
+func (m *marketImpl) KlineCandlestickData(symbol string, interval Interval, startTime, endTime int64, limit int) ([]Kline, error) {
+	v := klineValues(interval, startTime, endTime, limit)
+	v.Set("symbol", symbol)
+	var klines []Kline
+	if err := m.get(klinesEndpoint, v, &klines); err != nil {
 		return nil, fmt.Errorf("failed to get kline candlestick data: %w", err)
 	}
-
 	return klines, nil
 }
@@ -0,0 +1,55 @@
+package market
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+)
+
+// get sends a public GET request with params and decodes the response into out.
+func (m *marketImpl) get(endpoint string, params url.Values, out any) error {
+	return m.Do(context.Background(), http.MethodGet, endpoint, params, client.SecurityNone, out)
+}
+
+// klineValues returns the parameters shared by the kline endpoints.
+func klineValues(interval Interval, startTime, endTime int64, limit int) url.Values {
+	v := url.Values{}
+	v.Set("interval", string(interval))
+	setOptional(v, "startTime", startTime)
+	setOptional(v, "endTime", endTime)
+	setOptional(v, "limit", int64(limit))
+	return v
+}
+
+// setIf sets key unless value is empty.
+func setIf(v url.Values, key, value string) {
+	if value != "" {
+		v.Set(key, value)
+	}
+}
+
+// setInt sets key unless n is 0 or less.
+func setInt(v url.Values, key string, n int) {
+	if n > 0 {
+		v.Set(key, strconv.Itoa(n))
+	}
+}
+
+// setOptional sets key unless n is -1, the value the methods taking
+// positional parameters use for parameters left out.
+func setOptional(v url.Values, key string, n int64) {
+	if n != -1 {
+		v.Set(key, strconv.FormatInt(n, 10))
+	}
+}
+
+// setTime sets key to t in milliseconds, unless t is zero.
+func setTime(v url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		v.Set(key, strconv.FormatInt(t.UnixMilli(), 10))
+	}
+}
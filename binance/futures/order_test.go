@@ -0,0 +1,54 @@
+package futures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrderRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  NewOrderRequest
+		ok   bool
+	}{
+		{"limit", NewOrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: "1", Price: "100"}, true},
+		{"limit without price", NewOrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Quantity: "1"}, false},
+		{"market", NewOrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeMarket, Quantity: "1"}, true},
+		{"stop without stop price", NewOrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStop, Quantity: "1", Price: "90"}, false},
+		{"close position", NewOrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStopMarket, StopPrice: "90", ClosePosition: true}, true},
+		{"close position with quantity", NewOrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeStopMarket, StopPrice: "90", ClosePosition: true, Quantity: "1"}, false},
+		{"close position on limit", NewOrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeLimit, ClosePosition: true}, false},
+		{"trailing stop", NewOrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeTrailingStopMarket, Quantity: "1", CallbackRate: "1"}, true},
+		{"trailing stop without rate", NewOrderRequest{Symbol: "BTCUSDT", Side: SideSell, Type: OrderTypeTrailingStopMarket, Quantity: "1"}, false},
+		{"GTD without date", NewOrderRequest{Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGTD, Quantity: "1", Price: "100"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidOrder)
+			}
+		})
+	}
+}
+
+func TestNewOrderRequest_Values(t *testing.T) {
+	req := NewOrderRequest{
+		Symbol:        "BTCUSDT",
+		Side:          SideSell,
+		PositionSide:  PositionSideShort,
+		Type:          OrderTypeTakeProfitMarket,
+		StopPrice:     "90",
+		ClosePosition: true,
+		WorkingType:   WorkingTypeMarkPrice,
+		TimeInForce:   TimeInForceGTD,
+		GoodTillDate:  time.UnixMilli(1700000000000),
+	}
+	assert.Equal(t,
+		"closePosition=true&goodTillDate=1700000000000&positionSide=SHORT&side=SELL&stopPrice=90&symbol=BTCUSDT&timeInForce=GTD&type=TAKE_PROFIT_MARKET&workingType=MARK_PRICE",
+		req.values().Encode())
+}
@@ -0,0 +1,473 @@
+// Package ws implements the Binance USDⓈ-M futures WebSocket market streams.
+//
+// A Client holds one combined-stream connection. Streams are subscribed and
+// unsubscribed while it is open, and replayed after it reconnects. Binance
+// closes connections after 24 hours, so the client replaces them before
+// they reach that age.
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/logger"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// DefaultAckTimeout is how long subscribe and unsubscribe requests wait
+	// for the server's answer by default.
+	DefaultAckTimeout = 10 * time.Second
+	// DefaultStaleTimeout is how long a connection may go without a message
+	// or a ping by default. Binance pings every 3 minutes.
+	DefaultStaleTimeout = 10 * time.Minute
+	// DefaultMaxConnectionAge is the age at which connections are replaced by
+	// default, ahead of Binance closing them after 24 hours.
+	DefaultMaxConnectionAge = 23*time.Hour + 30*time.Minute
+	// MaxStreams is the largest number of streams a connection carries.
+	MaxStreams = 1024
+
+	combinedPath      = "/stream"
+	writeWait         = 10 * time.Second
+	methodSubscribe   = "SUBSCRIBE"
+	methodUnsubscribe = "UNSUBSCRIBE"
+)
+
+var (
+	// ErrClosed is returned by the methods of a closed client.
+	ErrClosed = errors.New("websocket client closed")
+	// ErrAckTimeout is returned when the server does not answer a request
+	// within the client's AckTimeout.
+	ErrAckTimeout = errors.New("timed out waiting for acknowledgement")
+	// ErrStaleConnection is reported when neither a message nor a ping
+	// arrived within StaleTimeout. The client reconnects when it happens.
+	ErrStaleConnection = errors.New("websocket connection is stale")
+	// ErrTooManyStreams is returned when a subscription would exceed MaxStreams.
+	ErrTooManyStreams = errors.New("too many streams")
+)
+
+// Handler processes the data of a stream message.
+type Handler func(data json.RawMessage)
+
+// RequestError is returned when the server rejects a request.
+type RequestError struct {
+	Method  string
+	Streams []string
+	Code    int
+	Message string
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%s %s rejected: code %d: %s", e.Method, strings.Join(e.Streams, ","), e.Code, e.Message)
+}
+
+// request is a frame sent to the server.
+type request struct {
+	Method string   `json:"method"`
+	Params []string `json:"params"`
+	ID     int64    `json:"id"`
+}
+
+// message is a frame received from the server: stream data, or the answer
+// to a request.
+type message struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	ID     *int64          `json:"id"`
+	Code   int             `json:"code"`
+	Msg    string          `json:"msg"`
+	Error  *struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"error"`
+}
+
+// Client is a combined-stream WebSocket connection.
+type Client struct {
+	// URL is the base URL of the streams, such as constants.ProductionWSURL.
+	URL string
+	// AckTimeout bounds how long requests wait for the server's answer; 0
+	// uses DefaultAckTimeout.
+	AckTimeout time.Duration
+	// StaleTimeout is how long the connection may go without a message or a
+	// ping before it is considered dead; 0 uses DefaultStaleTimeout.
+	StaleTimeout time.Duration
+	// MaxConnectionAge is the age at which the connection is replaced; 0
+	// uses DefaultMaxConnectionAge.
+	MaxConnectionAge time.Duration
+	Reconnect        ReconnectPolicy
+	OnEvent          func(event ConnectionEvent)
+	// OnError is called with errors that no method returns, such as
+	// connections dropping and messages that cannot be decoded.
+	OnError func(err error)
+	// Dialer dials the connections; nil uses websocket.DefaultDialer.
+	Dialer *websocket.Dialer
+
+	dialMu    sync.Mutex
+	closeOnce sync.Once
+	writeMu   sync.Mutex
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	closed   bool
+	handlers map[string]Handler
+	pending  map[int64]chan *RequestError
+	nextID   int64
+	logger   logger.Interface
+
+	done chan struct{}
+}
+
+// NewClient creates a client for the streams served at baseURL. It connects
+// on the first subscription.
+func NewClient(baseURL string) *Client {
+	return &Client{
+		URL:       baseURL,
+		Reconnect: DefaultReconnectPolicy,
+		logger:    logger.Nop,
+		done:      make(chan struct{}),
+	}
+}
+
+// SetLogger sets the logger the client writes to; nil discards the logs.
+func (c *Client) SetLogger(l logger.Interface) {
+	if l == nil {
+		l = logger.Nop
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger = l
+}
+
+func (c *Client) log() logger.Interface {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.logger
+}
+
+// Connect connects the client. It is a no-op if the client is already connected.
+func (c *Client) Connect() error {
+	c.dialMu.Lock()
+	defer c.dialMu.Unlock()
+
+	c.mu.Lock()
+	closed, conn := c.closed, c.conn
+	c.mu.Unlock()
+	if closed {
+		return ErrClosed
+	}
+	if conn != nil {
+		return nil
+	}
+
+	dialer := c.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	url := strings.TrimSuffix(c.URL, "/") + combinedPath
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to dial %s: %w", url, err)
+	}
+
+	stale := c.staleTimeout()
+	conn.SetPingHandler(func(appData string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(stale))
+		err := conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(writeWait))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		_ = conn.Close()
+		return ErrClosed
+	}
+	c.conn = conn
+	c.mu.Unlock()
+
+	c.log().Info("connected", "url", url)
+	exited := make(chan struct{})
+	go c.readLoop(conn, exited)
+	go c.expire(conn, exited)
+	return nil
+}
+
+// Close closes the connection. The client cannot be used afterwards.
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.closed = true
+		close(c.done)
+		conn := c.conn
+		c.conn = nil
+		c.mu.Unlock()
+
+		if conn != nil {
+			msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+			_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+			_ = conn.Close()
+		}
+		c.log().Info("connection closed")
+	})
+}
+
+// Subscribe subscribes to streams and calls handler with the data of their
+// messages. Streams already subscribed get handler instead of their previous
+// one. Subscribe connects the client if needed and returns once the server
+// has accepted the streams; a rejection is reported as a *RequestError.
+func (c *Client) Subscribe(handler Handler, streams ...string) error {
+	if handler == nil {
+		return errors.New("handler must not be nil")
+	}
+	if len(streams) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	if c.handlers == nil {
+		c.handlers = make(map[string]Handler)
+	}
+	var fresh []string
+	for _, stream := range streams {
+		if _, ok := c.handlers[stream]; !ok && !contains(fresh, stream) {
+			fresh = append(fresh, stream)
+		}
+	}
+	if len(c.handlers)+len(fresh) > MaxStreams {
+		c.mu.Unlock()
+		return fmt.Errorf("%w: %d streams are subscribed, at most %d are allowed", ErrTooManyStreams, len(c.handlers), MaxStreams)
+	}
+	for _, stream := range streams {
+		c.handlers[stream] = handler
+	}
+	c.mu.Unlock()
+
+	err := c.Connect()
+	if err == nil {
+		err = c.request(methodSubscribe, fresh)
+	}
+	if err != nil {
+		c.removeHandlers(fresh)
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from streams. Streams that are not subscribed are ignored.
+func (c *Client) Unsubscribe(streams ...string) error {
+	removed := c.removeHandlers(streams)
+	c.mu.Lock()
+	connected := c.conn != nil
+	c.mu.Unlock()
+	if len(removed) == 0 || !connected {
+		return nil
+	}
+	if err := c.request(methodUnsubscribe, removed); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+	return nil
+}
+
+// Streams returns the subscribed streams, sorted.
+func (c *Client) Streams() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	streams := make([]string, 0, len(c.handlers))
+	for stream := range c.handlers {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+// removeHandlers removes the handlers of streams and returns the streams that had one.
+func (c *Client) removeHandlers(streams []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var removed []string
+	for _, stream := range streams {
+		if _, ok := c.handlers[stream]; ok {
+			delete(c.handlers, stream)
+			removed = append(removed, stream)
+		}
+	}
+	return removed
+}
+
+// request sends method for streams and waits for the server's answer.
+func (c *Client) request(method string, streams []string) error {
+	if len(streams) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	if c.pending == nil {
+		c.pending = make(map[int64]chan *RequestError)
+	}
+	c.nextID++
+	id := c.nextID
+	result := make(chan *RequestError, 1)
+	c.pending[id] = result
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	frame, err := json.Marshal(request{Method: method, Params: streams, ID: id})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+	if err := c.write(frame); err != nil {
+		return fmt.Errorf("failed to send %s request: %w", method, err)
+	}
+
+	timer := time.NewTimer(c.ackTimeout())
+	defer timer.Stop()
+	select {
+	case reqErr := <-result:
+		if reqErr != nil {
+			reqErr.Method, reqErr.Streams = method, streams
+			return reqErr
+		}
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w: %s %s", ErrAckTimeout, method, strings.Join(streams, ","))
+	case <-c.done:
+		return ErrClosed
+	}
+}
+
+// write sends a text frame on the current connection.
+func (c *Client) write(frame []byte) error {
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return errors.New("not connected")
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.TextMessage, frame)
+}
+
+// readLoop reads messages from conn and dispatches them until the
+// connection fails or goes stale, then closes exited.
+func (c *Client) readLoop(conn *websocket.Conn, exited chan struct{}) {
+	defer close(exited)
+	stale := c.staleTimeout()
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(stale))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			c.mu.Lock()
+			replaced := c.closed || c.conn != conn
+			c.mu.Unlock()
+			// Connections closed on purpose, or replaced, are not errors.
+			if !replaced {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					err = fmt.Errorf("%w: %v", ErrStaleConnection, err)
+				}
+				c.reportError(err)
+				go c.handleReconnection(conn, err)
+			}
+			return
+		}
+		c.dispatch(data)
+	}
+}
+
+// expire replaces conn once it reaches MaxConnectionAge.
+func (c *Client) expire(conn *websocket.Conn, exited <-chan struct{}) {
+	age := c.MaxConnectionAge
+	if age <= 0 {
+		age = DefaultMaxConnectionAge
+	}
+	timer := time.NewTimer(age)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		c.log().Info("replacing connection", "age", age)
+		c.handleReconnection(conn, ErrConnectionExpired)
+	case <-exited:
+	case <-c.done:
+	}
+}
+
+// dispatch delivers a message to the handler of its stream, or to the
+// request it answers.
+func (c *Client) dispatch(data []byte) {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		c.reportError(fmt.Errorf("failed to decode message: %w", err))
+		return
+	}
+
+	if msg.ID != nil {
+		var reqErr *RequestError
+		if msg.Error != nil {
+			reqErr = &RequestError{Code: msg.Error.Code, Message: msg.Error.Msg}
+		} else if msg.Msg != "" {
+			reqErr = &RequestError{Code: msg.Code, Message: msg.Msg}
+		}
+		c.mu.Lock()
+		result, ok := c.pending[*msg.ID]
+		c.mu.Unlock()
+		if ok {
+			result <- reqErr
+		}
+		return
+	}
+
+	c.mu.Lock()
+	handler := c.handlers[msg.Stream]
+	c.mu.Unlock()
+	if handler != nil {
+		handler(msg.Data)
+	}
+}
+
+// reportError delivers err to OnError and logs it.
+func (c *Client) reportError(err error) {
+	if c.OnError != nil {
+		c.OnError(err)
+	}
+	c.log().Error("websocket error", "error", err)
+}
+
+func (c *Client) ackTimeout() time.Duration {
+	if c.AckTimeout > 0 {
+		return c.AckTimeout
+	}
+	return DefaultAckTimeout
+}
+
+func (c *Client) staleTimeout() time.Duration {
+	if c.StaleTimeout > 0 {
+		return c.StaleTimeout
+	}
+	return DefaultStaleTimeout
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
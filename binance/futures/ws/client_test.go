@@ -0,0 +1,193 @@
+package ws
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+// server is a local combined-stream server that acknowledges every request.
+type server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []request
+	conns    []*websocket.Conn
+	pongs    chan string
+}
+
+func newServer(t *testing.T) *server {
+	t.Helper()
+	s := &server{pongs: make(chan string, 1)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stream", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.SetPongHandler(func(appData string) error {
+			s.pongs <- appData
+			return nil
+		})
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req request
+			require.NoError(t, json.Unmarshal(data, &req))
+			s.mu.Lock()
+			s.requests = append(s.requests, req)
+			s.mu.Unlock()
+			if strings.HasPrefix(req.Params[0], "invalid") {
+				_ = s.write(conn, `{"code":2,"msg":"Invalid request","id":`+itoa(req.ID)+`}`)
+				continue
+			}
+			_ = s.write(conn, `{"result":null,"id":`+itoa(req.ID)+`}`)
+		}
+	}))
+	t.Cleanup(func() {
+		s.mu.Lock()
+		for _, conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mu.Unlock()
+		s.Close()
+	})
+	return s
+}
+
+func itoa(n int64) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
+
+// write sends message on conn; the server writes from several goroutines.
+func (s *server) write(conn *websocket.Conn, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, []byte(message))
+}
+
+// last returns the latest connection.
+func (s *server) last() *websocket.Conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns[len(s.conns)-1]
+}
+
+func (s *server) Requests() []request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]request(nil), s.requests...)
+}
+
+func newTestClient(t *testing.T, s *server) *Client {
+	t.Helper()
+	c := NewClient("ws" + strings.TrimPrefix(s.URL, "http"))
+	c.AckTimeout = time.Second
+	c.Reconnect = ReconnectPolicy{InitialDelay: 10 * time.Millisecond, Multiplier: 1, MaxRetries: 5}
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	s := newServer(t)
+	c := newTestClient(t, s)
+
+	events := make(chan AggTradeEvent, 1)
+	stream, err := c.SubscribeAggTrades("BTCUSDT", func(e AggTradeEvent) { events <- e })
+	require.NoError(t, err)
+	assert.Equal(t, "btcusdt@aggTrade", stream)
+	assert.Equal(t, []request{{Method: "SUBSCRIBE", Params: []string{"btcusdt@aggTrade"}, ID: 1}}, s.Requests())
+
+	require.NoError(t, s.write(s.last(), `{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","E":2,"s":"BTCUSDT","a":5,"p":"100.5","q":"2","T":1,"m":true}}`))
+	select {
+	case e := <-events:
+		assert.Equal(t, "aggTrade", e.EventType)
+		assert.Equal(t, int64(2), e.EventTime)
+		assert.Equal(t, int64(1), e.TradeTime)
+		assert.Equal(t, "100.5", e.Price)
+		assert.True(t, e.IsBuyerMaker)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the event")
+	}
+
+	_, err = c.SubscribeAggTrades("invalid", func(AggTradeEvent) {})
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, 2, reqErr.Code)
+	assert.Equal(t, []string{"btcusdt@aggTrade"}, c.Streams())
+
+	require.NoError(t, c.Unsubscribe(stream))
+	assert.Empty(t, c.Streams())
+	assert.Equal(t, "UNSUBSCRIBE", s.Requests()[2].Method)
+}
+
+func TestClient_Reconnect(t *testing.T) {
+	s := newServer(t)
+	c := newTestClient(t, s)
+	events := make(chan ConnectionEvent, 16)
+	c.OnEvent = func(e ConnectionEvent) { events <- e }
+
+	_, err := c.SubscribeKlines("BTCUSDT", "1m", func(KlineEvent) {})
+	require.NoError(t, err)
+	_, err = c.SubscribeDiffDepth("BTCUSDT", Speed100ms, func(DepthEvent) {})
+	require.NoError(t, err)
+
+	// The server pings; the client must answer.
+	require.NoError(t, s.last().WriteControl(websocket.PingMessage, []byte("hi"), time.Now().Add(time.Second)))
+	select {
+	case pong := <-s.pongs:
+		assert.Equal(t, "hi", pong)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the pong")
+	}
+
+	_ = s.last().Close()
+	for e := range events {
+		if e.Type == EventResubscribed {
+			assert.Equal(t, []string{"btcusdt@depth@100ms", "btcusdt@kline_1m"}, e.Streams)
+			break
+		}
+	}
+}
+
+func TestClient_ReplacesExpiredConnection(t *testing.T) {
+	s := newServer(t)
+	c := newTestClient(t, s)
+	c.MaxConnectionAge = 50 * time.Millisecond
+	events := make(chan ConnectionEvent, 16)
+	c.OnEvent = func(e ConnectionEvent) { events <- e }
+	errs := make(chan error, 16)
+	c.OnError = func(err error) { errs <- err }
+
+	_, err := c.SubscribeBookTicker("BTCUSDT", func(BookTickerEvent) {})
+	require.NoError(t, err)
+
+	e := <-events
+	assert.Equal(t, EventDisconnected, e.Type)
+	assert.ErrorIs(t, e.Err, ErrConnectionExpired)
+	e = <-events
+	assert.Equal(t, EventReconnecting, e.Type)
+	assert.Zero(t, e.Delay)
+	assert.Empty(t, errs)
+}
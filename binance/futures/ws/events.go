@@ -0,0 +1,184 @@
+package ws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/market"
+	"github.com/shopspring/decimal"
+)
+
+// Event fields are named after Binance's one-letter keys. Since encoding/json
+// matches keys case-insensitively, every event declares both cases of the
+// keys Binance sends in both, such as "e" and "E".
+
+// AggTradeEvent is a trade aggregated over the fills of one taker order at one price.
+type AggTradeEvent struct {
+	EventType    string `json:"e"`
+	EventTime    int64  `json:"E"`
+	Symbol       string `json:"s"`
+	AggTradeID   int64  `json:"a"`
+	Price        string `json:"p"`
+	Qty          string `json:"q"`
+	FirstTradeID int64  `json:"f"`
+	LastTradeID  int64  `json:"l"`
+	TradeTime    int64  `json:"T"`
+	IsBuyerMaker bool   `json:"m"`
+}
+
+// MarkPriceEvent is the mark price and funding rate of a symbol.
+type MarkPriceEvent struct {
+	EventType            string `json:"e"`
+	EventTime            int64  `json:"E"`
+	Symbol               string `json:"s"`
+	MarkPrice            string `json:"p"`
+	IndexPrice           string `json:"i"`
+	EstimatedSettlePrice string `json:"P"`
+	FundingRate          string `json:"r"`
+	NextFundingTime      int64  `json:"T"`
+}
+
+// KlineData is the candlestick of a kline event.
+type KlineData struct {
+	StartTime           int64           `json:"t"`
+	CloseTime           int64           `json:"T"`
+	Symbol              string          `json:"s"`
+	Interval            market.Interval `json:"i"`
+	FirstTradeID        int64           `json:"f"`
+	LastTradeID         int64           `json:"L"`
+	Open                string          `json:"o"`
+	Close               string          `json:"c"`
+	High                string          `json:"h"`
+	Low                 string          `json:"l"`
+	Volume              string          `json:"v"`
+	Trades              int64           `json:"n"`
+	Closed              bool            `json:"x"` // Closed reports whether the kline is final.
+	QuoteVolume         string          `json:"q"`
+	TakerBuyVolume      string          `json:"V"`
+	TakerBuyQuoteVolume string          `json:"Q"`
+}
+
+// Kline returns the candlestick as the type returned by the REST API.
+func (k *KlineData) Kline() (market.Kline, error) {
+	kline := market.Kline{
+		OpenTime:  time.UnixMilli(k.StartTime),
+		CloseTime: time.UnixMilli(k.CloseTime),
+		Trades:    k.Trades,
+	}
+	fields := []*decimal.Decimal{
+		&kline.Open, &kline.High, &kline.Low, &kline.Close, &kline.Volume,
+		&kline.QuoteVolume, &kline.TakerBuyVolume, &kline.TakerBuyQuoteVolume,
+	}
+	values := []string{k.Open, k.High, k.Low, k.Close, k.Volume, k.QuoteVolume, k.TakerBuyVolume, k.TakerBuyQuoteVolume}
+	for i, field := range fields {
+		d, err := decimal.NewFromString(values[i])
+		if err != nil {
+			return market.Kline{}, fmt.Errorf("failed to parse kline: %w", err)
+		}
+		*field = d
+	}
+	return kline, nil
+}
+
+// KlineEvent is an update of the current kline of a symbol.
+type KlineEvent struct {
+	EventType string    `json:"e"`
+	EventTime int64     `json:"E"`
+	Symbol    string    `json:"s"`
+	Kline     KlineData `json:"k"`
+}
+
+// ContinuousKlineEvent is an update of the current kline of a pair's contract.
+type ContinuousKlineEvent struct {
+	EventType    string              `json:"e"`
+	EventTime    int64               `json:"E"`
+	Pair         string              `json:"ps"`
+	ContractType market.ContractType `json:"ct"`
+	Kline        KlineData           `json:"k"`
+}
+
+// MiniTickerEvent is the rolling 24 hour statistics of a symbol.
+type MiniTickerEvent struct {
+	EventType   string `json:"e"`
+	EventTime   int64  `json:"E"`
+	Symbol      string `json:"s"`
+	Close       string `json:"c"`
+	Open        string `json:"o"`
+	High        string `json:"h"`
+	Low         string `json:"l"`
+	Volume      string `json:"v"`
+	QuoteVolume string `json:"q"`
+}
+
+// TickerEvent is the rolling 24 hour statistics of a symbol, in full.
+type TickerEvent struct {
+	EventType          string `json:"e"`
+	EventTime          int64  `json:"E"`
+	Symbol             string `json:"s"`
+	PriceChange        string `json:"p"`
+	PriceChangePercent string `json:"P"`
+	WeightedAvgPrice   string `json:"w"`
+	LastPrice          string `json:"c"`
+	LastQty            string `json:"Q"`
+	OpenPrice          string `json:"o"`
+	HighPrice          string `json:"h"`
+	LowPrice           string `json:"l"`
+	Volume             string `json:"v"`
+	QuoteVolume        string `json:"q"`
+	OpenTime           int64  `json:"O"`
+	CloseTime          int64  `json:"C"`
+	FirstTradeID       int64  `json:"F"`
+	LastTradeID        int64  `json:"L"`
+	Trades             int64  `json:"n"`
+}
+
+// BookTickerEvent is a change of the best bid or ask of a symbol.
+type BookTickerEvent struct {
+	EventType       string `json:"e"`
+	UpdateID        int64  `json:"u"`
+	EventTime       int64  `json:"E"`
+	TransactionTime int64  `json:"T"`
+	Symbol          string `json:"s"`
+	BidPrice        string `json:"b"`
+	BidQty          string `json:"B"`
+	AskPrice        string `json:"a"`
+	AskQty          string `json:"A"`
+}
+
+// LiquidationEvent is a liquidation order. Only the latest liquidation of a
+// symbol in each second is pushed.
+type LiquidationEvent struct {
+	EventType string           `json:"e"`
+	EventTime int64            `json:"E"`
+	Order     LiquidationOrder `json:"o"`
+}
+
+// LiquidationOrder is the order of a liquidation event.
+type LiquidationOrder struct {
+	Symbol        string `json:"s"`
+	Side          string `json:"S"`
+	OrderType     string `json:"o"`
+	TimeInForce   string `json:"f"`
+	OrigQty       string `json:"q"`
+	Price         string `json:"p"`
+	AvgPrice      string `json:"ap"`
+	Status        string `json:"X"`
+	LastFilledQty string `json:"l"`
+	FilledQty     string `json:"z"`
+	TradeTime     int64  `json:"T"`
+}
+
+// DepthEvent is an order book update. Partial depth events hold the top
+// levels of the book; diff depth events hold the levels that changed, a
+// quantity of 0 removing a level.
+type DepthEvent struct {
+	EventType         string     `json:"e"`
+	EventTime         int64      `json:"E"`
+	TransactionTime   int64      `json:"T"`
+	Symbol            string     `json:"s"`
+	FirstUpdateID     int64      `json:"U"`
+	FinalUpdateID     int64      `json:"u"`
+	PrevFinalUpdateID int64      `json:"pu"` // PrevFinalUpdateID is the FinalUpdateID of the previous event.
+	Bids              [][]string `json:"b"`
+	Asks              [][]string `json:"a"`
+}
@@ -0,0 +1,411 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/market"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DefaultSnapshotLimit is the depth of the snapshots an order book is
+	// built from by default.
+	DefaultSnapshotLimit = 1000
+	// DefaultResyncDelay is the default wait between failed synchronization attempts.
+	DefaultResyncDelay = time.Second
+	// DefaultMaxBuffer is the default number of updates buffered while the
+	// book is out of sync.
+	DefaultMaxBuffer = 10000
+)
+
+// ErrOrderBookClosed is returned by Wait once the order book is closed.
+var ErrOrderBookClosed = errors.New("order book is closed")
+
+// Snapshotter fetches order book snapshots; market.Market implements it.
+type Snapshotter interface {
+	OrderBook(symbol string, limit int) (*market.OrderBookResponse, error)
+}
+
+// Level is a price level of an order book.
+type Level struct {
+	Price decimal.Decimal
+	Qty   decimal.Decimal
+}
+
+// OrderBook is a local order book kept in sync with the diff depth stream
+// of a symbol. It buffers the stream while it fetches a snapshot, applies
+// the buffered updates that follow the snapshot and checks that every
+// update continues the previous one. Whenever an update is missed, such as
+// after a reconnection, the book is rebuilt from a new snapshot.
+//
+// All methods are safe for concurrent use.
+type OrderBook struct {
+	// Speed is the update speed of the diff depth stream.
+	Speed time.Duration
+	// SnapshotLimit is the depth of the snapshots; 0 uses DefaultSnapshotLimit.
+	SnapshotLimit int
+	// ResyncDelay is the wait between failed synchronization attempts; 0
+	// uses DefaultResyncDelay.
+	ResyncDelay time.Duration
+	// MaxBuffer is the number of updates buffered while the book is out of
+	// sync; 0 uses DefaultMaxBuffer. When the buffer is full, it is reset
+	// and the next snapshot anchors the book on the updates that follow.
+	MaxBuffer int
+
+	symbol    string
+	ws        *Client
+	snapshots Snapshotter
+	stream    string
+
+	mu           sync.RWMutex
+	bids         []Level // bids are sorted by descending price.
+	asks         []Level // asks are sorted by ascending price.
+	lastUpdateID int64
+	synced       bool
+	awaitFirst   bool // awaitFirst is set until the first update after a snapshot.
+	buffer       []DepthEvent
+	resyncing    bool
+	ready        chan struct{}
+
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewOrderBook creates the order book of symbol, streamed on c and built
+// from the snapshots of snapshots.
+func NewOrderBook(c *Client, snapshots Snapshotter, symbol string) *OrderBook {
+	return &OrderBook{
+		symbol:    symbol,
+		ws:        c,
+		snapshots: snapshots,
+		ready:     make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start subscribes to the diff depth stream and synchronizes the book in
+// the background. Use Wait to block until the book is synchronized.
+func (b *OrderBook) Start() error {
+	stream, err := b.ws.SubscribeDiffDepth(b.symbol, b.Speed, b.handle)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	b.stream = stream
+	b.startResync()
+	b.mu.Unlock()
+	return nil
+}
+
+// Close unsubscribes from the diff depth stream and stops synchronizing.
+func (b *OrderBook) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		close(b.done)
+		b.mu.RLock()
+		stream := b.stream
+		b.mu.RUnlock()
+		if stream != "" {
+			err = b.ws.Unsubscribe(stream)
+		}
+		b.wg.Wait()
+	})
+	return err
+}
+
+// Wait blocks until the book is synchronized, ctx is done or the book is closed.
+func (b *OrderBook) Wait(ctx context.Context) error {
+	b.mu.RLock()
+	ready := b.ready
+	b.mu.RUnlock()
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.done:
+		return ErrOrderBookClosed
+	}
+}
+
+// Synced reports whether the book is in sync with the stream.
+func (b *OrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// LastUpdateID returns the ID of the last update applied to the book.
+func (b *OrderBook) LastUpdateID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lastUpdateID
+}
+
+// BestBid returns the highest bid; ok is false if there are no bids or
+// the book is not synchronized.
+func (b *OrderBook) BestBid() (level Level, ok bool) {
+	return b.best(func() []Level { return b.bids })
+}
+
+// BestAsk returns the lowest ask; ok is false if there are no asks or the
+// book is not synchronized.
+func (b *OrderBook) BestAsk() (level Level, ok bool) {
+	return b.best(func() []Level { return b.asks })
+}
+
+// Bids returns up to n of the highest bids, or every bid if n is 0 or less.
+func (b *OrderBook) Bids(n int) []Level {
+	return b.top(func() []Level { return b.bids }, n)
+}
+
+// Asks returns up to n of the lowest asks, or every ask if n is 0 or less.
+func (b *OrderBook) Asks(n int) []Level {
+	return b.top(func() []Level { return b.asks }, n)
+}
+
+// Depth returns up to n levels of both sides of the book.
+func (b *OrderBook) Depth(n int) (bids, asks []Level) {
+	return b.Bids(n), b.Asks(n)
+}
+
+func (b *OrderBook) best(side func() []Level) (Level, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	levels := side()
+	if !b.synced || len(levels) == 0 {
+		return Level{}, false
+	}
+	return levels[0], true
+}
+
+func (b *OrderBook) top(side func() []Level, n int) []Level {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return nil
+	}
+	levels := side()
+	if n > 0 && n < len(levels) {
+		levels = levels[:n]
+	}
+	return append([]Level(nil), levels...)
+}
+
+// handle applies an update of the stream, or buffers it while the book
+// is being synchronized.
+func (b *OrderBook) handle(event DepthEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.synced {
+		b.bufferEvent(event)
+		return
+	}
+	if err := b.apply(event); err != nil {
+		b.ws.reportError(err)
+		b.desync(event)
+	}
+}
+
+// bufferEvent buffers event, resetting the buffer first if it is full so
+// that it does not grow while snapshots keep failing. It must be called
+// with mu held.
+func (b *OrderBook) bufferEvent(event DepthEvent) {
+	limit := b.MaxBuffer
+	if limit <= 0 {
+		limit = DefaultMaxBuffer
+	}
+	if len(b.buffer) >= limit {
+		b.ws.log().Warn("order book buffer full, dropping buffered updates", "symbol", b.symbol, "updates", len(b.buffer))
+		b.buffer = nil
+	}
+	b.buffer = append(b.buffer, event)
+}
+
+// desync marks the book out of sync and rebuilds it, replaying the stream
+// from event on.
+func (b *OrderBook) desync(event DepthEvent) {
+	b.synced = false
+	b.ready = make(chan struct{})
+	b.buffer = []DepthEvent{event}
+	b.startResync()
+}
+
+// startResync starts synchronizing unless it is already in progress. It
+// must be called with mu held.
+func (b *OrderBook) startResync() {
+	if b.resyncing {
+		return
+	}
+	b.resyncing = true
+	b.wg.Add(1)
+	go b.resync()
+}
+
+// resync rebuilds the book from snapshots until it is in sync or closed.
+func (b *OrderBook) resync() {
+	defer b.wg.Done()
+	delay := b.ResyncDelay
+	if delay <= 0 {
+		delay = DefaultResyncDelay
+	}
+	limit := b.SnapshotLimit
+	if limit <= 0 {
+		limit = DefaultSnapshotLimit
+	}
+
+	for {
+		select {
+		case <-b.done:
+			return
+		default:
+		}
+
+		snapshot, err := b.snapshots.OrderBook(b.symbol, limit)
+		if err == nil {
+			err = b.load(snapshot)
+		}
+		if err == nil {
+			b.ws.log().Info("order book synchronized", "symbol", b.symbol)
+			return
+		}
+		b.ws.reportError(fmt.Errorf("failed to synchronize order book of %s: %w", b.symbol, err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-b.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// load replaces the book with snapshot and replays the buffered updates.
+// On a gap, the updates from the gap on stay buffered for the next attempt.
+func (b *OrderBook) load(snapshot *market.OrderBookResponse) error {
+	bids, err := parseLevels(snapshot.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := parseLevels(snapshot.Asks)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids, b.asks = bids, asks
+	sortLevels(b.bids, true)
+	sortLevels(b.asks, false)
+	b.lastUpdateID = snapshot.LastUpdateID
+	b.awaitFirst = true
+
+	for i, event := range b.buffer {
+		if err := b.apply(event); err != nil {
+			b.buffer = b.buffer[i:]
+			return err
+		}
+	}
+	b.buffer = nil
+	b.synced = true
+	b.resyncing = false
+	close(b.ready)
+	return nil
+}
+
+// apply applies event to the book. Updates older than the book are
+// skipped; an update that does not continue the book is an error.
+func (b *OrderBook) apply(event DepthEvent) error {
+	if event.FinalUpdateID < b.lastUpdateID {
+		return nil
+	}
+	if b.awaitFirst {
+		if event.FirstUpdateID > b.lastUpdateID {
+			return fmt.Errorf("update %d-%d does not overlap snapshot %d", event.FirstUpdateID, event.FinalUpdateID, b.lastUpdateID)
+		}
+	} else if event.PrevFinalUpdateID != b.lastUpdateID {
+		return fmt.Errorf("update %d follows %d instead of %d", event.FinalUpdateID, event.PrevFinalUpdateID, b.lastUpdateID)
+	}
+
+	bids, err := parseLevels(event.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := parseLevels(event.Asks)
+	if err != nil {
+		return err
+	}
+	for _, level := range bids {
+		b.bids = setLevel(b.bids, level, true)
+	}
+	for _, level := range asks {
+		b.asks = setLevel(b.asks, level, false)
+	}
+	b.lastUpdateID = event.FinalUpdateID
+	b.awaitFirst = false
+	return nil
+}
+
+// parseLevels parses [price, quantity] pairs.
+func parseLevels(raw [][]string) ([]Level, error) {
+	levels := make([]Level, 0, len(raw))
+	for _, pair := range raw {
+		if len(pair) < 2 {
+			return nil, fmt.Errorf("invalid price level %v", pair)
+		}
+		price, err := decimal.NewFromString(pair[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse price level: %w", err)
+		}
+		qty, err := decimal.NewFromString(pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse price level: %w", err)
+		}
+		levels = append(levels, Level{Price: price, Qty: qty})
+	}
+	return levels, nil
+}
+
+// sortLevels sorts levels by descending price if desc is set, by ascending
+// price otherwise.
+func sortLevels(levels []Level, desc bool) {
+	sort.Slice(levels, func(i, j int) bool {
+		if desc {
+			return levels[i].Price.GreaterThan(levels[j].Price)
+		}
+		return levels[i].Price.LessThan(levels[j].Price)
+	})
+}
+
+// setLevel sets the quantity of a level of sorted levels, removing the
+// level if the quantity is zero.
+func setLevel(levels []Level, level Level, desc bool) []Level {
+	i := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].Price.LessThanOrEqual(level.Price)
+		}
+		return levels[i].Price.GreaterThanOrEqual(level.Price)
+	})
+	found := i < len(levels) && levels[i].Price.Equal(level.Price)
+	switch {
+	case level.Qty.IsZero() && found:
+		return append(levels[:i], levels[i+1:]...)
+	case level.Qty.IsZero():
+		return levels
+	case found:
+		levels[i].Qty = level.Qty
+		return levels
+	default:
+		levels = append(levels, Level{})
+		copy(levels[i+1:], levels[i:])
+		levels[i] = level
+		return levels
+	}
+}
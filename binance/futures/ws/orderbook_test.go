@@ -0,0 +1,128 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotter hands out the snapshots sent on its channel.
+type snapshotter chan *market.OrderBookResponse
+
+func (s snapshotter) OrderBook(symbol string, limit int) (*market.OrderBookResponse, error) {
+	snapshot, ok := <-s
+	if !ok {
+		return nil, errors.New("closed")
+	}
+	return snapshot, nil
+}
+
+func prices(levels []Level) []string {
+	var out []string
+	for _, level := range levels {
+		out = append(out, level.Price.String())
+	}
+	return out
+}
+
+func TestOrderBook(t *testing.T) {
+	s := newServer(t)
+	c := newTestClient(t, s)
+	snapshots := make(snapshotter, 1)
+	book := NewOrderBook(c, snapshots, "BTCUSDT")
+	book.ResyncDelay = 10 * time.Millisecond
+	t.Cleanup(func() {
+		close(snapshots)
+		_ = book.Close()
+	})
+	require.NoError(t, book.Start())
+	assert.Equal(t, "SUBSCRIBE", s.Requests()[0].Method)
+	assert.Equal(t, []string{"btcusdt@depth"}, s.Requests()[0].Params)
+
+	send := func(data string) {
+		require.NoError(t, s.write(s.last(), `{"stream":"btcusdt@depth","data":`+data+`}`))
+	}
+	// Updates are buffered until the snapshot arrives; the first one is
+	// older than the snapshot and the second one overlaps it.
+	send(`{"e":"depthUpdate","U":90,"u":95,"pu":89,"b":[["10","5"]],"a":[]}`)
+	send(`{"e":"depthUpdate","U":98,"u":105,"pu":95,"b":[["10","0"]],"a":[["12","3"]]}`)
+	require.Eventually(t, func() bool {
+		book.mu.RLock()
+		defer book.mu.RUnlock()
+		return len(book.buffer) == 2
+	}, time.Second, 5*time.Millisecond)
+	_, ok := book.BestBid()
+	assert.False(t, ok)
+
+	snapshots <- &market.OrderBookResponse{
+		LastUpdateID: 100,
+		Bids:         [][]string{{"9", "2"}, {"10", "1"}},
+		Asks:         [][]string{{"11", "1"}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, book.Wait(ctx))
+	assert.Equal(t, int64(105), book.LastUpdateID())
+	assert.Equal(t, []string{"9"}, prices(book.Bids(0)))
+	assert.Equal(t, []string{"11", "12"}, prices(book.Asks(0)))
+
+	send(`{"e":"depthUpdate","U":106,"u":110,"pu":105,"b":[["9.5","1"]],"a":[["11","0"]]}`)
+	require.Eventually(t, func() bool { return book.LastUpdateID() == 110 }, time.Second, 5*time.Millisecond)
+	bid, ok := book.BestBid()
+	require.True(t, ok)
+	assert.True(t, bid.Price.Equal(decimal.RequireFromString("9.5")))
+	ask, ok := book.BestAsk()
+	require.True(t, ok)
+	assert.True(t, ask.Qty.Equal(decimal.NewFromInt(3)))
+	bids, asks := book.Depth(1)
+	assert.Equal(t, []string{"9.5"}, prices(bids))
+	assert.Equal(t, []string{"12"}, prices(asks))
+
+	// A missed update triggers a new snapshot.
+	send(`{"e":"depthUpdate","U":201,"u":210,"pu":200,"b":[["7","1"]],"a":[]}`)
+	require.Eventually(t, func() bool { return !book.Synced() }, time.Second, 5*time.Millisecond)
+	snapshots <- &market.OrderBookResponse{LastUpdateID: 205, Bids: [][]string{{"8", "1"}}}
+	require.NoError(t, book.Wait(ctx))
+	assert.Equal(t, int64(210), book.LastUpdateID())
+	assert.Equal(t, []string{"8", "7"}, prices(book.Bids(0)))
+	assert.Empty(t, book.Asks(0))
+}
+
+func TestOrderBook_MaxBuffer(t *testing.T) {
+	s := newServer(t)
+	c := newTestClient(t, s)
+	snapshots := make(snapshotter)
+	book := NewOrderBook(c, snapshots, "BTCUSDT")
+	book.MaxBuffer = 2
+	t.Cleanup(func() {
+		close(snapshots)
+		_ = book.Close()
+	})
+	require.NoError(t, book.Start())
+
+	send := func(data string) {
+		require.NoError(t, s.write(s.last(), `{"stream":"btcusdt@depth","data":`+data+`}`))
+	}
+	// The third update finds the buffer full and replaces its content.
+	send(`{"e":"depthUpdate","U":90,"u":95,"pu":89,"b":[],"a":[]}`)
+	send(`{"e":"depthUpdate","U":96,"u":100,"pu":95,"b":[],"a":[]}`)
+	send(`{"e":"depthUpdate","U":101,"u":105,"pu":100,"b":[["10","1"]],"a":[]}`)
+	require.Eventually(t, func() bool {
+		book.mu.RLock()
+		defer book.mu.RUnlock()
+		return len(book.buffer) == 1 && book.buffer[0].FinalUpdateID == 105
+	}, time.Second, 5*time.Millisecond)
+
+	snapshots <- &market.OrderBookResponse{LastUpdateID: 102}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, book.Wait(ctx))
+	assert.Equal(t, int64(105), book.LastUpdateID())
+	assert.Equal(t, []string{"10"}, prices(book.Bids(0)))
+}
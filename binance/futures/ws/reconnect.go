@@ -0,0 +1,104 @@
+package ws
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/internal/backoff"
+	"github.com/gorilla/websocket"
+)
+
+// ErrConnectionExpired is reported when a connection is replaced because it
+// reached MaxConnectionAge.
+var ErrConnectionExpired = errors.New("websocket connection reached its maximum age")
+
+// ReconnectPolicy configures how the client reconnects after the connection drops.
+// The delay before attempt n is InitialDelay * Multiplier^(n-1), capped at
+// MaxDelay and randomized by ±Jitter (a fraction between 0 and 1).
+type ReconnectPolicy = backoff.Policy
+
+// DefaultReconnectPolicy is the policy used by new clients.
+var DefaultReconnectPolicy = backoff.DefaultPolicy
+
+// ConnectionEventType identifies a change in the state of the connection.
+type ConnectionEventType string
+
+const (
+	EventDisconnected ConnectionEventType = "disconnected"
+	EventReconnecting ConnectionEventType = "reconnecting"
+	EventReconnected  ConnectionEventType = "reconnected"
+	EventResubscribed ConnectionEventType = "resubscribed"
+	EventGaveUp       ConnectionEventType = "gave_up"
+)
+
+// ConnectionEvent describes a change in the state of the connection.
+type ConnectionEvent struct {
+	Type    ConnectionEventType
+	Attempt int           // Attempt is the reconnection attempt, for reconnecting and reconnected events.
+	Delay   time.Duration // Delay is the wait before the attempt, for reconnecting events.
+	Streams []string      // Streams are the streams replayed, for resubscribed events.
+	Err     error         // Err is the error that caused the event, if any.
+}
+
+// emit delivers event to the OnEvent callback.
+func (c *Client) emit(event ConnectionEvent) {
+	if c.OnEvent != nil {
+		c.OnEvent(event)
+	}
+}
+
+// handleReconnection replaces a failed connection. Only the first caller for a
+// given connection reconnects; later calls for the same connection are ignored.
+func (c *Client) handleReconnection(failed *websocket.Conn, cause error) {
+	c.mu.Lock()
+	if c.closed || c.conn != failed {
+		c.mu.Unlock()
+		return
+	}
+	c.conn = nil
+	c.mu.Unlock()
+	_ = failed.Close()
+
+	c.emit(ConnectionEvent{Type: EventDisconnected, Err: cause})
+	// An expired connection is healthy, so it is replaced without waiting.
+	c.reconnect(errors.Is(cause, ErrConnectionExpired))
+}
+
+// reconnect dials again following the reconnect policy and replays the
+// active streams. If immediate is set, the first attempt is not delayed.
+func (c *Client) reconnect(immediate bool) {
+	policy := c.Reconnect
+	_, err := policy.Retry(c.done, immediate, func(attempt int, delay time.Duration) {
+		c.emit(ConnectionEvent{Type: EventReconnecting, Attempt: attempt, Delay: delay})
+		c.log().Info("reconnecting", "attempt", attempt, "delay", delay)
+	}, func(attempt int) error {
+		if err := c.Connect(); err != nil {
+			c.log().Warn("reconnection attempt failed", "attempt", attempt, "error", err)
+			return err
+		}
+		c.log().Info("reconnected", "attempt", attempt)
+		c.emit(ConnectionEvent{Type: EventReconnected, Attempt: attempt})
+		return nil
+	})
+	switch {
+	case err == nil:
+		c.resubscribe()
+	case errors.Is(err, backoff.ErrGaveUp):
+		c.log().Error("giving up reconnecting", "attempts", policy.MaxRetries)
+		c.emit(ConnectionEvent{Type: EventGaveUp, Attempt: policy.MaxRetries})
+	}
+}
+
+// resubscribe replays every active stream on the current connection.
+func (c *Client) resubscribe() {
+	streams := c.Streams()
+	if len(streams) == 0 {
+		return
+	}
+	if err := c.request(methodSubscribe, streams); err != nil {
+		c.reportError(fmt.Errorf("failed to resubscribe: %w", err))
+		return
+	}
+	c.emit(ConnectionEvent{Type: EventResubscribed, Streams: streams})
+}
@@ -0,0 +1,164 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/market"
+)
+
+// Update speeds of the depth streams. The default speed is 250ms.
+const (
+	DefaultSpeed time.Duration = 0
+	Speed100ms                 = 100 * time.Millisecond
+	Speed500ms                 = 500 * time.Millisecond
+)
+
+// Stream names of the all-market streams.
+const (
+	AllMarkPricesStream   = "!markPrice@arr"
+	AllMiniTickersStream  = "!miniTicker@arr"
+	AllTickersStream      = "!ticker@arr"
+	AllBookTickersStream  = "!bookTicker"
+	AllLiquidationsStream = "!forceOrder@arr"
+)
+
+// subscribe subscribes to stream and calls handler with its data decoded
+// into T. Data that cannot be decoded is reported through OnError.
+func subscribe[T any](c *Client, stream string, handler func(T)) (string, error) {
+	err := c.Subscribe(func(data json.RawMessage) {
+		var event T
+		if err := json.Unmarshal(data, &event); err != nil {
+			c.reportError(fmt.Errorf("failed to decode %s: %w", stream, err))
+			return
+		}
+		handler(event)
+	}, stream)
+	return stream, err
+}
+
+// symbolStream returns the name of a stream of symbol.
+func symbolStream(symbol, name string) string {
+	return strings.ToLower(symbol) + "@" + name
+}
+
+// speedSuffix returns the suffix selecting the update speed of a depth stream.
+func speedSuffix(speed time.Duration) (string, error) {
+	switch speed {
+	case DefaultSpeed:
+		return "", nil
+	case Speed100ms, Speed500ms:
+		return fmt.Sprintf("@%dms", speed.Milliseconds()), nil
+	default:
+		return "", fmt.Errorf("unsupported update speed %s", speed)
+	}
+}
+
+// SubscribeAggTrades subscribes to the aggregate trades of symbol and
+// returns the name of the stream.
+func (c *Client) SubscribeAggTrades(symbol string, handler func(AggTradeEvent)) (string, error) {
+	return subscribe(c, symbolStream(symbol, "aggTrade"), handler)
+}
+
+// SubscribeMarkPrice subscribes to the mark price of symbol, pushed every
+// 3 seconds or, if everySecond is set, every second.
+func (c *Client) SubscribeMarkPrice(symbol string, everySecond bool, handler func(MarkPriceEvent)) (string, error) {
+	stream := symbolStream(symbol, "markPrice")
+	if everySecond {
+		stream += "@1s"
+	}
+	return subscribe(c, stream, handler)
+}
+
+// SubscribeAllMarkPrices subscribes to the mark prices of every symbol.
+func (c *Client) SubscribeAllMarkPrices(everySecond bool, handler func([]MarkPriceEvent)) (string, error) {
+	stream := AllMarkPricesStream
+	if everySecond {
+		stream += "@1s"
+	}
+	return subscribe(c, stream, handler)
+}
+
+// SubscribeKlines subscribes to the klines of symbol.
+func (c *Client) SubscribeKlines(symbol string, interval market.Interval, handler func(KlineEvent)) (string, error) {
+	if err := interval.Validate(); err != nil {
+		return "", err
+	}
+	return subscribe(c, symbolStream(symbol, "kline_"+string(interval)), handler)
+}
+
+// SubscribeContinuousKlines subscribes to the klines of the contract of pair
+// of contractType, across its successive deliveries.
+func (c *Client) SubscribeContinuousKlines(pair string, contractType market.ContractType, interval market.Interval, handler func(ContinuousKlineEvent)) (string, error) {
+	if err := interval.Validate(); err != nil {
+		return "", err
+	}
+	stream := fmt.Sprintf("%s_%s@continuousKline_%s", strings.ToLower(pair), strings.ToLower(string(contractType)), interval)
+	return subscribe(c, stream, handler)
+}
+
+// SubscribeMiniTicker subscribes to the mini ticker of symbol.
+func (c *Client) SubscribeMiniTicker(symbol string, handler func(MiniTickerEvent)) (string, error) {
+	return subscribe(c, symbolStream(symbol, "miniTicker"), handler)
+}
+
+// SubscribeAllMiniTickers subscribes to the mini tickers of the symbols
+// that changed, every second.
+func (c *Client) SubscribeAllMiniTickers(handler func([]MiniTickerEvent)) (string, error) {
+	return subscribe(c, AllMiniTickersStream, handler)
+}
+
+// SubscribeTicker subscribes to the ticker of symbol.
+func (c *Client) SubscribeTicker(symbol string, handler func(TickerEvent)) (string, error) {
+	return subscribe(c, symbolStream(symbol, "ticker"), handler)
+}
+
+// SubscribeAllTickers subscribes to the tickers of the symbols that
+// changed, every second.
+func (c *Client) SubscribeAllTickers(handler func([]TickerEvent)) (string, error) {
+	return subscribe(c, AllTickersStream, handler)
+}
+
+// SubscribeBookTicker subscribes to the best bid and ask of symbol.
+func (c *Client) SubscribeBookTicker(symbol string, handler func(BookTickerEvent)) (string, error) {
+	return subscribe(c, symbolStream(symbol, "bookTicker"), handler)
+}
+
+// SubscribeAllBookTickers subscribes to the best bids and asks of every symbol.
+func (c *Client) SubscribeAllBookTickers(handler func(BookTickerEvent)) (string, error) {
+	return subscribe(c, AllBookTickersStream, handler)
+}
+
+// SubscribeLiquidations subscribes to the liquidation orders of symbol.
+func (c *Client) SubscribeLiquidations(symbol string, handler func(LiquidationEvent)) (string, error) {
+	return subscribe(c, symbolStream(symbol, "forceOrder"), handler)
+}
+
+// SubscribeAllLiquidations subscribes to the liquidation orders of every symbol.
+func (c *Client) SubscribeAllLiquidations(handler func(LiquidationEvent)) (string, error) {
+	return subscribe(c, AllLiquidationsStream, handler)
+}
+
+// SubscribePartialDepth subscribes to the top levels of the order book of
+// symbol; levels is 5, 10 or 20.
+func (c *Client) SubscribePartialDepth(symbol string, levels int, speed time.Duration, handler func(DepthEvent)) (string, error) {
+	if levels != 5 && levels != 10 && levels != 20 {
+		return "", fmt.Errorf("unsupported depth levels %d", levels)
+	}
+	suffix, err := speedSuffix(speed)
+	if err != nil {
+		return "", err
+	}
+	return subscribe(c, symbolStream(symbol, fmt.Sprintf("depth%d%s", levels, suffix)), handler)
+}
+
+// SubscribeDiffDepth subscribes to the changes of the order book of symbol.
+func (c *Client) SubscribeDiffDepth(symbol string, speed time.Duration, handler func(DepthEvent)) (string, error) {
+	suffix, err := speedSuffix(speed)
+	if err != nil {
+		return "", err
+	}
+	return subscribe(c, symbolStream(symbol, "depth"+suffix), handler)
+}
@@ -0,0 +1,492 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+)
+
+const (
+	listenKeyEndpoint = "/fapi/v1/listenKey"
+	// DefaultKeepAliveInterval is how often listen keys are kept alive by
+	// default. Binance expires them after 60 minutes without a keepalive.
+	DefaultKeepAliveInterval = 30 * time.Minute
+)
+
+// Event types of the user data stream.
+const (
+	EventOrderTradeUpdate    = "ORDER_TRADE_UPDATE"
+	EventAccountUpdate       = "ACCOUNT_UPDATE"
+	EventMarginCall          = "MARGIN_CALL"
+	EventAccountConfigUpdate = "ACCOUNT_CONFIG_UPDATE"
+	EventListenKeyExpired    = "listenKeyExpired"
+)
+
+// ListenKeys manages the listen keys of an API on Endpoint.
+type ListenKeys struct {
+	Endpoint string
+	// KeyParam sends the listen key when keeping it alive or closing it,
+	// as the spot API requires.
+	KeyParam bool
+}
+
+// ErrStreamStarted is returned by UserDataStream.Start when the stream is
+// already started.
+var ErrStreamStarted = errors.New("user data stream already started")
+
+var futuresListenKeys = ListenKeys{Endpoint: listenKeyEndpoint}
+
+// CreateListenKey creates a listen key, or returns the active one and
+// extends its validity.
+func CreateListenKey(ctx context.Context, c *client.Client) (string, error) {
+	return futuresListenKeys.Create(ctx, c)
+}
+
+// KeepAliveListenKey extends the validity of the active listen key by 60 minutes.
+func KeepAliveListenKey(ctx context.Context, c *client.Client) error {
+	return futuresListenKeys.KeepAlive(ctx, c, "")
+}
+
+// CloseListenKey closes the active listen key, ending its stream.
+func CloseListenKey(ctx context.Context, c *client.Client) error {
+	return futuresListenKeys.Close(ctx, c, "")
+}
+
+// Create creates a listen key, or returns the active one and extends its
+// validity.
+func (l ListenKeys) Create(ctx context.Context, c *client.Client) (string, error) {
+	var resp struct {
+		ListenKey string `json:"listenKey"`
+	}
+	if err := c.Do(ctx, http.MethodPost, l.Endpoint, nil, client.SecurityAPIKey, &resp); err != nil {
+		return "", fmt.Errorf("failed to create listen key: %w", err)
+	}
+	return resp.ListenKey, nil
+}
+
+// KeepAlive extends the validity of listenKey by 60 minutes.
+func (l ListenKeys) KeepAlive(ctx context.Context, c *client.Client, listenKey string) error {
+	if err := c.Do(ctx, http.MethodPut, l.Endpoint, l.params(listenKey), client.SecurityAPIKey, nil); err != nil {
+		return fmt.Errorf("failed to keep listen key alive: %w", err)
+	}
+	return nil
+}
+
+// Close closes listenKey, ending its stream.
+func (l ListenKeys) Close(ctx context.Context, c *client.Client, listenKey string) error {
+	if err := c.Do(ctx, http.MethodDelete, l.Endpoint, l.params(listenKey), client.SecurityAPIKey, nil); err != nil {
+		return fmt.Errorf("failed to close listen key: %w", err)
+	}
+	return nil
+}
+
+// params returns the parameters identifying listenKey, if l sends them.
+func (l ListenKeys) params(listenKey string) url.Values {
+	if !l.KeyParam {
+		return nil
+	}
+	return url.Values{"listenKey": {listenKey}}
+}
+
+// OrderTradeUpdateEvent is a change of an order, such as a new order, a
+// fill or a cancellation.
+type OrderTradeUpdateEvent struct {
+	EventType       string      `json:"e"`
+	EventTime       int64       `json:"E"`
+	TransactionTime int64       `json:"T"`
+	Order           OrderUpdate `json:"o"`
+}
+
+// OrderUpdate is the order of an OrderTradeUpdateEvent.
+type OrderUpdate struct {
+	Symbol                  string `json:"s"`
+	ClientOrderID           string `json:"c"`
+	Side                    string `json:"S"`
+	OrderType               string `json:"o"`
+	TimeInForce             string `json:"f"`
+	OrigQty                 string `json:"q"`
+	Price                   string `json:"p"`
+	AvgPrice                string `json:"ap"`
+	StopPrice               string `json:"sp"`
+	ExecutionType           string `json:"x"` // ExecutionType is NEW, CANCELED, CALCULATED, EXPIRED, TRADE or AMENDMENT.
+	Status                  string `json:"X"`
+	OrderID                 int64  `json:"i"`
+	LastFilledQty           string `json:"l"`
+	FilledQty               string `json:"z"`
+	LastFilledPrice         string `json:"L"`
+	CommissionAsset         string `json:"N"`
+	Commission              string `json:"n"`
+	TradeTime               int64  `json:"T"`
+	TradeID                 int64  `json:"t"`
+	BidsNotional            string `json:"b"`
+	AsksNotional            string `json:"a"`
+	IsMaker                 bool   `json:"m"`
+	ReduceOnly              bool   `json:"R"`
+	WorkingType             string `json:"wt"`
+	OrigType                string `json:"ot"`
+	PositionSide            string `json:"ps"`
+	MarginAsset             string `json:"ma"` // MarginAsset is only set by COIN-M futures.
+	ClosePosition           bool   `json:"cp"`
+	ActivationPrice         string `json:"AP"`
+	CallbackRate            string `json:"cr"`
+	PriceProtect            bool   `json:"pP"`
+	RealizedProfit          string `json:"rp"`
+	SelfTradePreventionMode string `json:"V"`
+	PriceMatch              string `json:"pm"`
+	GoodTillDate            int64  `json:"gtd"`
+}
+
+// AccountUpdateEvent is a change of balances or positions.
+type AccountUpdateEvent struct {
+	EventType       string        `json:"e"`
+	EventTime       int64         `json:"E"`
+	TransactionTime int64         `json:"T"`
+	AccountAlias    string        `json:"i"` // AccountAlias is only set by COIN-M futures.
+	Update          AccountUpdate `json:"a"`
+}
+
+// AccountUpdate is the content of an AccountUpdateEvent. Only the balances
+// and positions that changed are listed.
+type AccountUpdate struct {
+	Reason    string                  `json:"m"` // Reason is what caused the update, such as ORDER or FUNDING_FEE.
+	Balances  []AccountUpdateBalance  `json:"B"`
+	Positions []AccountUpdatePosition `json:"P"`
+}
+
+// AccountUpdateBalance is a balance of an AccountUpdate.
+type AccountUpdateBalance struct {
+	Asset              string `json:"a"`
+	WalletBalance      string `json:"wb"`
+	CrossWalletBalance string `json:"cw"`
+	BalanceChange      string `json:"bc"` // BalanceChange excludes PnL and commission.
+}
+
+// AccountUpdatePosition is a position of an AccountUpdate.
+type AccountUpdatePosition struct {
+	Symbol              string `json:"s"`
+	PositionAmt         string `json:"pa"`
+	EntryPrice          string `json:"ep"`
+	BreakEvenPrice      string `json:"bep"`
+	AccumulatedRealized string `json:"cr"`
+	UnrealizedPnL       string `json:"up"`
+	MarginType          string `json:"mt"`
+	IsolatedWallet      string `json:"iw"`
+	PositionSide        string `json:"ps"`
+}
+
+// MarginCallEvent warns that positions are close to liquidation.
+type MarginCallEvent struct {
+	EventType          string           `json:"e"`
+	EventTime          int64            `json:"E"`
+	CrossWalletBalance string           `json:"cw"` // CrossWalletBalance is only set for cross positions.
+	Positions          []MarginCallItem `json:"p"`
+}
+
+// MarginCallItem is a position of a MarginCallEvent.
+type MarginCallItem struct {
+	Symbol            string `json:"s"`
+	PositionSide      string `json:"ps"`
+	PositionAmt       string `json:"pa"`
+	MarginType        string `json:"mt"`
+	IsolatedWallet    string `json:"iw"`
+	MarkPrice         string `json:"mp"`
+	UnrealizedPnL     string `json:"up"`
+	MaintenanceMargin string `json:"mm"`
+}
+
+// AccountConfigUpdateEvent is a change of the leverage of a symbol, or of
+// the multi-assets mode.
+type AccountConfigUpdateEvent struct {
+	EventType       string `json:"e"`
+	EventTime       int64  `json:"E"`
+	TransactionTime int64  `json:"T"`
+	// Leverage is set when the leverage of a symbol changed.
+	Leverage *struct {
+		Symbol   string `json:"s"`
+		Leverage int    `json:"l"`
+	} `json:"ac"`
+	// MultiAssets is set when the multi-assets mode changed.
+	MultiAssets *struct {
+		Enabled bool `json:"j"`
+	} `json:"ai"`
+}
+
+// ListenKeyExpiredEvent reports that the listen key of the stream expired.
+// The stream renews it on its own.
+type ListenKeyExpiredEvent struct {
+	EventType string `json:"e"`
+	EventTime int64  `json:"E"`
+	ListenKey string `json:"listenKey"`
+}
+
+// UserDataStream is the stream of account updates. It creates the listen
+// key, keeps it alive and renews it when it expires, on a connection of its own.
+type UserDataStream struct {
+	// KeepAliveInterval is how often the listen key is kept alive; 0 uses
+	// DefaultKeepAliveInterval. It must be set before Start.
+	KeepAliveInterval time.Duration
+
+	rest *client.Client
+	ws   *Client
+	keys ListenKeys
+
+	mu        sync.Mutex
+	listenKey string
+
+	onOrderTradeUpdate    func(OrderTradeUpdateEvent)
+	onAccountUpdate       func(AccountUpdateEvent)
+	onMarginCall          func(MarginCallEvent)
+	onAccountConfigUpdate func(AccountConfigUpdateEvent)
+	onListenKeyExpired    func(ListenKeyExpiredEvent)
+	handlers              map[string]func(json.RawMessage) error
+
+	// startMu guards the lifecycle fields below against concurrent Start
+	// and Close calls.
+	startMu   sync.Mutex
+	cancel    context.CancelFunc
+	unwatch   func() bool
+	stopped   chan struct{}
+	expired   chan struct{} // expired asks keepAlive to renew the listen key.
+	closeOnce sync.Once
+}
+
+// NewUserDataStream creates the user data stream of the account of c.
+func NewUserDataStream(c *client.Client) *UserDataStream {
+	return NewUserDataStreamWithEndpoint(c, listenKeyEndpoint)
+}
+
+// NewUserDataStreamWithEndpoint creates the user data stream of the account
+// of c, with listen keys managed on endpoint. It serves the APIs whose user
+// data stream is the one of USDⓈ-M futures, such as COIN-M futures.
+func NewUserDataStreamWithEndpoint(c *client.Client, endpoint string) *UserDataStream {
+	return NewUserDataStreamWithListenKeys(c, ListenKeys{Endpoint: endpoint})
+}
+
+// NewUserDataStreamWithListenKeys creates the user data stream of the
+// account of c, with listen keys managed by keys. Events other than those
+// of futures are delivered through HandleEvent.
+func NewUserDataStreamWithListenKeys(c *client.Client, keys ListenKeys) *UserDataStream {
+	return &UserDataStream{
+		rest:    c,
+		ws:      NewClient(c.Config().WSBaseURL),
+		keys:    keys,
+		expired: make(chan struct{}, 1),
+	}
+}
+
+// Client returns the WebSocket client of the stream, for example to set its
+// OnEvent and OnError callbacks before Start.
+func (u *UserDataStream) Client() *Client {
+	return u.ws
+}
+
+// OnOrderTradeUpdate sets the callback of order updates. Callbacks must be
+// set before Start.
+func (u *UserDataStream) OnOrderTradeUpdate(callback func(OrderTradeUpdateEvent)) {
+	u.onOrderTradeUpdate = callback
+}
+
+// OnAccountUpdate sets the callback of balance and position updates.
+func (u *UserDataStream) OnAccountUpdate(callback func(AccountUpdateEvent)) {
+	u.onAccountUpdate = callback
+}
+
+// OnMarginCall sets the callback of margin calls.
+func (u *UserDataStream) OnMarginCall(callback func(MarginCallEvent)) {
+	u.onMarginCall = callback
+}
+
+// OnAccountConfigUpdate sets the callback of leverage and multi-assets mode changes.
+func (u *UserDataStream) OnAccountConfigUpdate(callback func(AccountConfigUpdateEvent)) {
+	u.onAccountConfigUpdate = callback
+}
+
+// OnListenKeyExpired sets the callback of listen key expirations.
+func (u *UserDataStream) OnListenKeyExpired(callback func(ListenKeyExpiredEvent)) {
+	u.onListenKeyExpired = callback
+}
+
+// HandleEvent sets the handler of the events of eventType the stream does
+// not decode itself. A handler error is reported to the OnError callback.
+// Handlers must be set before Start.
+func (u *UserDataStream) HandleEvent(eventType string, handler func(json.RawMessage) error) {
+	if u.handlers == nil {
+		u.handlers = make(map[string]func(json.RawMessage) error)
+	}
+	u.handlers[eventType] = handler
+}
+
+// ListenKey returns the listen key the stream is subscribed with.
+func (u *UserDataStream) ListenKey() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.listenKey
+}
+
+// Start creates a listen key and subscribes to its stream. The stream is
+// closed when ctx is cancelled or Close is called, whichever happens first.
+func (u *UserDataStream) Start(ctx context.Context) error {
+	u.startMu.Lock()
+	defer u.startMu.Unlock()
+	if u.cancel != nil {
+		return ErrStreamStarted
+	}
+
+	key, err := u.keys.Create(ctx, u.rest)
+	if err != nil {
+		return err
+	}
+	if err := u.ws.Subscribe(u.handle, key); err != nil {
+		u.closeKey(key)
+		return err
+	}
+	u.mu.Lock()
+	u.listenKey = key
+	u.mu.Unlock()
+
+	u.unwatch = context.AfterFunc(ctx, u.Close)
+	streamCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	u.cancel = cancel
+	u.stopped = make(chan struct{})
+	go u.keepAlive(streamCtx)
+	return nil
+}
+
+// Close stops keeping the listen key alive, closes it and closes the connection.
+func (u *UserDataStream) Close() {
+	u.closeOnce.Do(func() {
+		u.startMu.Lock()
+		if u.cancel != nil {
+			u.unwatch()
+			u.cancel()
+			<-u.stopped
+		}
+		u.startMu.Unlock()
+		u.ws.Close()
+		if key := u.ListenKey(); key != "" {
+			u.closeKey(key)
+		}
+	})
+}
+
+// closeKey closes the listen key, logging a failure.
+func (u *UserDataStream) closeKey(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), writeWait)
+	defer cancel()
+	if err := u.keys.Close(ctx, u.rest, key); err != nil {
+		u.ws.log().Warn("failed to close listen key", "error", err)
+	}
+}
+
+// keepAlive keeps the listen key alive, and renews it when it expires,
+// until ctx is done. Renewals all run here, so they never overlap.
+func (u *UserDataStream) keepAlive(ctx context.Context) {
+	defer close(u.stopped)
+	interval := u.KeepAliveInterval
+	if interval <= 0 {
+		interval = DefaultKeepAliveInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-u.expired:
+			u.renew(ctx)
+		case <-ticker.C:
+			if err := u.keys.KeepAlive(ctx, u.rest, u.ListenKey()); err != nil {
+				u.ws.reportError(err)
+				u.renew(ctx)
+			}
+		}
+	}
+}
+
+// renew moves the stream to a new listen key if the current one was replaced.
+func (u *UserDataStream) renew(ctx context.Context) {
+	key, err := u.keys.Create(ctx, u.rest)
+	if err != nil {
+		u.ws.reportError(err)
+		return
+	}
+	u.mu.Lock()
+	old := u.listenKey
+	u.listenKey = key
+	u.mu.Unlock()
+	if key == old {
+		return
+	}
+
+	if err := u.ws.Subscribe(u.handle, key); err != nil {
+		u.ws.reportError(err)
+		return
+	}
+	if err := u.ws.Unsubscribe(old); err != nil {
+		u.ws.reportError(err)
+	}
+	u.ws.log().Info("listen key renewed")
+}
+
+// handle decodes a user data message and delivers it to its callback.
+func (u *UserDataStream) handle(data json.RawMessage) {
+	var header struct {
+		EventType string `json:"e"`
+		EventTime int64  `json:"E"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		u.ws.reportError(fmt.Errorf("failed to decode user data event: %w", err))
+		return
+	}
+
+	var err error
+	switch header.EventType {
+	case EventOrderTradeUpdate:
+		err = deliver(data, u.onOrderTradeUpdate)
+	case EventAccountUpdate:
+		err = deliver(data, u.onAccountUpdate)
+	case EventMarginCall:
+		err = deliver(data, u.onMarginCall)
+	case EventAccountConfigUpdate:
+		err = deliver(data, u.onAccountConfigUpdate)
+	case EventListenKeyExpired:
+		err = deliver(data, u.onListenKeyExpired)
+		// The handler runs on the read loop, which renewing waits on, so
+		// keepAlive renews the key. A renewal already asked for covers this one.
+		select {
+		case u.expired <- struct{}{}:
+		default:
+		}
+	default:
+		handler, ok := u.handlers[header.EventType]
+		if !ok {
+			u.ws.log().Debug("ignoring user data event", "type", header.EventType)
+			break
+		}
+		err = handler(data)
+	}
+	if err != nil {
+		u.ws.reportError(fmt.Errorf("failed to decode %s event: %w", header.EventType, err))
+	}
+}
+
+// deliver decodes data into T and calls callback with it, if it is set.
+func deliver[T any](data json.RawMessage, callback func(T)) error {
+	if callback == nil {
+		return nil
+	}
+	var event T
+	if err := json.Unmarshal(data, &event); err != nil {
+		return err
+	}
+	callback(event)
+	return nil
+}
@@ -0,0 +1,161 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestUserDataStream(t *testing.T) {
+	// Registered first, the check runs after the servers are closed: the
+	// renewal after the expiry must not outlive Close.
+	ignore := goleak.IgnoreCurrent()
+	t.Cleanup(func() { goleak.VerifyNone(t, ignore) })
+	s := newServer(t)
+	var mu sync.Mutex
+	var calls []string
+	keys := []string{"key1", "key2"}
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/fapi/v1/listenKey", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("X-MBX-APIKEY"))
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method)
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"listenKey":"` + keys[0] + `"}`))
+			keys = keys[1:]
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(rest.Close)
+
+	u := NewUserDataStream(client.NewClient(client.Config{
+		APIKey: "key", APISecret: "secret", BaseURL: rest.URL, WSBaseURL: "ws" + strings.TrimPrefix(s.URL, "http"),
+	}))
+	u.KeepAliveInterval = 20 * time.Millisecond
+	orders := make(chan OrderTradeUpdateEvent, 1)
+	u.OnOrderTradeUpdate(func(e OrderTradeUpdateEvent) { orders <- e })
+	configs := make(chan AccountConfigUpdateEvent, 1)
+	u.OnAccountConfigUpdate(func(e AccountConfigUpdateEvent) { configs <- e })
+	expired := make(chan ListenKeyExpiredEvent, 1)
+	u.OnListenKeyExpired(func(e ListenKeyExpiredEvent) { expired <- e })
+
+	require.NoError(t, u.Start(context.Background()))
+	assert.Equal(t, "key1", u.ListenKey())
+
+	require.NoError(t, s.write(s.last(), `{"stream":"key1","data":{"e":"ORDER_TRADE_UPDATE","E":2,"T":1,"o":{"s":"BTCUSDT","S":"BUY","x":"TRADE","X":"FILLED","i":7,"l":"0.5","L":"100","n":"0.01","N":"USDT","t":9,"T":1,"ap":"100","AP":"90"}}}`))
+	e := <-orders
+	assert.Equal(t, int64(2), e.EventTime)
+	assert.Equal(t, "BUY", e.Order.Side)
+	assert.Equal(t, "TRADE", e.Order.ExecutionType)
+	assert.Equal(t, "FILLED", e.Order.Status)
+	assert.Equal(t, "0.5", e.Order.LastFilledQty)
+	assert.Equal(t, "100", e.Order.LastFilledPrice)
+	assert.Equal(t, "USDT", e.Order.CommissionAsset)
+	assert.Equal(t, "100", e.Order.AvgPrice)
+	assert.Equal(t, "90", e.Order.ActivationPrice)
+
+	require.NoError(t, s.write(s.last(), `{"stream":"key1","data":{"e":"ACCOUNT_CONFIG_UPDATE","E":3,"T":3,"ac":{"s":"BTCUSDT","l":25}}}`))
+	config := <-configs
+	require.NotNil(t, config.Leverage)
+	assert.Equal(t, 25, config.Leverage.Leverage)
+	assert.Nil(t, config.MultiAssets)
+
+	// An expired listen key is replaced by a new one.
+	require.NoError(t, s.write(s.last(), `{"stream":"key1","data":{"e":"listenKeyExpired","E":4,"listenKey":"key1"}}`))
+	assert.Equal(t, "key1", (<-expired).ListenKey)
+	require.Eventually(t, func() bool {
+		return len(s.Requests()) == 3
+	}, time.Second, 5*time.Millisecond)
+	requests := s.Requests()
+	assert.Equal(t, request{Method: "SUBSCRIBE", Params: []string{"key2"}, ID: 2}, requests[1])
+	assert.Equal(t, request{Method: "UNSUBSCRIBE", Params: []string{"key1"}, ID: 3}, requests[2])
+	assert.Equal(t, "key2", u.ListenKey())
+	assert.Equal(t, []string{"key2"}, u.Client().Streams())
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, method := range calls {
+			if method == http.MethodPut {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 5*time.Millisecond)
+
+	u.Close()
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, http.MethodDelete, calls[len(calls)-1])
+}
+
+func TestUserDataStream_Start(t *testing.T) {
+	s := newServer(t)
+	var mu sync.Mutex
+	var calls []string
+	keys := []string{"invalid1", "key2"}
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method)
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"listenKey":"` + keys[0] + `"}`))
+			keys = keys[1:]
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(rest.Close)
+
+	u := NewUserDataStream(client.NewClient(client.Config{
+		APIKey: "key", APISecret: "secret", BaseURL: rest.URL, WSBaseURL: "ws" + strings.TrimPrefix(s.URL, "http"),
+	}))
+	defer u.Close()
+
+	// A listen key whose subscription is rejected is closed again.
+	require.Error(t, u.Start(context.Background()))
+	assert.Empty(t, u.ListenKey())
+	mu.Lock()
+	assert.Equal(t, []string{http.MethodPost, http.MethodDelete}, calls)
+	mu.Unlock()
+
+	require.NoError(t, u.Start(context.Background()))
+	assert.Equal(t, "key2", u.ListenKey())
+	assert.ErrorIs(t, u.Start(context.Background()), ErrStreamStarted)
+}
+
+func TestListenKeys_KeyParam(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	c := clienttest.NewServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/userDataStream", r.URL.Path)
+		assert.Equal(t, clienttest.APIKey, r.Header.Get("X-MBX-APIKEY"))
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.Form.Get("listenKey"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"listenKey":"key1"}`))
+	})
+	keys := ListenKeys{Endpoint: "/api/v3/userDataStream", KeyParam: true}
+	ctx := context.Background()
+
+	key, err := keys.Create(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, "key1", key)
+	require.NoError(t, keys.KeepAlive(ctx, c, key))
+	require.NoError(t, keys.Close(ctx, c, key))
+
+	assert.Equal(t, []string{"POST ", "PUT key1", "DELETE key1"}, calls)
+}
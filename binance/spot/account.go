@@ -0,0 +1,179 @@
+package spot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+)
+
+// Endpoints used for account queries.
+const (
+	accountEndpoint        = "/api/v3/account"
+	myTradesEndpoint       = "/api/v3/myTrades"
+	orderRateLimitEndpoint = "/api/v3/rateLimit/order"
+	commissionEndpoint     = "/api/v3/account/commission"
+)
+
+// Account defines the interface for spot account and trade queries.
+type Account interface {
+	// AccountInfo returns the account; zero balances are left out if omitZeroBalances is set.
+	AccountInfo(omitZeroBalances bool) (*AccountInfo, error)
+	Trades(req *TradesRequest) ([]Trade, error)
+	// OrderRateLimits returns the order count of the account in every order limit.
+	OrderRateLimits() ([]OrderRateLimit, error)
+	CommissionRates(symbol string) (*CommissionRates, error)
+}
+
+// Commissions are commission rates by role.
+type Commissions struct {
+	Maker  string `json:"maker"`
+	Taker  string `json:"taker"`
+	Buyer  string `json:"buyer"`
+	Seller string `json:"seller"`
+}
+
+// Balance is the balance of an asset.
+type Balance struct {
+	Asset  string `json:"asset"`
+	Free   string `json:"free"`
+	Locked string `json:"locked"`
+}
+
+// AccountInfo is the state of a spot account.
+type AccountInfo struct {
+	MakerCommission            int         `json:"makerCommission"`
+	TakerCommission            int         `json:"takerCommission"`
+	BuyerCommission            int         `json:"buyerCommission"`
+	SellerCommission           int         `json:"sellerCommission"`
+	CommissionRates            Commissions `json:"commissionRates"`
+	CanTrade                   bool        `json:"canTrade"`
+	CanWithdraw                bool        `json:"canWithdraw"`
+	CanDeposit                 bool        `json:"canDeposit"`
+	Brokered                   bool        `json:"brokered"`
+	RequireSelfTradePrevention bool        `json:"requireSelfTradePrevention"`
+	PreventSor                 bool        `json:"preventSor"`
+	UpdateTime                 int64       `json:"updateTime"`
+	AccountType                string      `json:"accountType"`
+	Balances                   []Balance   `json:"balances"`
+	Permissions                []string    `json:"permissions"`
+	UID                        int64       `json:"uid"`
+}
+
+// TradesRequest filters the trades returned by Trades. Trades are returned
+// from FromID on if it is set, otherwise the most recent ones.
+type TradesRequest struct {
+	Symbol    string
+	OrderID   int64 // OrderID limits the trades to those of an order.
+	FromID    int64
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 500, with a maximum of 1000.
+}
+
+func (r *TradesRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	setInt64(v, "orderId", r.OrderID)
+	setInt64(v, "fromId", r.FromID)
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt64(v, "limit", int64(r.Limit))
+	return v
+}
+
+// Trade is a trade of the account.
+type Trade struct {
+	Symbol          string `json:"symbol"`
+	ID              int64  `json:"id"`
+	OrderID         int64  `json:"orderId"`
+	OrderListID     int64  `json:"orderListId"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	IsBuyer         bool   `json:"isBuyer"`
+	IsMaker         bool   `json:"isMaker"`
+	IsBestMatch     bool   `json:"isBestMatch"`
+}
+
+// OrderRateLimit is the number of orders the account placed in an order limit.
+type OrderRateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int    `json:"intervalNum"`
+	Limit         int    `json:"limit"`
+	Count         int    `json:"count"`
+}
+
+// CommissionRates is the commission of the account on a symbol.
+type CommissionRates struct {
+	Symbol             string      `json:"symbol"`
+	StandardCommission Commissions `json:"standardCommission"`
+	TaxCommission      Commissions `json:"taxCommission"`
+	Discount           struct {
+		EnabledForAccount bool   `json:"enabledForAccount"`
+		EnabledForSymbol  bool   `json:"enabledForSymbol"`
+		DiscountAsset     string `json:"discountAsset"`
+		Discount          string `json:"discount"`
+	} `json:"discount"`
+}
+
+// accountImpl implements Account using Binance spot API.
+type accountImpl struct {
+	*client.Client
+}
+
+// NewAccount creates a new Account instance.
+func NewAccount(client *client.Client) Account {
+	return &accountImpl{client}
+}
+
+// signed sends a signed GET request with params and decodes the response into out.
+func (a *accountImpl) signed(endpoint string, params url.Values, out any) error {
+	return a.Do(context.Background(), http.MethodGet, endpoint, params, client.SecuritySigned, out)
+}
+
+func (a *accountImpl) AccountInfo(omitZeroBalances bool) (*AccountInfo, error) {
+	v := url.Values{}
+	if omitZeroBalances {
+		v.Set("omitZeroBalances", "true")
+	}
+	info := new(AccountInfo)
+	if err := a.signed(accountEndpoint, v, info); err != nil {
+		return nil, fmt.Errorf("failed to get account info: %w", err)
+	}
+	return info, nil
+}
+
+func (a *accountImpl) Trades(req *TradesRequest) ([]Trade, error) {
+	if req.Symbol == "" {
+		return nil, fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	var trades []Trade
+	if err := a.signed(myTradesEndpoint, req.values(), &trades); err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (a *accountImpl) OrderRateLimits() ([]OrderRateLimit, error) {
+	var limits []OrderRateLimit
+	if err := a.signed(orderRateLimitEndpoint, nil, &limits); err != nil {
+		return nil, fmt.Errorf("failed to get order rate limits: %w", err)
+	}
+	return limits, nil
+}
+
+func (a *accountImpl) CommissionRates(symbol string) (*CommissionRates, error) {
+	rates := new(CommissionRates)
+	if err := a.signed(commissionEndpoint, url.Values{"symbol": {symbol}}, rates); err != nil {
+		return nil, fmt.Errorf("failed to get commission rates: %w", err)
+	}
+	return rates, nil
+}
@@ -0,0 +1,50 @@
+package spot
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_AccountInfo(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v3/account", r.URL.Path)
+		params := clienttest.SignedParams(t, r)
+		assert.Equal(t, "true", params.Get("omitZeroBalances"))
+		_, _ = w.Write([]byte(`{"canTrade":true,"accountType":"SPOT","commissionRates":{"maker":"0.001"},
+			"balances":[{"asset":"BTC","free":"1.5","locked":"0"}]}`))
+	})
+
+	info, err := NewAccount(c).AccountInfo(true)
+	require.NoError(t, err)
+	assert.True(t, info.CanTrade)
+	assert.Equal(t, "0.001", info.CommissionRates.Maker)
+	require.Len(t, info.Balances, 1)
+	assert.Equal(t, "1.5", info.Balances[0].Free)
+}
+
+func TestAccount_Trades(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v3/myTrades", r.URL.Path)
+		params := clienttest.SignedParams(t, r)
+		assert.Equal(t, "BTCUSDT", params.Get("symbol"))
+		assert.Equal(t, "7", params.Get("orderId"))
+		assert.Equal(t, "10", params.Get("limit"))
+		_, _ = w.Write([]byte(`[{"symbol":"BTCUSDT","id":1,"orderId":7,"price":"100","qty":"0.5","isBuyer":true}]`))
+	})
+	account := NewAccount(c)
+
+	_, err := account.Trades(&TradesRequest{})
+	require.ErrorIs(t, err, ErrInvalidOrder)
+
+	trades, err := account.Trades(&TradesRequest{Symbol: "BTCUSDT", OrderID: 7, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, trades, 1)
+	assert.Equal(t, "0.5", trades[0].Qty)
+	assert.True(t, trades[0].IsBuyer)
+}
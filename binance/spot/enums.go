@@ -0,0 +1,75 @@
+package spot
+
+// Side is the side of an order.
+type Side string
+
+const (
+	SideBuy  Side = "BUY"
+	SideSell Side = "SELL"
+)
+
+// OrderType is the type of order.
+type OrderType string
+
+const (
+	OrderTypeLimit           OrderType = "LIMIT"
+	OrderTypeMarket          OrderType = "MARKET"
+	OrderTypeStopLoss        OrderType = "STOP_LOSS"
+	OrderTypeStopLossLimit   OrderType = "STOP_LOSS_LIMIT"
+	OrderTypeTakeProfit      OrderType = "TAKE_PROFIT"
+	OrderTypeTakeProfitLimit OrderType = "TAKE_PROFIT_LIMIT"
+	OrderTypeLimitMaker      OrderType = "LIMIT_MAKER"
+)
+
+// TimeInForce is how long an order remains active.
+type TimeInForce string
+
+const (
+	TimeInForceGTC TimeInForce = "GTC" // Good till cancelled.
+	TimeInForceIOC TimeInForce = "IOC" // Immediate or cancel.
+	TimeInForceFOK TimeInForce = "FOK" // Fill or kill.
+)
+
+// OrderStatus is the state of an order.
+type OrderStatus string
+
+const (
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusPendingNew      OrderStatus = "PENDING_NEW"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCanceled        OrderStatus = "CANCELED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
+	OrderStatusExpiredInMatch  OrderStatus = "EXPIRED_IN_MATCH"
+)
+
+// ResponseType is how much of a new order is returned.
+type ResponseType string
+
+const (
+	ResponseTypeACK    ResponseType = "ACK"
+	ResponseTypeResult ResponseType = "RESULT"
+	ResponseTypeFull   ResponseType = "FULL" // ResponseTypeFull adds the fills of the order.
+)
+
+// SelfTradePrevention is what happens when an order would trade against
+// another order of the same account.
+type SelfTradePrevention string
+
+const (
+	SelfTradePreventionNone        SelfTradePrevention = "NONE"
+	SelfTradePreventionExpireTaker SelfTradePrevention = "EXPIRE_TAKER"
+	SelfTradePreventionExpireMaker SelfTradePrevention = "EXPIRE_MAKER"
+	SelfTradePreventionExpireBoth  SelfTradePrevention = "EXPIRE_BOTH"
+)
+
+// CancelReplaceMode is what a cancel-replace request does when the cancel fails.
+type CancelReplaceMode string
+
+const (
+	// CancelReplaceStopOnFailure does not place the new order if the cancel fails.
+	CancelReplaceStopOnFailure CancelReplaceMode = "STOP_ON_FAILURE"
+	// CancelReplaceAllowFailure places the new order even if the cancel fails.
+	CancelReplaceAllowFailure CancelReplaceMode = "ALLOW_FAILURE"
+)
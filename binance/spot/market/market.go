@@ -0,0 +1,313 @@
+// Package market is the market data of the Binance spot API.
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	futuresmarket "github.com/cploutarchou/crypto-sdk-suite/binance/futures/market"
+)
+
+// Endpoints of the spot market data.
+const (
+	pingEndpoint             = "/api/v3/ping"
+	serverTimeEndpoint       = "/api/v3/time"
+	exchangeInfoEndpoint     = "/api/v3/exchangeInfo"
+	depthEndpoint            = "/api/v3/depth"
+	tradesEndpoint           = "/api/v3/trades"
+	historicalTradesEndpoint = "/api/v3/historicalTrades"
+	aggTradesEndpoint        = "/api/v3/aggTrades"
+	klinesEndpoint           = "/api/v3/klines"
+	uiKlinesEndpoint         = "/api/v3/uiKlines"
+	avgPriceEndpoint         = "/api/v3/avgPrice"
+	ticker24hrEndpoint       = "/api/v3/ticker/24hr"
+	tickerPriceEndpoint      = "/api/v3/ticker/price"
+	bookTickerEndpoint       = "/api/v3/ticker/bookTicker"
+	rollingTickerEndpoint    = "/api/v3/ticker"
+)
+
+// Interval is the interval of klines. The spot API accepts the futures
+// intervals and OneSecond.
+type Interval = futuresmarket.Interval
+
+// OneSecond is the kline interval only the spot API supports.
+const OneSecond Interval = "1s"
+
+// Kline is a candlestick, as returned by the futures API too.
+type Kline = futuresmarket.Kline
+
+// Market defines the interface for spot market data.
+type Market interface {
+	Ping() error
+	ServerTime() (time.Time, error)
+	// ExchangeInfo returns the trading rules of symbols, or of every symbol if
+	// none are given. The rate limits it lists replace the ones of the
+	// client's limiter.
+	ExchangeInfo(symbols ...string) (*ExchangeInfo, error)
+	OrderBook(symbol string, limit int) (*OrderBook, error)
+	RecentTrades(symbol string, limit int) ([]Trade, error)
+	// HistoricalTrades returns trades from fromID on, or the most recent
+	// ones if it is 0. It requires the API key.
+	HistoricalTrades(symbol string, limit int, fromID int64) ([]Trade, error)
+	AggTrades(req *AggTradesRequest) ([]AggTrade, error)
+	Klines(req *KlinesRequest) ([]Kline, error)
+	// UIKlines returns klines tuned for presentation in charts.
+	UIKlines(req *KlinesRequest) ([]Kline, error)
+	AvgPrice(symbol string) (*AvgPrice, error)
+
+	Ticker24hr(symbol string) (*Ticker24hr, error)
+	// Tickers24hr returns the tickers of symbols, or of every symbol if none are given.
+	Tickers24hr(symbols ...string) ([]Ticker24hr, error)
+	PriceTicker(symbol string) (*PriceTicker, error)
+	PriceTickers(symbols ...string) ([]PriceTicker, error)
+	BookTicker(symbol string) (*BookTicker, error)
+	BookTickers(symbols ...string) ([]BookTicker, error)
+	// RollingTicker returns the statistics of symbol over windowSize, from
+	// 1 minute to 7 days; 0 uses the default of 1 day.
+	RollingTicker(symbol string, windowSize time.Duration) (*Ticker24hr, error)
+}
+
+type marketImpl struct {
+	*client.Client
+}
+
+// NewMarket creates a new Market instance.
+func NewMarket(client *client.Client) Market {
+	return &marketImpl{client}
+}
+
+// get sends a public GET request with params and decodes the response into out.
+func (m *marketImpl) get(endpoint string, params url.Values, out any) error {
+	return m.Do(context.Background(), http.MethodGet, endpoint, params, client.SecurityNone, out)
+}
+
+func (m *marketImpl) Ping() error {
+	if err := m.get(pingEndpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to ping: %w", err)
+	}
+	return nil
+}
+
+func (m *marketImpl) ServerTime() (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	if err := m.get(serverTimeEndpoint, nil, &resp); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get server time: %w", err)
+	}
+	return time.UnixMilli(resp.ServerTime), nil
+}
+
+func (m *marketImpl) ExchangeInfo(symbols ...string) (*ExchangeInfo, error) {
+	v, err := symbolValues(symbols)
+	if err != nil {
+		return nil, err
+	}
+	info := new(ExchangeInfo)
+	if err := m.get(exchangeInfoEndpoint, v, info); err != nil {
+		return nil, fmt.Errorf("failed to get exchange info: %w", err)
+	}
+
+	limits := make([]client.RateLimit, 0, len(info.RateLimits))
+	for _, rl := range info.RateLimits {
+		limit, err := client.ParseRateLimit(rl.RateLimitType, rl.Interval, rl.IntervalNum, rl.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rate limits: %w", err)
+		}
+		limits = append(limits, limit)
+	}
+	if len(limits) > 0 {
+		m.Limiter().SetLimits(limits)
+	}
+	return info, nil
+}
+
+func (m *marketImpl) OrderBook(symbol string, limit int) (*OrderBook, error) {
+	v := url.Values{"symbol": {symbol}}
+	setInt(v, "limit", limit)
+	book := new(OrderBook)
+	if err := m.get(depthEndpoint, v, book); err != nil {
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+	return book, nil
+}
+
+func (m *marketImpl) RecentTrades(symbol string, limit int) ([]Trade, error) {
+	v := url.Values{"symbol": {symbol}}
+	setInt(v, "limit", limit)
+	var trades []Trade
+	if err := m.get(tradesEndpoint, v, &trades); err != nil {
+		return nil, fmt.Errorf("failed to get recent trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (m *marketImpl) HistoricalTrades(symbol string, limit int, fromID int64) ([]Trade, error) {
+	v := url.Values{"symbol": {symbol}}
+	setInt(v, "limit", limit)
+	if fromID > 0 {
+		v.Set("fromId", strconv.FormatInt(fromID, 10))
+	}
+	var trades []Trade
+	err := m.Do(context.Background(), http.MethodGet, historicalTradesEndpoint, v, client.SecurityAPIKey, &trades)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get historical trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (m *marketImpl) AggTrades(req *AggTradesRequest) ([]AggTrade, error) {
+	var trades []AggTrade
+	if err := m.get(aggTradesEndpoint, req.values(), &trades); err != nil {
+		return nil, fmt.Errorf("failed to get aggregate trades: %w", err)
+	}
+	return trades, nil
+}
+
+func (m *marketImpl) Klines(req *KlinesRequest) ([]Kline, error) {
+	var klines []Kline
+	if err := m.get(klinesEndpoint, req.values(), &klines); err != nil {
+		return nil, fmt.Errorf("failed to get klines: %w", err)
+	}
+	return klines, nil
+}
+
+func (m *marketImpl) UIKlines(req *KlinesRequest) ([]Kline, error) {
+	var klines []Kline
+	if err := m.get(uiKlinesEndpoint, req.values(), &klines); err != nil {
+		return nil, fmt.Errorf("failed to get UI klines: %w", err)
+	}
+	return klines, nil
+}
+
+func (m *marketImpl) AvgPrice(symbol string) (*AvgPrice, error) {
+	price := new(AvgPrice)
+	if err := m.get(avgPriceEndpoint, url.Values{"symbol": {symbol}}, price); err != nil {
+		return nil, fmt.Errorf("failed to get average price: %w", err)
+	}
+	return price, nil
+}
+
+func (m *marketImpl) Ticker24hr(symbol string) (*Ticker24hr, error) {
+	ticker := new(Ticker24hr)
+	if err := m.get(ticker24hrEndpoint, url.Values{"symbol": {symbol}}, ticker); err != nil {
+		return nil, fmt.Errorf("failed to get 24hr ticker: %w", err)
+	}
+	return ticker, nil
+}
+
+func (m *marketImpl) Tickers24hr(symbols ...string) ([]Ticker24hr, error) {
+	v, err := symbolValues(symbols)
+	if err != nil {
+		return nil, err
+	}
+	var tickers []Ticker24hr
+	if err := m.get(ticker24hrEndpoint, v, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get 24hr tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+func (m *marketImpl) PriceTicker(symbol string) (*PriceTicker, error) {
+	ticker := new(PriceTicker)
+	if err := m.get(tickerPriceEndpoint, url.Values{"symbol": {symbol}}, ticker); err != nil {
+		return nil, fmt.Errorf("failed to get price ticker: %w", err)
+	}
+	return ticker, nil
+}
+
+func (m *marketImpl) PriceTickers(symbols ...string) ([]PriceTicker, error) {
+	v, err := symbolValues(symbols)
+	if err != nil {
+		return nil, err
+	}
+	var tickers []PriceTicker
+	if err := m.get(tickerPriceEndpoint, v, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get price tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+func (m *marketImpl) BookTicker(symbol string) (*BookTicker, error) {
+	ticker := new(BookTicker)
+	if err := m.get(bookTickerEndpoint, url.Values{"symbol": {symbol}}, ticker); err != nil {
+		return nil, fmt.Errorf("failed to get book ticker: %w", err)
+	}
+	return ticker, nil
+}
+
+func (m *marketImpl) BookTickers(symbols ...string) ([]BookTicker, error) {
+	v, err := symbolValues(symbols)
+	if err != nil {
+		return nil, err
+	}
+	var tickers []BookTicker
+	if err := m.get(bookTickerEndpoint, v, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get book tickers: %w", err)
+	}
+	return tickers, nil
+}
+
+func (m *marketImpl) RollingTicker(symbol string, windowSize time.Duration) (*Ticker24hr, error) {
+	v := url.Values{"symbol": {symbol}}
+	if windowSize > 0 {
+		v.Set("windowSize", formatWindow(windowSize))
+	}
+	ticker := new(Ticker24hr)
+	if err := m.get(rollingTickerEndpoint, v, ticker); err != nil {
+		return nil, fmt.Errorf("failed to get rolling window ticker: %w", err)
+	}
+	return ticker, nil
+}
+
+// formatWindow formats a rolling window size in the largest whole unit,
+// such as "15m", "4h" or "2d".
+func formatWindow(d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d%day == 0:
+		return strconv.FormatInt(int64(d/day), 10) + "d"
+	case d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	default:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	}
+}
+
+// symbolValues returns the parameters selecting symbols: symbol for one,
+// symbols as a JSON list for several, and none for every symbol.
+func symbolValues(symbols []string) (url.Values, error) {
+	v := url.Values{}
+	switch len(symbols) {
+	case 0:
+	case 1:
+		v.Set("symbol", symbols[0])
+	default:
+		list, err := json.Marshal(symbols)
+		if err != nil {
+			return nil, err
+		}
+		v.Set("symbols", string(list))
+	}
+	return v, nil
+}
+
+// setInt sets key unless n is 0 or less.
+func setInt(v url.Values, key string, n int) {
+	if n > 0 {
+		v.Set(key, strconv.Itoa(n))
+	}
+}
+
+// setTime sets key to t in milliseconds unless t is zero.
+func setTime(v url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		v.Set(key, strconv.FormatInt(t.UnixMilli(), 10))
+	}
+}
@@ -0,0 +1,42 @@
+package market
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarket_ExchangeInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/exchangeInfo", r.URL.Path)
+		assert.Equal(t, `["BTCUSDT","ETHUSDT"]`, r.URL.Query().Get("symbols"))
+		_, _ = w.Write([]byte(`{"timezone":"UTC","rateLimits":[
+			{"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":6000},
+			{"rateLimitType":"ORDERS","interval":"SECOND","intervalNum":10,"limit":100}],
+			"symbols":[{"symbol":"BTCUSDT","ocoAllowed":true,"filters":[{"filterType":"NOTIONAL","minNotional":"5.0","applyMinToMarket":true}]}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := client.NewClient(client.Config{BaseURL: srv.URL})
+
+	info, err := NewMarket(c).ExchangeInfo("BTCUSDT", "ETHUSDT")
+	require.NoError(t, err)
+	require.Len(t, info.Symbols, 1)
+	assert.True(t, info.Symbols[0].OcoAllowed)
+	assert.Equal(t, "5.0", info.Symbols[0].Filters[0].MinNotional)
+
+	usage := c.Limiter().Usage()
+	require.Len(t, usage, 2)
+	assert.Equal(t, client.RateLimit{Type: client.RateLimitRequestWeight, Interval: time.Minute, Limit: 6000}, usage[0].RateLimit)
+	assert.Equal(t, client.RateLimit{Type: client.RateLimitOrders, Interval: 10 * time.Second, Limit: 100}, usage[1].RateLimit)
+}
+
+func TestFormatWindow(t *testing.T) {
+	assert.Equal(t, "15m", formatWindow(15*time.Minute))
+	assert.Equal(t, "4h", formatWindow(4*time.Hour))
+	assert.Equal(t, "2d", formatWindow(48*time.Hour))
+}
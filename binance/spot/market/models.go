@@ -0,0 +1,197 @@
+package market
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RateLimit is a rate limit listed by the exchange info.
+type RateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int    `json:"intervalNum"`
+	Limit         int    `json:"limit"`
+}
+
+// SymbolFilter is a trading rule of a symbol. Which fields are set depends
+// on FilterType, such as PRICE_FILTER, LOT_SIZE or NOTIONAL.
+type SymbolFilter struct {
+	FilterType            string `json:"filterType"`
+	MinPrice              string `json:"minPrice,omitempty"`
+	MaxPrice              string `json:"maxPrice,omitempty"`
+	TickSize              string `json:"tickSize,omitempty"`
+	MinQty                string `json:"minQty,omitempty"`
+	MaxQty                string `json:"maxQty,omitempty"`
+	StepSize              string `json:"stepSize,omitempty"`
+	MinNotional           string `json:"minNotional,omitempty"`
+	MaxNotional           string `json:"maxNotional,omitempty"`
+	ApplyMinToMarket      bool   `json:"applyMinToMarket,omitempty"`
+	ApplyMaxToMarket      bool   `json:"applyMaxToMarket,omitempty"`
+	AvgPriceMins          int    `json:"avgPriceMins,omitempty"`
+	Limit                 int    `json:"limit,omitempty"`
+	MaxNumOrders          int    `json:"maxNumOrders,omitempty"`
+	MaxNumAlgoOrders      int    `json:"maxNumAlgoOrders,omitempty"`
+	BidMultiplierUp       string `json:"bidMultiplierUp,omitempty"`
+	BidMultiplierDown     string `json:"bidMultiplierDown,omitempty"`
+	AskMultiplierUp       string `json:"askMultiplierUp,omitempty"`
+	AskMultiplierDown     string `json:"askMultiplierDown,omitempty"`
+	MinTrailingAboveDelta int    `json:"minTrailingAboveDelta,omitempty"`
+	MaxTrailingAboveDelta int    `json:"maxTrailingAboveDelta,omitempty"`
+	MinTrailingBelowDelta int    `json:"minTrailingBelowDelta,omitempty"`
+	MaxTrailingBelowDelta int    `json:"maxTrailingBelowDelta,omitempty"`
+}
+
+// SymbolInfo is the trading rules of a symbol.
+type SymbolInfo struct {
+	Symbol                          string         `json:"symbol"`
+	Status                          string         `json:"status"`
+	BaseAsset                       string         `json:"baseAsset"`
+	BaseAssetPrecision              int            `json:"baseAssetPrecision"`
+	QuoteAsset                      string         `json:"quoteAsset"`
+	QuoteAssetPrecision             int            `json:"quoteAssetPrecision"`
+	BaseCommissionPrecision         int            `json:"baseCommissionPrecision"`
+	QuoteCommissionPrecision        int            `json:"quoteCommissionPrecision"`
+	OrderTypes                      []string       `json:"orderTypes"`
+	IcebergAllowed                  bool           `json:"icebergAllowed"`
+	OcoAllowed                      bool           `json:"ocoAllowed"`
+	OtoAllowed                      bool           `json:"otoAllowed"`
+	QuoteOrderQtyMarketAllowed      bool           `json:"quoteOrderQtyMarketAllowed"`
+	AllowTrailingStop               bool           `json:"allowTrailingStop"`
+	CancelReplaceAllowed            bool           `json:"cancelReplaceAllowed"`
+	IsSpotTradingAllowed            bool           `json:"isSpotTradingAllowed"`
+	IsMarginTradingAllowed          bool           `json:"isMarginTradingAllowed"`
+	Filters                         []SymbolFilter `json:"filters"`
+	PermissionSets                  [][]string     `json:"permissionSets"`
+	DefaultSelfTradePreventionMode  string         `json:"defaultSelfTradePreventionMode"`
+	AllowedSelfTradePreventionModes []string       `json:"allowedSelfTradePreventionModes"`
+}
+
+// ExchangeInfo is the trading rules and rate limits of the exchange.
+type ExchangeInfo struct {
+	Timezone   string       `json:"timezone"`
+	ServerTime int64        `json:"serverTime"`
+	RateLimits []RateLimit  `json:"rateLimits"`
+	Symbols    []SymbolInfo `json:"symbols"`
+}
+
+// OrderBook is a snapshot of the order book of a symbol.
+type OrderBook struct {
+	LastUpdateID int64      `json:"lastUpdateId"`
+	Bids         [][]string `json:"bids"` // Bids are [price, quantity] pairs by descending price.
+	Asks         [][]string `json:"asks"` // Asks are [price, quantity] pairs by ascending price.
+}
+
+// Trade is a trade of a symbol.
+type Trade struct {
+	ID           int64  `json:"id"`
+	Price        string `json:"price"`
+	Qty          string `json:"qty"`
+	QuoteQty     string `json:"quoteQty"`
+	Time         int64  `json:"time"`
+	IsBuyerMaker bool   `json:"isBuyerMaker"`
+	IsBestMatch  bool   `json:"isBestMatch"`
+}
+
+// AggTrade is a trade aggregated over the fills of one taker order at one price.
+type AggTrade struct {
+	AggTradeID   int64  `json:"a"`
+	Price        string `json:"p"`
+	Qty          string `json:"q"`
+	FirstTradeID int64  `json:"f"`
+	LastTradeID  int64  `json:"l"`
+	Time         int64  `json:"T"`
+	IsBuyerMaker bool   `json:"m"`
+	IsBestMatch  bool   `json:"M"`
+}
+
+// AggTradesRequest filters the trades returned by AggTrades. Trades are
+// returned from FromID on if it is set, otherwise from StartTime on, or
+// the most recent ones.
+type AggTradesRequest struct {
+	Symbol    string
+	FromID    int64
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 500, with a maximum of 1000.
+}
+
+func (r *AggTradesRequest) values() url.Values {
+	v := url.Values{"symbol": {r.Symbol}}
+	if r.FromID > 0 {
+		v.Set("fromId", strconv.FormatInt(r.FromID, 10))
+	}
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt(v, "limit", r.Limit)
+	return v
+}
+
+// KlinesRequest selects the klines returned by Klines and UIKlines.
+type KlinesRequest struct {
+	Symbol    string
+	Interval  Interval
+	StartTime time.Time
+	EndTime   time.Time
+	TimeZone  string // TimeZone offsets the kline boundaries, such as "+08:00"; UTC by default.
+	Limit     int    // Limit defaults to 500, with a maximum of 1000.
+}
+
+func (r *KlinesRequest) values() url.Values {
+	v := url.Values{"symbol": {r.Symbol}, "interval": {string(r.Interval)}}
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	if r.TimeZone != "" {
+		v.Set("timeZone", r.TimeZone)
+	}
+	setInt(v, "limit", r.Limit)
+	return v
+}
+
+// AvgPrice is the average price of a symbol over the last Mins minutes.
+type AvgPrice struct {
+	Mins      int    `json:"mins"`
+	Price     string `json:"price"`
+	CloseTime int64  `json:"closeTime"`
+}
+
+// Ticker24hr is the statistics of a symbol over a rolling window, of 24
+// hours unless requested otherwise.
+type Ticker24hr struct {
+	Symbol             string `json:"symbol"`
+	PriceChange        string `json:"priceChange"`
+	PriceChangePercent string `json:"priceChangePercent"`
+	WeightedAvgPrice   string `json:"weightedAvgPrice"`
+	PrevClosePrice     string `json:"prevClosePrice"`
+	LastPrice          string `json:"lastPrice"`
+	LastQty            string `json:"lastQty"`
+	BidPrice           string `json:"bidPrice"`
+	BidQty             string `json:"bidQty"`
+	AskPrice           string `json:"askPrice"`
+	AskQty             string `json:"askQty"`
+	OpenPrice          string `json:"openPrice"`
+	HighPrice          string `json:"highPrice"`
+	LowPrice           string `json:"lowPrice"`
+	Volume             string `json:"volume"`
+	QuoteVolume        string `json:"quoteVolume"`
+	OpenTime           int64  `json:"openTime"`
+	CloseTime          int64  `json:"closeTime"`
+	FirstID            int64  `json:"firstId"`
+	LastID             int64  `json:"lastId"`
+	Count              int64  `json:"count"`
+}
+
+// PriceTicker is the last price of a symbol.
+type PriceTicker struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+// BookTicker is the best bid and ask of a symbol.
+type BookTicker struct {
+	Symbol   string `json:"symbol"`
+	BidPrice string `json:"bidPrice"`
+	BidQty   string `json:"bidQty"`
+	AskPrice string `json:"askPrice"`
+	AskQty   string `json:"askQty"`
+}
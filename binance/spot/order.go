@@ -0,0 +1,414 @@
+package spot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+)
+
+// Endpoints used for order management.
+const (
+	orderEndpoint         = "/api/v3/order"
+	testOrderEndpoint     = "/api/v3/order/test"
+	cancelReplaceEndpoint = "/api/v3/order/cancelReplace"
+	openOrdersEndpoint    = "/api/v3/openOrders"
+	allOrdersEndpoint     = "/api/v3/allOrders"
+)
+
+// ErrInvalidOrder is returned when an order request is missing a parameter
+// its type requires, before it is sent.
+var ErrInvalidOrder = errors.New("invalid order")
+
+// Orders defines the interface for spot order management.
+type Orders interface {
+	NewOrder(req *NewOrderRequest) (*Order, error)
+	// TestOrder validates req with the server without placing it.
+	TestOrder(req *NewOrderRequest) error
+	CancelOrder(query OrderQuery) (*Order, error)
+	// CancelOpenOrders cancels every open order of symbol, order lists included.
+	CancelOpenOrders(symbol string) ([]Order, error)
+	// CancelReplace cancels an order and places a new one in one request.
+	// When either part fails, the error is a *client.BinanceAPIError.
+	CancelReplace(req *CancelReplaceRequest) (*CancelReplaceResult, error)
+	QueryOrder(query OrderQuery) (*Order, error)
+	// OpenOrders returns the open orders of symbol, or of every symbol if it is empty.
+	OpenOrders(symbol string) ([]Order, error)
+	AllOrders(req *AllOrdersRequest) ([]Order, error)
+
+	// NewOCO places a pair of orders where the fill of one cancels the other.
+	NewOCO(req *OCORequest) (*OrderList, error)
+	// NewOTO places a working order that places a pending order when it fills.
+	NewOTO(req *OTORequest) (*OrderList, error)
+	// NewOTOCO places a working order that places an OCO pair when it fills.
+	NewOTOCO(req *OTOCORequest) (*OrderList, error)
+	CancelOrderList(query OrderListQuery) (*OrderList, error)
+	QueryOrderList(query OrderListQuery) (*OrderList, error)
+	AllOrderLists(req *AllOrderListsRequest) ([]OrderList, error)
+	OpenOrderLists() ([]OrderList, error)
+}
+
+// NewOrderRequest is a new order. The parameters required depend on Type:
+//
+//   - LIMIT: TimeInForce, Quantity and Price.
+//   - MARKET: Quantity or QuoteOrderQty.
+//   - STOP_LOSS and TAKE_PROFIT: Quantity, and StopPrice or TrailingDelta.
+//   - STOP_LOSS_LIMIT and TAKE_PROFIT_LIMIT: TimeInForce, Quantity, Price,
+//     and StopPrice or TrailingDelta.
+//   - LIMIT_MAKER: Quantity and Price.
+type NewOrderRequest struct {
+	Symbol           string
+	Side             Side
+	Type             OrderType
+	TimeInForce      TimeInForce
+	Quantity         string
+	QuoteOrderQty    string // QuoteOrderQty is the amount of the quote asset a MARKET order spends or receives.
+	Price            string
+	NewClientOrderID string
+	StopPrice        string
+	// TrailingDelta is the trailing stop distance in basis points.
+	TrailingDelta           int64
+	IcebergQty              string
+	NewOrderRespType        ResponseType
+	SelfTradePreventionMode SelfTradePrevention
+	StrategyID              int64
+	StrategyType            int64 // StrategyType must be 1000000 or more.
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if r is missing a
+// parameter its type requires.
+func (r *NewOrderRequest) Validate() error {
+	missing := func(param string) error {
+		return fmt.Errorf("%w: %s orders require %s", ErrInvalidOrder, r.Type, param)
+	}
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	if r.Side != SideBuy && r.Side != SideSell {
+		return fmt.Errorf("%w: unsupported side %q", ErrInvalidOrder, string(r.Side))
+	}
+	if r.Type == OrderTypeMarket {
+		if (r.Quantity == "") == (r.QuoteOrderQty == "") {
+			return fmt.Errorf("%w: MARKET orders require either quantity or quoteOrderQty", ErrInvalidOrder)
+		}
+		return nil
+	}
+	if r.Quantity == "" {
+		return missing("quantity")
+	}
+
+	switch r.Type {
+	case OrderTypeLimit, OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		if r.TimeInForce == "" {
+			return missing("timeInForce")
+		}
+		if r.Price == "" {
+			return missing("price")
+		}
+	case OrderTypeLimitMaker:
+		if r.Price == "" {
+			return missing("price")
+		}
+	case OrderTypeStopLoss, OrderTypeTakeProfit:
+	default:
+		return fmt.Errorf("%w: unsupported order type %q", ErrInvalidOrder, string(r.Type))
+	}
+	if isStop(r.Type) && r.StopPrice == "" && r.TrailingDelta == 0 {
+		return missing("stopPrice or trailingDelta")
+	}
+	return nil
+}
+
+// isStop reports whether orders of type t are triggered by a stop price.
+func isStop(t OrderType) bool {
+	switch t {
+	case OrderTypeStopLoss, OrderTypeStopLossLimit, OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		return true
+	default:
+		return false
+	}
+}
+
+// values returns the request parameters of r.
+func (r *NewOrderRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	v.Set("side", string(r.Side))
+	v.Set("type", string(r.Type))
+	setIf(v, "timeInForce", string(r.TimeInForce))
+	setIf(v, "quantity", r.Quantity)
+	setIf(v, "quoteOrderQty", r.QuoteOrderQty)
+	setIf(v, "price", r.Price)
+	setIf(v, "newClientOrderId", r.NewClientOrderID)
+	setIf(v, "stopPrice", r.StopPrice)
+	setInt64(v, "trailingDelta", r.TrailingDelta)
+	setIf(v, "icebergQty", r.IcebergQty)
+	setIf(v, "newOrderRespType", string(r.NewOrderRespType))
+	setIf(v, "selfTradePreventionMode", string(r.SelfTradePreventionMode))
+	setInt64(v, "strategyId", r.StrategyID)
+	setInt64(v, "strategyType", r.StrategyType)
+	return v
+}
+
+// OrderQuery identifies an order by OrderID or OrigClientOrderID.
+type OrderQuery struct {
+	Symbol            string
+	OrderID           int64
+	OrigClientOrderID string
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if q identifies no order.
+func (q OrderQuery) Validate() error {
+	if q.Symbol == "" {
+		return fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	if q.OrderID == 0 && q.OrigClientOrderID == "" {
+		return fmt.Errorf("%w: orderId or origClientOrderId is required", ErrInvalidOrder)
+	}
+	return nil
+}
+
+func (q OrderQuery) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", q.Symbol)
+	setInt64(v, "orderId", q.OrderID)
+	setIf(v, "origClientOrderId", q.OrigClientOrderID)
+	return v
+}
+
+// CancelReplaceRequest cancels the order identified by CancelOrderID or
+// CancelOrigClientOrderID and places Order, which must be of the same symbol.
+type CancelReplaceRequest struct {
+	Order                   NewOrderRequest
+	Mode                    CancelReplaceMode
+	CancelOrderID           int64
+	CancelOrigClientOrderID string
+	CancelNewClientOrderID  string // CancelNewClientOrderID identifies the cancellation.
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if r is incomplete.
+func (r *CancelReplaceRequest) Validate() error {
+	if err := r.Order.Validate(); err != nil {
+		return err
+	}
+	if r.Mode != CancelReplaceStopOnFailure && r.Mode != CancelReplaceAllowFailure {
+		return fmt.Errorf("%w: unsupported cancel-replace mode %q", ErrInvalidOrder, string(r.Mode))
+	}
+	if r.CancelOrderID == 0 && r.CancelOrigClientOrderID == "" {
+		return fmt.Errorf("%w: cancelOrderId or cancelOrigClientOrderId is required", ErrInvalidOrder)
+	}
+	return nil
+}
+
+func (r *CancelReplaceRequest) values() url.Values {
+	v := r.Order.values()
+	v.Set("cancelReplaceMode", string(r.Mode))
+	setInt64(v, "cancelOrderId", r.CancelOrderID)
+	setIf(v, "cancelOrigClientOrderId", r.CancelOrigClientOrderID)
+	setIf(v, "cancelNewClientOrderId", r.CancelNewClientOrderID)
+	return v
+}
+
+// CancelReplaceResult is the outcome of a cancel-replace request.
+type CancelReplaceResult struct {
+	CancelResult     string `json:"cancelResult"`   // CancelResult is SUCCESS, FAILURE or NOT_ATTEMPTED.
+	NewOrderResult   string `json:"newOrderResult"` // NewOrderResult is SUCCESS, FAILURE or NOT_ATTEMPTED.
+	CancelResponse   *Order `json:"cancelResponse"`
+	NewOrderResponse *Order `json:"newOrderResponse"`
+}
+
+// AllOrdersRequest filters the orders returned by AllOrders. Orders are
+// returned from OrderID on if it is set, otherwise the most recent ones.
+type AllOrdersRequest struct {
+	Symbol    string
+	OrderID   int64
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 500, with a maximum of 1000.
+}
+
+func (r *AllOrdersRequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	setInt64(v, "orderId", r.OrderID)
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt64(v, "limit", int64(r.Limit))
+	return v
+}
+
+// Fill is a trade of a new order, returned with the FULL response type.
+type Fill struct {
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	TradeID         int64  `json:"tradeId"`
+}
+
+// Order is the state of an order.
+type Order struct {
+	Symbol                  string              `json:"symbol"`
+	OrderID                 int64               `json:"orderId"`
+	OrderListID             int64               `json:"orderListId"` // OrderListID is -1 for orders outside order lists.
+	ClientOrderID           string              `json:"clientOrderId"`
+	OrigClientOrderID       string              `json:"origClientOrderId"` // OrigClientOrderID is set on cancellations.
+	TransactTime            int64               `json:"transactTime"`
+	Price                   string              `json:"price"`
+	OrigQty                 string              `json:"origQty"`
+	ExecutedQty             string              `json:"executedQty"`
+	CummulativeQuoteQty     string              `json:"cummulativeQuoteQty"`
+	OrigQuoteOrderQty       string              `json:"origQuoteOrderQty"`
+	Status                  OrderStatus         `json:"status"`
+	TimeInForce             TimeInForce         `json:"timeInForce"`
+	Type                    OrderType           `json:"type"`
+	Side                    Side                `json:"side"`
+	StopPrice               string              `json:"stopPrice"`
+	TrailingDelta           int64               `json:"trailingDelta"`
+	IcebergQty              string              `json:"icebergQty"`
+	Time                    int64               `json:"time"`
+	UpdateTime              int64               `json:"updateTime"`
+	IsWorking               bool                `json:"isWorking"`
+	WorkingTime             int64               `json:"workingTime"`
+	SelfTradePreventionMode SelfTradePrevention `json:"selfTradePreventionMode"`
+	Fills                   []Fill              `json:"fills"`
+}
+
+// ordersImpl implements Orders using Binance spot API.
+type ordersImpl struct {
+	*client.Client
+}
+
+// NewOrders creates a new Orders instance.
+func NewOrders(client *client.Client) Orders {
+	return &ordersImpl{client}
+}
+
+// signed sends a signed request with params and decodes the response into out.
+func (o *ordersImpl) signed(method, endpoint string, params url.Values, out any) error {
+	return o.Do(context.Background(), method, endpoint, params, client.SecuritySigned, out)
+}
+
+func (o *ordersImpl) NewOrder(req *NewOrderRequest) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var order Order
+	if err := o.signed(http.MethodPost, orderEndpoint, req.values(), &order); err != nil {
+		return nil, fmt.Errorf("failed to place order: %w", err)
+	}
+	return &order, nil
+}
+
+func (o *ordersImpl) TestOrder(req *NewOrderRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	if err := o.signed(http.MethodPost, testOrderEndpoint, req.values(), nil); err != nil {
+		return fmt.Errorf("failed to test order: %w", err)
+	}
+	return nil
+}
+
+func (o *ordersImpl) CancelOrder(query OrderQuery) (*Order, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	var order Order
+	if err := o.signed(http.MethodDelete, orderEndpoint, query.values(), &order); err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+	return &order, nil
+}
+
+func (o *ordersImpl) CancelOpenOrders(symbol string) ([]Order, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	// Orders of order lists are returned inside their list, and skipped here.
+	var items []struct {
+		Order
+		OrderReports []Order `json:"orderReports"`
+	}
+	if err := o.signed(http.MethodDelete, openOrdersEndpoint, url.Values{"symbol": {symbol}}, &items); err != nil {
+		return nil, fmt.Errorf("failed to cancel open orders: %w", err)
+	}
+	orders := make([]Order, 0, len(items))
+	for _, item := range items {
+		if item.OrderReports != nil {
+			orders = append(orders, item.OrderReports...)
+			continue
+		}
+		orders = append(orders, item.Order)
+	}
+	return orders, nil
+}
+
+func (o *ordersImpl) CancelReplace(req *CancelReplaceRequest) (*CancelReplaceResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var result CancelReplaceResult
+	if err := o.signed(http.MethodPost, cancelReplaceEndpoint, req.values(), &result); err != nil {
+		return nil, fmt.Errorf("failed to cancel and replace order: %w", err)
+	}
+	return &result, nil
+}
+
+func (o *ordersImpl) QueryOrder(query OrderQuery) (*Order, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	var order Order
+	if err := o.signed(http.MethodGet, orderEndpoint, query.values(), &order); err != nil {
+		return nil, fmt.Errorf("failed to query order: %w", err)
+	}
+	return &order, nil
+}
+
+func (o *ordersImpl) OpenOrders(symbol string) ([]Order, error) {
+	v := url.Values{}
+	setIf(v, "symbol", symbol)
+	var orders []Order
+	if err := o.signed(http.MethodGet, openOrdersEndpoint, v, &orders); err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+	return orders, nil
+}
+
+func (o *ordersImpl) AllOrders(req *AllOrdersRequest) ([]Order, error) {
+	if req.Symbol == "" {
+		return nil, fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	var orders []Order
+	if err := o.signed(http.MethodGet, allOrdersEndpoint, req.values(), &orders); err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	return orders, nil
+}
+
+// setIf sets key to value unless value is empty.
+func setIf(v url.Values, key, value string) {
+	if value != "" {
+		v.Set(key, value)
+	}
+}
+
+// setInt64 sets key to n unless n is 0.
+func setInt64(v url.Values, key string, n int64) {
+	if n != 0 {
+		v.Set(key, strconv.FormatInt(n, 10))
+	}
+}
+
+// setTime sets key to t in milliseconds unless t is zero.
+func setTime(v url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		v.Set(key, strconv.FormatInt(t.UnixMilli(), 10))
+	}
+}
@@ -0,0 +1,124 @@
+package spot
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a spot client whose requests are answered by handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+	return NewClient(clienttest.NewServerConfig(t, handler), false)
+}
+
+func TestOrders_NewOrder(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v3/order", r.URL.Path)
+		params := clienttest.SignedParams(t, r)
+		assert.Equal(t, "BTCUSDT", params.Get("symbol"))
+		assert.Equal(t, "BUY", params.Get("side"))
+		assert.Equal(t, "LIMIT", params.Get("type"))
+		assert.Equal(t, "GTC", params.Get("timeInForce"))
+		assert.Equal(t, "1", params.Get("quantity"))
+		assert.Equal(t, "100", params.Get("price"))
+		_, _ = w.Write([]byte(`{"symbol":"BTCUSDT","orderId":5,"status":"NEW","type":"LIMIT","side":"BUY"}`))
+	})
+
+	order, err := NewOrders(c).NewOrder(&NewOrderRequest{
+		Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit,
+		TimeInForce: TimeInForceGTC, Quantity: "1", Price: "100",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), order.OrderID)
+	assert.Equal(t, OrderStatusNew, order.Status)
+}
+
+func TestOrders_CancelAndQuery(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/order", r.URL.Path)
+		params := clienttest.SignedParams(t, r)
+		assert.Equal(t, "BTCUSDT", params.Get("symbol"))
+		assert.Equal(t, "5", params.Get("orderId"))
+		status := "NEW"
+		if r.Method == http.MethodDelete {
+			status = "CANCELED"
+		}
+		_, _ = w.Write([]byte(`{"symbol":"BTCUSDT","orderId":5,"status":"` + status + `"}`))
+	})
+	orders := NewOrders(c)
+	query := OrderQuery{Symbol: "BTCUSDT", OrderID: 5}
+
+	order, err := orders.QueryOrder(query)
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusNew, order.Status)
+
+	order, err = orders.CancelOrder(query)
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusCanceled, order.Status)
+}
+
+func TestOrders_NewOCO(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/orderList/oco", r.URL.Path)
+		params := clienttest.SignedParams(t, r)
+		assert.Equal(t, "SELL", params.Get("side"))
+		assert.Equal(t, "LIMIT_MAKER", params.Get("aboveType"))
+		assert.Equal(t, "110", params.Get("abovePrice"))
+		assert.Equal(t, "STOP_LOSS_LIMIT", params.Get("belowType"))
+		assert.Equal(t, "90", params.Get("belowStopPrice"))
+		assert.Equal(t, "GTC", params.Get("belowTimeInForce"))
+		_, _ = w.Write([]byte(`{"orderListId":3,"contingencyType":"OCO","listOrderStatus":"EXECUTING","symbol":"BTCUSDT",
+			"orders":[{"symbol":"BTCUSDT","orderId":1},{"symbol":"BTCUSDT","orderId":2}],
+			"orderReports":[{"orderId":1,"orderListId":3,"type":"STOP_LOSS_LIMIT"},{"orderId":2,"orderListId":3,"type":"LIMIT_MAKER"}]}`))
+	})
+	orders := NewOrders(c)
+
+	req := &OCORequest{
+		Symbol:   "BTCUSDT",
+		Side:     SideSell,
+		Quantity: "1",
+		Above:    OrderListLeg{Type: OrderTypeLimitMaker, Price: "110"},
+		Below:    OrderListLeg{Type: OrderTypeStopLossLimit, Price: "89", StopPrice: "90"},
+	}
+	_, err := orders.NewOCO(req)
+	require.ErrorIs(t, err, ErrInvalidOrder)
+
+	req.Below.TimeInForce = TimeInForceGTC
+	list, err := orders.NewOCO(req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), list.OrderListID)
+	require.Len(t, list.OrderReports, 2)
+	assert.Equal(t, OrderTypeLimitMaker, list.OrderReports[1].Type)
+	assert.Equal(t, 2, c.Limiter().Usage()[1].Used)
+}
+
+func TestOrders_CancelReplace(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/order/cancelReplace", r.URL.Path)
+		params := clienttest.SignedParams(t, r)
+		assert.Equal(t, "STOP_ON_FAILURE", params.Get("cancelReplaceMode"))
+		assert.Equal(t, "7", params.Get("cancelOrderId"))
+		assert.Equal(t, "LIMIT", params.Get("type"))
+		_, _ = w.Write([]byte(`{"cancelResult":"SUCCESS","newOrderResult":"SUCCESS",
+			"cancelResponse":{"orderId":7,"status":"CANCELED"},"newOrderResponse":{"orderId":8,"status":"NEW"}}`))
+	})
+
+	result, err := NewOrders(c).CancelReplace(&CancelReplaceRequest{
+		Order: NewOrderRequest{
+			Symbol: "BTCUSDT", Side: SideBuy, Type: OrderTypeLimit,
+			TimeInForce: TimeInForceGTC, Quantity: "1", Price: "100",
+		},
+		Mode:          CancelReplaceStopOnFailure,
+		CancelOrderID: 7,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCESS", result.CancelResult)
+	assert.Equal(t, OrderStatusCanceled, result.CancelResponse.Status)
+	assert.Equal(t, int64(8), result.NewOrderResponse.OrderID)
+}
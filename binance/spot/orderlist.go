@@ -0,0 +1,351 @@
+package spot
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Endpoints used for order lists.
+const (
+	ocoEndpoint           = "/api/v3/orderList/oco"
+	otoEndpoint           = "/api/v3/orderList/oto"
+	otocoEndpoint         = "/api/v3/orderList/otoco"
+	orderListEndpoint     = "/api/v3/orderList"
+	allOrderListEndpoint  = "/api/v3/allOrderList"
+	openOrderListEndpoint = "/api/v3/openOrderList"
+)
+
+// OrderListLeg is one order of an order list. Its side and quantity are
+// set by the list.
+type OrderListLeg struct {
+	Type          OrderType
+	ClientOrderID string
+	Price         string
+	StopPrice     string
+	TrailingDelta int64 // TrailingDelta is the trailing stop distance in basis points.
+	TimeInForce   TimeInForce
+	IcebergQty    string
+}
+
+// set sets the parameters of l, named after prefix, such as "aboveType"
+// for the prefix "above".
+func (l *OrderListLeg) set(v url.Values, prefix string) {
+	v.Set(prefix+"Type", string(l.Type))
+	setIf(v, prefix+"ClientOrderId", l.ClientOrderID)
+	setIf(v, prefix+"Price", l.Price)
+	setIf(v, prefix+"StopPrice", l.StopPrice)
+	setInt64(v, prefix+"TrailingDelta", l.TrailingDelta)
+	setIf(v, prefix+"TimeInForce", string(l.TimeInForce))
+	setIf(v, prefix+"IcebergQty", l.IcebergQty)
+}
+
+// validate returns an error wrapping ErrInvalidOrder if the leg named name
+// is missing a parameter its type requires.
+func (l *OrderListLeg) validate(name string) error {
+	if l.Type == "" {
+		return fmt.Errorf("%w: %s order requires a type", ErrInvalidOrder, name)
+	}
+	if isStop(l.Type) && l.StopPrice == "" && l.TrailingDelta == 0 {
+		return fmt.Errorf("%w: %s %s order requires stopPrice or trailingDelta", ErrInvalidOrder, name, l.Type)
+	}
+	switch l.Type {
+	case OrderTypeLimit, OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		if l.TimeInForce == "" || l.Price == "" {
+			return fmt.Errorf("%w: %s %s order requires timeInForce and price", ErrInvalidOrder, name, l.Type)
+		}
+	case OrderTypeLimitMaker:
+		if l.Price == "" {
+			return fmt.Errorf("%w: %s %s order requires price", ErrInvalidOrder, name, l.Type)
+		}
+	}
+	return nil
+}
+
+// OCORequest is a pair of orders on both sides of the price, where the
+// fill of one cancels the other. Above is a LIMIT_MAKER, STOP_LOSS,
+// STOP_LOSS_LIMIT, TAKE_PROFIT or TAKE_PROFIT_LIMIT order, as is Below.
+type OCORequest struct {
+	Symbol                  string
+	ListClientOrderID       string
+	Side                    Side
+	Quantity                string
+	Above                   OrderListLeg
+	Below                   OrderListLeg
+	NewOrderRespType        ResponseType
+	SelfTradePreventionMode SelfTradePrevention
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if r is incomplete.
+func (r *OCORequest) Validate() error {
+	if r.Symbol == "" || r.Quantity == "" {
+		return fmt.Errorf("%w: symbol and quantity are required", ErrInvalidOrder)
+	}
+	if r.Side != SideBuy && r.Side != SideSell {
+		return fmt.Errorf("%w: unsupported side %q", ErrInvalidOrder, string(r.Side))
+	}
+	if err := r.Above.validate("above"); err != nil {
+		return err
+	}
+	return r.Below.validate("below")
+}
+
+func (r *OCORequest) values() url.Values {
+	v := url.Values{}
+	v.Set("symbol", r.Symbol)
+	v.Set("side", string(r.Side))
+	v.Set("quantity", r.Quantity)
+	setIf(v, "listClientOrderId", r.ListClientOrderID)
+	r.Above.set(v, "above")
+	r.Below.set(v, "below")
+	setIf(v, "newOrderRespType", string(r.NewOrderRespType))
+	setIf(v, "selfTradePreventionMode", string(r.SelfTradePreventionMode))
+	return v
+}
+
+// OTORequest is a working order that places a pending order once it fills.
+type OTORequest struct {
+	Symbol                  string
+	ListClientOrderID       string
+	WorkingSide             Side
+	WorkingQuantity         string
+	Working                 OrderListLeg // Working is a LIMIT or LIMIT_MAKER order.
+	PendingSide             Side
+	PendingQuantity         string
+	Pending                 OrderListLeg
+	NewOrderRespType        ResponseType
+	SelfTradePreventionMode SelfTradePrevention
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if r is incomplete.
+func (r *OTORequest) Validate() error {
+	if err := validateWorking(r.Symbol, r.WorkingSide, r.WorkingQuantity, &r.Working); err != nil {
+		return err
+	}
+	if err := validatePending(r.PendingSide, r.PendingQuantity); err != nil {
+		return err
+	}
+	return r.Pending.validate("pending")
+}
+
+func (r *OTORequest) values() url.Values {
+	v := workingValues(r.Symbol, r.ListClientOrderID, r.WorkingSide, r.WorkingQuantity, &r.Working)
+	v.Set("pendingSide", string(r.PendingSide))
+	v.Set("pendingQuantity", r.PendingQuantity)
+	r.Pending.set(v, "pending")
+	setIf(v, "newOrderRespType", string(r.NewOrderRespType))
+	setIf(v, "selfTradePreventionMode", string(r.SelfTradePreventionMode))
+	return v
+}
+
+// OTOCORequest is a working order that places an OCO pair once it fills.
+// PendingBelow is optional; without it, the pending pair is a single order.
+type OTOCORequest struct {
+	Symbol                  string
+	ListClientOrderID       string
+	WorkingSide             Side
+	WorkingQuantity         string
+	Working                 OrderListLeg // Working is a LIMIT or LIMIT_MAKER order.
+	PendingSide             Side
+	PendingQuantity         string
+	PendingAbove            OrderListLeg
+	PendingBelow            *OrderListLeg
+	NewOrderRespType        ResponseType
+	SelfTradePreventionMode SelfTradePrevention
+}
+
+// Validate returns an error wrapping ErrInvalidOrder if r is incomplete.
+func (r *OTOCORequest) Validate() error {
+	if err := validateWorking(r.Symbol, r.WorkingSide, r.WorkingQuantity, &r.Working); err != nil {
+		return err
+	}
+	if err := validatePending(r.PendingSide, r.PendingQuantity); err != nil {
+		return err
+	}
+	if err := r.PendingAbove.validate("pending above"); err != nil {
+		return err
+	}
+	if r.PendingBelow != nil {
+		return r.PendingBelow.validate("pending below")
+	}
+	return nil
+}
+
+func (r *OTOCORequest) values() url.Values {
+	v := workingValues(r.Symbol, r.ListClientOrderID, r.WorkingSide, r.WorkingQuantity, &r.Working)
+	v.Set("pendingSide", string(r.PendingSide))
+	v.Set("pendingQuantity", r.PendingQuantity)
+	r.PendingAbove.set(v, "pendingAbove")
+	if r.PendingBelow != nil {
+		r.PendingBelow.set(v, "pendingBelow")
+	}
+	setIf(v, "newOrderRespType", string(r.NewOrderRespType))
+	setIf(v, "selfTradePreventionMode", string(r.SelfTradePreventionMode))
+	return v
+}
+
+// validateWorking validates the working order of an OTO or OTOCO request.
+func validateWorking(symbol string, side Side, quantity string, working *OrderListLeg) error {
+	if symbol == "" || quantity == "" {
+		return fmt.Errorf("%w: symbol and working quantity are required", ErrInvalidOrder)
+	}
+	if side != SideBuy && side != SideSell {
+		return fmt.Errorf("%w: unsupported working side %q", ErrInvalidOrder, string(side))
+	}
+	if working.Type != OrderTypeLimit && working.Type != OrderTypeLimitMaker {
+		return fmt.Errorf("%w: the working order must be LIMIT or LIMIT_MAKER", ErrInvalidOrder)
+	}
+	return working.validate("working")
+}
+
+// validatePending validates the side and quantity of the pending orders.
+func validatePending(side Side, quantity string) error {
+	if side != SideBuy && side != SideSell {
+		return fmt.Errorf("%w: unsupported pending side %q", ErrInvalidOrder, string(side))
+	}
+	if quantity == "" {
+		return fmt.Errorf("%w: pending quantity is required", ErrInvalidOrder)
+	}
+	return nil
+}
+
+// workingValues returns the parameters of the working order of an OTO or
+// OTOCO request.
+func workingValues(symbol, listClientOrderID string, side Side, quantity string, working *OrderListLeg) url.Values {
+	v := url.Values{}
+	v.Set("symbol", symbol)
+	setIf(v, "listClientOrderId", listClientOrderID)
+	v.Set("workingSide", string(side))
+	v.Set("workingQuantity", quantity)
+	working.set(v, "working")
+	return v
+}
+
+// OrderListQuery identifies an order list by OrderListID or
+// ListClientOrderID. Symbol is only needed to cancel.
+type OrderListQuery struct {
+	Symbol            string
+	OrderListID       int64
+	ListClientOrderID string
+}
+
+func (q OrderListQuery) validate() error {
+	if q.OrderListID == 0 && q.ListClientOrderID == "" {
+		return fmt.Errorf("%w: orderListId or listClientOrderId is required", ErrInvalidOrder)
+	}
+	return nil
+}
+
+// values returns the parameters of q, sending ListClientOrderID as
+// clientIDParam: queries and cancellations name it differently.
+func (q OrderListQuery) values(clientIDParam string) url.Values {
+	v := url.Values{}
+	setIf(v, "symbol", q.Symbol)
+	setInt64(v, "orderListId", q.OrderListID)
+	setIf(v, clientIDParam, q.ListClientOrderID)
+	return v
+}
+
+// AllOrderListsRequest filters the order lists returned by AllOrderLists.
+// Lists are returned from FromID on if it is set, otherwise the most recent ones.
+type AllOrderListsRequest struct {
+	FromID    int64
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int // Limit defaults to 500, with a maximum of 1000.
+}
+
+func (r *AllOrderListsRequest) values() url.Values {
+	v := url.Values{}
+	setInt64(v, "fromId", r.FromID)
+	setTime(v, "startTime", r.StartTime)
+	setTime(v, "endTime", r.EndTime)
+	setInt64(v, "limit", int64(r.Limit))
+	return v
+}
+
+// OrderListOrder identifies an order of an order list.
+type OrderListOrder struct {
+	Symbol        string `json:"symbol"`
+	OrderID       int64  `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+}
+
+// OrderList is the state of an order list.
+type OrderList struct {
+	OrderListID       int64            `json:"orderListId"`
+	ContingencyType   string           `json:"contingencyType"` // ContingencyType is OCO or OTO.
+	ListStatusType    string           `json:"listStatusType"`  // ListStatusType is RESPONSE, EXEC_STARTED, UPDATED or ALL_DONE.
+	ListOrderStatus   string           `json:"listOrderStatus"` // ListOrderStatus is EXECUTING, ALL_DONE or REJECT.
+	ListClientOrderID string           `json:"listClientOrderId"`
+	TransactionTime   int64            `json:"transactionTime"`
+	Symbol            string           `json:"symbol"`
+	Orders            []OrderListOrder `json:"orders"`
+	// OrderReports are the orders of a new or cancelled list.
+	OrderReports []Order `json:"orderReports"`
+}
+
+func (o *ordersImpl) NewOCO(req *OCORequest) (*OrderList, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return o.orderList(http.MethodPost, ocoEndpoint, req.values(), "failed to place OCO order list")
+}
+
+func (o *ordersImpl) NewOTO(req *OTORequest) (*OrderList, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return o.orderList(http.MethodPost, otoEndpoint, req.values(), "failed to place OTO order list")
+}
+
+func (o *ordersImpl) NewOTOCO(req *OTOCORequest) (*OrderList, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return o.orderList(http.MethodPost, otocoEndpoint, req.values(), "failed to place OTOCO order list")
+}
+
+func (o *ordersImpl) CancelOrderList(query OrderListQuery) (*OrderList, error) {
+	if query.Symbol == "" {
+		return nil, fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	}
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+	return o.orderList(http.MethodDelete, orderListEndpoint, query.values("listClientOrderId"), "failed to cancel order list")
+}
+
+func (o *ordersImpl) QueryOrderList(query OrderListQuery) (*OrderList, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+	query.Symbol = ""
+	return o.orderList(http.MethodGet, orderListEndpoint, query.values("origClientOrderId"), "failed to query order list")
+}
+
+func (o *ordersImpl) AllOrderLists(req *AllOrderListsRequest) ([]OrderList, error) {
+	var lists []OrderList
+	if err := o.signed(http.MethodGet, allOrderListEndpoint, req.values(), &lists); err != nil {
+		return nil, fmt.Errorf("failed to get order lists: %w", err)
+	}
+	return lists, nil
+}
+
+func (o *ordersImpl) OpenOrderLists() ([]OrderList, error) {
+	var lists []OrderList
+	if err := o.signed(http.MethodGet, openOrderListEndpoint, nil, &lists); err != nil {
+		return nil, fmt.Errorf("failed to get open order lists: %w", err)
+	}
+	return lists, nil
+}
+
+// orderList sends an order list request and decodes the list it returns.
+func (o *ordersImpl) orderList(method, endpoint string, params url.Values, failure string) (*OrderList, error) {
+	var list OrderList
+	if err := o.signed(method, endpoint, params, &list); err != nil {
+		return nil, fmt.Errorf("%s: %w", failure, err)
+	}
+	return &list, nil
+}
@@ -0,0 +1,96 @@
+// Package spot is the Binance spot API. It shares the signing and rate
+// limiting of the futures client.
+package spot
+
+import (
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/spot/market"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/spot/ws"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+)
+
+// Base URLs of the Binance spot API.
+const (
+	ProductionBaseURL = "https://api.binance.com"
+	TestnetBaseURL    = "https://testnet.binance.vision"
+	ProductionWSURL   = "wss://stream.binance.com:9443"
+	TestnetWSURL      = "wss://stream.testnet.binance.vision"
+)
+
+const serverTimeEndpoint = "/api/v3/time"
+
+type Spot interface {
+	Market() market.Market
+	Account() Account
+	Orders() Orders
+	// UserData returns a new user data stream of the account, on a
+	// connection of its own.
+	UserData() *ws.UserDataStream
+}
+
+type spotImpl struct {
+	client *client.Client
+}
+
+// NewClient returns a client for the spot API, configured like config
+// except for the spot URLs, weights and rate limits, which are filled in
+// when they are not set.
+func NewClient(config client.Config, isTestnet bool) *client.Client {
+	if config.BaseURL == "" {
+		config.BaseURL = ProductionBaseURL
+		if isTestnet {
+			config.BaseURL = TestnetBaseURL
+		}
+	}
+	if config.WSBaseURL == "" {
+		config.WSBaseURL = ProductionWSURL
+		if isTestnet {
+			config.WSBaseURL = TestnetWSURL
+		}
+	}
+	if config.TimeEndpoint == "" {
+		config.TimeEndpoint = serverTimeEndpoint
+	}
+	if config.RequestWeight == nil {
+		config.RequestWeight = RequestWeight
+	}
+	if config.Limiter == nil {
+		config.Limiter = client.NewLimiter(DefaultRateLimits...)
+	}
+	return client.NewClient(config)
+}
+
+func New(apiKey, apiSecret string, isTestnet bool) Spot {
+	return &spotImpl{
+		client: NewClient(client.Config{APIKey: apiKey, APISecret: apiSecret}, isTestnet),
+	}
+}
+
+// NewWithProvider creates the spot API with credentials read from p
+// before every signed request.
+func NewWithProvider(p credentials.Provider, isTestnet bool) Spot {
+	return &spotImpl{
+		client: NewClient(client.Config{Credentials: p}, isTestnet),
+	}
+}
+
+// NewWithClient creates the spot API on c, for clients built with NewClient.
+func NewWithClient(c *client.Client) Spot {
+	return &spotImpl{client: c}
+}
+
+func (s *spotImpl) Market() market.Market {
+	return market.NewMarket(s.client)
+}
+
+func (s *spotImpl) Account() Account {
+	return NewAccount(s.client)
+}
+
+func (s *spotImpl) Orders() Orders {
+	return NewOrders(s.client)
+}
+
+func (s *spotImpl) UserData() *ws.UserDataStream {
+	return ws.NewUserDataStream(s.client)
+}
@@ -0,0 +1,132 @@
+package spot
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+)
+
+// DefaultRateLimits are the spot limits in force when exchange info has
+// not been loaded.
+var DefaultRateLimits = []client.RateLimit{
+	{Type: client.RateLimitRequestWeight, Interval: time.Minute, Limit: 6000},
+	{Type: client.RateLimitOrders, Interval: 10 * time.Second, Limit: 100},
+	{Type: client.RateLimitOrders, Interval: 24 * time.Hour, Limit: 200000},
+}
+
+// endpointWeights are the weights of the spot endpoints that do not weigh
+// 1, keyed by method and path. Weights depending on the parameters are
+// computed by RequestWeight.
+var endpointWeights = map[string]int{
+	"GET /api/v3/exchangeInfo":       20,
+	"GET /api/v3/trades":             25,
+	"GET /api/v3/historicalTrades":   25,
+	"GET /api/v3/aggTrades":          4,
+	"GET /api/v3/klines":             2,
+	"GET /api/v3/uiKlines":           2,
+	"GET /api/v3/avgPrice":           2,
+	"GET /api/v3/order":              4,
+	"GET /api/v3/allOrders":          20,
+	"GET /api/v3/orderList":          4,
+	"GET /api/v3/allOrderList":       20,
+	"GET /api/v3/openOrderList":      6,
+	"GET /api/v3/account":            20,
+	"GET /api/v3/myTrades":           20,
+	"GET /api/v3/rateLimit/order":    40,
+	"GET /api/v3/account/commission": 20,
+	"POST /api/v3/userDataStream":    2,
+	"PUT /api/v3/userDataStream":     2,
+	"DELETE /api/v3/userDataStream":  2,
+}
+
+// symbolWeights are the weights of the endpoints that weigh more when
+// queried for every symbol: the weight with a symbol, then without.
+var symbolWeights = map[string][2]int{
+	"GET /api/v3/ticker/price":      {2, 4},
+	"GET /api/v3/ticker/bookTicker": {2, 4},
+	"GET /api/v3/openOrders":        {6, 80},
+}
+
+// orderCounts are the numbers of orders placed by the order endpoints.
+var orderCounts = map[string]int{
+	"POST /api/v3/order":               1,
+	"POST /api/v3/order/cancelReplace": 1,
+	"POST /api/v3/orderList/oco":       2,
+	"POST /api/v3/orderList/oto":       2,
+	"POST /api/v3/orderList/otoco":     3,
+}
+
+// RequestWeight returns the weight of a spot request and the number of
+// orders it places.
+func RequestWeight(method, path string, params url.Values) (weight, orders int) {
+	key := method + " " + path
+	orders = orderCounts[key]
+	switch {
+	case key == "GET /api/v3/depth":
+		return depthWeight(params.Get("limit")), orders
+	case key == "GET /api/v3/ticker/24hr":
+		return tickerWeight(params), orders
+	case key == "GET /api/v3/ticker":
+		// The rolling window ticker weighs 4 per symbol, up to 200.
+		return min(4*symbolCount(params), 200), orders
+	case key == "POST /api/v3/order/test" && params.Get("computeCommissionRates") == "true":
+		return 20, orders
+	}
+	if w, ok := endpointWeights[key]; ok {
+		return w, orders
+	}
+	if w, ok := symbolWeights[key]; ok {
+		if params.Get("symbol") != "" {
+			return w[0], orders
+		}
+		return w[1], orders
+	}
+	return 1, orders
+}
+
+// depthWeight returns the weight of an order book request of limit levels.
+func depthWeight(limit string) int {
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		n = 100 // 100 is the default limit.
+	}
+	switch {
+	case n <= 100:
+		return 5
+	case n <= 500:
+		return 25
+	case n <= 1000:
+		return 50
+	default:
+		return 250
+	}
+}
+
+// tickerWeight returns the weight of a 24 hour ticker request, which
+// depends on the number of symbols it is for.
+func tickerWeight(params url.Values) int {
+	switch n := symbolCount(params); {
+	case n == 0 || n > 100:
+		return 80
+	case n <= 20:
+		return 2
+	default:
+		return 40
+	}
+}
+
+// symbolCount returns the number of symbols a request is for: 1 for the
+// symbol parameter, or the length of the JSON list of the symbols one.
+func symbolCount(params url.Values) int {
+	if params.Get("symbol") != "" {
+		return 1
+	}
+	list := strings.Trim(params.Get("symbols"), "[]")
+	if list == "" {
+		return 0
+	}
+	return strings.Count(list, ",") + 1
+}
@@ -0,0 +1,227 @@
+// Package ws is the user data stream of the Binance spot API. It runs on
+// the user data stream of the futures API, with the spot listen keys and
+// events.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	futuresws "github.com/cploutarchou/crypto-sdk-suite/binance/futures/ws"
+)
+
+// DefaultKeepAliveInterval is how often listen keys are kept alive by
+// default. Binance expires them after 60 minutes without a keepalive.
+const DefaultKeepAliveInterval = futuresws.DefaultKeepAliveInterval
+
+// Event types of the user data stream.
+const (
+	EventExecutionReport         = "executionReport"
+	EventOutboundAccountPosition = "outboundAccountPosition"
+	EventBalanceUpdate           = "balanceUpdate"
+	EventListStatus              = "listStatus"
+	EventListenKeyExpired        = futuresws.EventListenKeyExpired
+)
+
+// listenKeys are the spot listen keys, which are named when they are kept
+// alive or closed.
+var listenKeys = futuresws.ListenKeys{Endpoint: "/api/v3/userDataStream", KeyParam: true}
+
+// CreateListenKey creates a listen key, or returns the active one and
+// extends its validity.
+func CreateListenKey(ctx context.Context, c *client.Client) (string, error) {
+	return listenKeys.Create(ctx, c)
+}
+
+// KeepAliveListenKey extends the validity of listenKey by 60 minutes.
+func KeepAliveListenKey(ctx context.Context, c *client.Client, listenKey string) error {
+	return listenKeys.KeepAlive(ctx, c, listenKey)
+}
+
+// CloseListenKey closes listenKey, ending its stream.
+func CloseListenKey(ctx context.Context, c *client.Client, listenKey string) error {
+	return listenKeys.Close(ctx, c, listenKey)
+}
+
+// Event fields are named after Binance's one-letter keys. Since
+// encoding/json matches keys case-insensitively, every event declares both
+// cases of the keys Binance sends in both, such as "e" and "E".
+
+// ExecutionReportEvent is a change of an order, such as a new order, a
+// fill or a cancellation.
+type ExecutionReportEvent struct {
+	EventType         string `json:"e"`
+	EventTime         int64  `json:"E"`
+	Symbol            string `json:"s"`
+	ClientOrderID     string `json:"c"`
+	Side              string `json:"S"`
+	OrderType         string `json:"o"`
+	TimeInForce       string `json:"f"`
+	Quantity          string `json:"q"`
+	Price             string `json:"p"`
+	StopPrice         string `json:"P"`
+	IcebergQty        string `json:"F"`
+	OrderListID       int64  `json:"g"`
+	OrigClientOrderID string `json:"C"` // OrigClientOrderID is the ID of the cancelled order.
+	ExecutionType     string `json:"x"` // ExecutionType is NEW, CANCELED, REPLACED, REJECTED, TRADE, EXPIRED or TRADE_PREVENTION.
+	Status            string `json:"X"`
+	RejectReason      string `json:"r"`
+	OrderID           int64  `json:"i"`
+	LastFilledQty     string `json:"l"`
+	FilledQty         string `json:"z"`
+	LastFilledPrice   string `json:"L"`
+	Commission        string `json:"n"`
+	CommissionAsset   string `json:"N"`
+	TransactionTime   int64  `json:"T"`
+	TradeID           int64  `json:"t"`
+	// Ignore and IgnoreM hold keys Binance marks as unused, which would
+	// otherwise be decoded into OrderID and IsMaker.
+	Ignore                  int64  `json:"I"`
+	IsWorking               bool   `json:"w"`
+	IsMaker                 bool   `json:"m"`
+	IgnoreM                 bool   `json:"M"`
+	CreationTime            int64  `json:"O"`
+	FilledQuoteQty          string `json:"Z"`
+	LastQuoteQty            string `json:"Y"`
+	QuoteOrderQty           string `json:"Q"`
+	WorkingTime             int64  `json:"W"`
+	SelfTradePreventionMode string `json:"V"`
+	PreventedMatchID        int64  `json:"v"`
+	TrailingDelta           int64  `json:"d"`
+	TrailingTime            int64  `json:"D"`
+	StrategyID              int64  `json:"j"`
+	StrategyType            int64  `json:"J"`
+	TradeGroupID            int64  `json:"u"`
+	CounterOrderID          int64  `json:"U"`
+	PreventedQty            string `json:"A"`
+	LastPreventedQty        string `json:"B"`
+}
+
+// OutboundAccountPositionEvent lists the balances that changed.
+type OutboundAccountPositionEvent struct {
+	EventType      string           `json:"e"`
+	EventTime      int64            `json:"E"`
+	LastUpdateTime int64            `json:"u"`
+	Balances       []AccountBalance `json:"B"`
+}
+
+// AccountBalance is a balance of an OutboundAccountPositionEvent.
+type AccountBalance struct {
+	Asset  string `json:"a"`
+	Free   string `json:"f"`
+	Locked string `json:"l"`
+}
+
+// BalanceUpdateEvent is a deposit, withdrawal or transfer.
+type BalanceUpdateEvent struct {
+	EventType string `json:"e"`
+	EventTime int64  `json:"E"`
+	Asset     string `json:"a"`
+	Delta     string `json:"d"`
+	ClearTime int64  `json:"T"`
+}
+
+// ListStatusEvent is a change of an order list.
+type ListStatusEvent struct {
+	EventType         string            `json:"e"`
+	EventTime         int64             `json:"E"`
+	Symbol            string            `json:"s"`
+	OrderListID       int64             `json:"g"`
+	ContingencyType   string            `json:"c"`
+	ListStatusType    string            `json:"l"`
+	ListOrderStatus   string            `json:"L"`
+	RejectReason      string            `json:"r"`
+	ListClientOrderID string            `json:"C"`
+	TransactionTime   int64             `json:"T"`
+	Orders            []ListStatusOrder `json:"O"`
+}
+
+// ListStatusOrder identifies an order of a ListStatusEvent.
+type ListStatusOrder struct {
+	Symbol        string `json:"s"`
+	OrderID       int64  `json:"i"`
+	ClientOrderID string `json:"c"`
+}
+
+// ListenKeyExpiredEvent reports that the listen key of the stream expired.
+// The stream renews it on its own.
+type ListenKeyExpiredEvent = futuresws.ListenKeyExpiredEvent
+
+// UserDataStream is the stream of spot account updates. It is the user data
+// stream of futures, which creates the listen key, keeps it alive and renews
+// it when it expires, delivering the spot events.
+type UserDataStream struct {
+	// KeepAliveInterval is how often the listen key is kept alive; 0 uses
+	// DefaultKeepAliveInterval. It must be set before Start.
+	KeepAliveInterval time.Duration
+
+	stream *futuresws.UserDataStream
+}
+
+// NewUserDataStream creates the user data stream of the account of c.
+func NewUserDataStream(c *client.Client) *UserDataStream {
+	return &UserDataStream{stream: futuresws.NewUserDataStreamWithListenKeys(c, listenKeys)}
+}
+
+// Client returns the WebSocket client of the stream, for example to set its
+// OnEvent and OnError callbacks before Start.
+func (u *UserDataStream) Client() *futuresws.Client {
+	return u.stream.Client()
+}
+
+// OnExecutionReport sets the callback of order updates. Callbacks must be
+// set before Start.
+func (u *UserDataStream) OnExecutionReport(callback func(ExecutionReportEvent)) {
+	u.stream.HandleEvent(EventExecutionReport, decoder(callback))
+}
+
+// OnAccountPosition sets the callback of balance changes.
+func (u *UserDataStream) OnAccountPosition(callback func(OutboundAccountPositionEvent)) {
+	u.stream.HandleEvent(EventOutboundAccountPosition, decoder(callback))
+}
+
+// OnBalanceUpdate sets the callback of deposits, withdrawals and transfers.
+func (u *UserDataStream) OnBalanceUpdate(callback func(BalanceUpdateEvent)) {
+	u.stream.HandleEvent(EventBalanceUpdate, decoder(callback))
+}
+
+// OnListStatus sets the callback of order list updates.
+func (u *UserDataStream) OnListStatus(callback func(ListStatusEvent)) {
+	u.stream.HandleEvent(EventListStatus, decoder(callback))
+}
+
+// OnListenKeyExpired sets the callback of listen key expirations.
+func (u *UserDataStream) OnListenKeyExpired(callback func(ListenKeyExpiredEvent)) {
+	u.stream.OnListenKeyExpired(callback)
+}
+
+// ListenKey returns the listen key the stream is subscribed with.
+func (u *UserDataStream) ListenKey() string {
+	return u.stream.ListenKey()
+}
+
+// Start creates a listen key and subscribes to its stream. The stream is
+// closed when ctx is cancelled or Close is called, whichever happens first.
+func (u *UserDataStream) Start(ctx context.Context) error {
+	u.stream.KeepAliveInterval = u.KeepAliveInterval
+	return u.stream.Start(ctx)
+}
+
+// Close stops keeping the listen key alive, closes it and closes the connection.
+func (u *UserDataStream) Close() {
+	u.stream.Close()
+}
+
+// decoder returns a handler decoding events into T for callback.
+func decoder[T any](callback func(T)) func(json.RawMessage) error {
+	return func(data json.RawMessage) error {
+		var event T
+		if err := json.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		callback(event)
+		return nil
+	}
+}
@@ -12,6 +12,7 @@ type Account interface {
 	Info() *Info
 	TransactionLog() *TransactionLog
 	Margin() *Margin
+	APIKey() *APIKey
 }
 
 type account struct {
@@ -51,6 +52,9 @@ func (a *account) TransactionLog() *TransactionLog {
 func (a *account) Margin() *Margin {
 	return NewMargin(a.client)
 }
+func (a *account) APIKey() *APIKey {
+	return NewAPIKey(a.client)
+}
 func New(client_ *client.Client) Account {
 	return &account{client: client_}
 }
@@ -0,0 +1,107 @@
+package account
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+const APIKeyInfoEndpoint = "/v5/user/query-api"
+
+// DefaultExpiryWarning is how long before the API key expires CheckExpiry
+// starts warning when no window is given.
+const DefaultExpiryWarning = 7 * 24 * time.Hour
+
+type APIKey struct {
+	client *client.Client
+}
+
+func NewAPIKey(client_ *client.Client) *APIKey {
+	return &APIKey{client: client_}
+}
+
+type APIKeyInfoResponse struct {
+	BaseResponse
+	Result APIKeyInfo `json:"result"`
+}
+
+type APIKeyInfo struct {
+	ID          string              `json:"id"`
+	Note        string              `json:"note"`
+	APIKey      string              `json:"apiKey"`
+	ReadOnly    int                 `json:"readOnly"`
+	Secret      string              `json:"secret"`
+	Permissions map[string][]string `json:"permissions"`
+	IPs         []string            `json:"ips"`
+	Type        int                 `json:"type"`
+	DeadlineDay int                 `json:"deadlineDay"`
+	ExpiredAt   string              `json:"expiredAt"` // ExpiredAt is empty for keys bound to IP addresses, which do not expire.
+	CreatedAt   string              `json:"createdAt"`
+	Unified     int                 `json:"unified"`
+	Uta         int                 `json:"uta"`
+	UserID      int64               `json:"userID"`
+	InviterID   int64               `json:"inviterID"`
+	VipLevel    string              `json:"vipLevel"`
+	MktMakerLvl string              `json:"mktMakerLevel"`
+	AffiliateID int64               `json:"affiliateID"`
+	IsMaster    bool                `json:"isMaster"`
+}
+
+// Expiry returns when the key expires, or the zero time if it does not.
+func (i APIKeyInfo) Expiry() (time.Time, error) {
+	if i.ExpiredAt == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, i.ExpiredAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse expiry: %w", err)
+	}
+	return t, nil
+}
+
+// Info returns the details of the API key the client signs requests with.
+func (k *APIKey) Info() (*APIKeyInfoResponse, error) {
+	response, err := k.client.Get(APIKeyInfoEndpoint, client.Params{})
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", response.StatusCode())
+	}
+
+	var infoResponse APIKeyInfoResponse
+	if err := response.Unmarshal(&infoResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if infoResponse.RetCode != 0 {
+		return nil, fmt.Errorf("failed to query API key: %s", infoResponse.RetMsg)
+	}
+	return &infoResponse, nil
+}
+
+// CheckExpiry returns when the API key expires, or the zero time if it does
+// not, and whether it expires within the given window. A window of 0 means
+// DefaultExpiryWarning. Keys expiring soon are also warned about through the
+// client's logger, which discards them unless one was set.
+func (k *APIKey) CheckExpiry(within time.Duration) (expiry time.Time, expiresSoon bool, err error) {
+	if within == 0 {
+		within = DefaultExpiryWarning
+	}
+	info, err := k.Info()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	expiry, err = info.Result.Expiry()
+	if err != nil || expiry.IsZero() {
+		return expiry, false, err
+	}
+	left := time.Until(expiry)
+	if left >= within {
+		return expiry, false, nil
+	}
+	k.client.Logger().Warn("API key expires soon", "id", info.Result.ID, "expiredAt", expiry, "left", left.Round(time.Minute))
+	return expiry, true, nil
+}
@@ -17,7 +17,7 @@ func NewFeeRates(client_ *client.Client) *FeeRates {
 	return &FeeRates{client: client_}
 }
 
-func (fr *FeeRates) GetFeeRate(category string, symbol, baseCoin string) (*FeeRatesResponse, error) {
+func (fr *FeeRates) GetFeeRate(category client.Category, symbol, baseCoin string) (*FeeRatesResponse, error) {
 	// Construct parameters
 	params := client.Params{
 		"category": category,
@@ -1,6 +1,8 @@
 package bybit
 
 import (
+	"fmt"
+
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/account"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/asset"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
@@ -9,6 +11,8 @@ import (
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/trade"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws"
 	wsCli "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+	"github.com/cploutarchou/crypto-sdk-suite/logger"
 )
 
 type Bybit interface {
@@ -18,6 +22,9 @@ type Bybit interface {
 	Trade() trade.Trade
 	Position() position.Position
 	Asset() asset.Asset
+	// SetLogger routes the logs of the REST client and every WebSocket
+	// connection to l. Credentials are redacted.
+	SetLogger(l logger.Interface)
 }
 
 type bybitImpl struct {
@@ -34,18 +41,54 @@ type bybitImpl struct {
 	webSocket  ws.WebSocket
 }
 
-func New(key, secretKey string, isTestNet bool, category string) Bybit {
-	c := client.NewClient(key, secretKey, isTestNet)
-	privateClient, err := wsCli.NewPrivateClient(key, secretKey, isTestNet, "", category)
+// New creates the Bybit API for mainnet or testnet. See NewWithEnvironment
+// for the demo trading and regional hosts.
+func New(key, secretKey string, isTestNet bool, category client.Category) Bybit {
+	return NewWithEnvironment(key, secretKey, client.EnvironmentFor(isTestNet), category)
+}
+
+// NewWithEnvironment creates the Bybit API for the hosts of env. The REST
+// client and every WebSocket stream, private ones included, use env.
+func NewWithEnvironment(key, secretKey string, env client.Environment, category client.Category) Bybit {
+	by, err := newBybit(credentials.Static(key, secretKey), env, category)
 	if err != nil {
 		panic(err)
 	}
+	return by
+}
+
+// NewWithProvider is like NewWithEnvironment with credentials read from p
+// before every REST request and WebSocket authentication, so keys can be
+// rotated without recreating the client.
+func NewWithProvider(p credentials.Provider, env client.Environment, category client.Category) (Bybit, error) {
+	by, err := newBybit(p, env, category)
+	if err != nil {
+		return nil, err
+	}
+	return by, nil
+}
+
+// newBybit creates the Bybit API for the hosts of env, with credentials read
+// from p.
+func newBybit(p credentials.Provider, env client.Environment, category client.Category) (*bybitImpl, error) {
+	creds, err := p.Credentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	c := client.NewClientWithEnvironment(creds.APIKey, creds.APISecret, env)
+	c.SetCredentials(p)
+	isTestNet := env == client.Testnet
+	privateClient, err := wsCli.NewPrivateClient(creds.APIKey, creds.APISecret, isTestNet, "", category)
+	if err != nil {
+		return nil, err
+	}
+	privateClient.Credentials = p
 	publicClient, err := wsCli.NewPublicClient(isTestNet, category)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	by := &bybitImpl{
+	return &bybitImpl{
 		market:    market.New(c),
 		account:   account.New(c),
 		trade:     trade.New(c),
@@ -53,11 +96,10 @@ func New(key, secretKey string, isTestNet bool, category string) Bybit {
 		asset:     asset.New(c),
 		client:    c,
 		isTestNet: isTestNet,
-		apiKey:    key,
-		secretKey: secretKey,
-		webSocket: ws.New(publicClient, privateClient, isTestNet),
-	}
-	return by
+		apiKey:    creds.APIKey,
+		secretKey: creds.APISecret,
+		webSocket: ws.NewWithEnvironment(publicClient, privateClient, env),
+	}, nil
 }
 
 // Market returns the market interface for Bybit operations.
@@ -107,3 +149,9 @@ func (b *bybitImpl) Position() position.Position {
 func (b *bybitImpl) Asset() asset.Asset {
 	return b.asset
 }
+
+// SetLogger routes the logs of the REST client and every WebSocket connection to l.
+func (b *bybitImpl) SetLogger(l logger.Interface) {
+	b.client.SetLogger(l)
+	b.webSocket.SetLogger(l)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Category is the product type of a Bybit v5 request or stream.
+type Category string
+
+const (
+	CategorySpot    Category = "spot"
+	CategoryLinear  Category = "linear"  // USDT and USDC perpetuals and futures
+	CategoryInverse Category = "inverse" // Inverse perpetuals and futures
+	CategoryOption  Category = "option"  // USDC options
+)
+
+// categoryAliases maps the category names used by earlier versions of the
+// WebSocket client onto the v5 categories.
+var categoryAliases = map[string]Category{
+	"usdt_contract":    CategoryLinear,
+	"usdc_contract":    CategoryLinear,
+	"inverse_contract": CategoryInverse,
+	"usdc_option":      CategoryOption,
+}
+
+// Categories returns every supported category.
+func Categories() []Category {
+	return []Category{CategorySpot, CategoryLinear, CategoryInverse, CategoryOption}
+}
+
+// ParseCategory returns the category named s. It also accepts the legacy
+// WebSocket names such as "usdt_contract".
+func ParseCategory(s string) (Category, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if c, ok := categoryAliases[name]; ok {
+		return c, nil
+	}
+	c := Category(name)
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	return c, nil
+}
+
+// Validate returns an error if c is not a supported category.
+func (c Category) Validate() error {
+	switch c {
+	case CategorySpot, CategoryLinear, CategoryInverse, CategoryOption:
+		return nil
+	default:
+		return fmt.Errorf("invalid category %q: must be one of spot, linear, inverse or option", string(c))
+	}
+}
+
+// String returns the category as sent to the API.
+func (c Category) String() string {
+	return string(c)
+}
+
+// StreamPath returns the path of the public WebSocket stream serving c,
+// relative to /v5/public/.
+func (c Category) StreamPath() (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	// The public streams are named after the categories they serve.
+	return string(c), nil
+}
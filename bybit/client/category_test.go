@@ -0,0 +1,44 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCategory(t *testing.T) {
+	for input, want := range map[string]Category{
+		"spot":             CategorySpot,
+		"Linear":           CategoryLinear,
+		"usdt_contract":    CategoryLinear,
+		"usdc_contract":    CategoryLinear,
+		"inverse_contract": CategoryInverse,
+		"usdc_option":      CategoryOption,
+	} {
+		got, err := ParseCategory(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseCategory("taker")
+	assert.Error(t, err)
+}
+
+func TestCategory_StreamPath(t *testing.T) {
+	for _, c := range Categories() {
+		path, err := c.StreamPath()
+		require.NoError(t, err)
+		assert.Equal(t, string(c), path)
+	}
+
+	_, err := Category("usdt_contract").StreamPath()
+	assert.Error(t, err)
+}
+
+func TestValidateCategory(t *testing.T) {
+	assert.NoError(t, validateCategory(Params{"symbol": "BTCUSDT"}))
+	assert.NoError(t, validateCategory(Params{"category": CategoryLinear}))
+	assert.NoError(t, validateCategory(Params{"category": "spot"}))
+	assert.Error(t, validateCategory(Params{"category": "taker"}))
+}
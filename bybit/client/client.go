@@ -12,8 +12,11 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+	"github.com/cploutarchou/crypto-sdk-suite/logger"
 	"golang.org/x/time/rate"
 )
 
@@ -40,13 +43,14 @@ type Requester interface {
 
 // Client struct holds information needed for API interaction
 type Client struct {
-	key             string
-	secretKey       string
+	credsMu         sync.RWMutex // credsMu guards credentials and lastCreds.
+	credentials     credentials.Provider
+	lastCreds       credentials.Credentials // lastCreds are the credentials of the last request, redacted from the logs.
 	httpClient      *http.Client
 	IsTestNet       bool
-	params          []byte
-	QueryParams     url.Values
+	Environment     Environment // Environment selects the API host; when unset, IsTestNet picks mainnet or testnet.
 	endpointLimiter *EndpointRateLimiter
+	logger          logger.Interface
 }
 
 // Define HTTP method types as strings
@@ -79,11 +83,12 @@ func (c *Client) initializeEndpointLimiters() {
 // NewClient creates a new client instance with API key, secret key, and testnet setting
 func NewClient(key, secretKey string, isTestnet bool) *Client {
 	client := &Client{
-		key:             key,
-		secretKey:       secretKey,
+		credentials:     credentials.Static(key, secretKey),
+		lastCreds:       credentials.Credentials{APIKey: key, APISecret: secretKey},
 		httpClient:      &http.Client{},
 		IsTestNet:       isTestnet,
 		endpointLimiter: NewEndpointRateLimiter(),
+		logger:          logger.Nop,
 	}
 
 	// Initialize the rate limiters for all endpoints
@@ -92,6 +97,56 @@ func NewClient(key, secretKey string, isTestnet bool) *Client {
 	return client
 }
 
+// SetCredentials sets the provider the API key and secret are read from
+// before every request, so keys can be rotated while the client is in use.
+func (c *Client) SetCredentials(p credentials.Provider) {
+	c.credsMu.Lock()
+	defer c.credsMu.Unlock()
+	c.credentials = p
+}
+
+// SetLogger sets the logger requests are reported to at DEBUG level; nil
+// discards the logs. The API key and secret are redacted from everything
+// logged. It should be called before the client is used.
+func (c *Client) SetLogger(l logger.Interface) {
+	if l == nil {
+		l = logger.Nop
+	}
+	c.logger = l
+}
+
+// log returns the client's logger with its credentials redacted.
+func (c *Client) log() logger.Interface {
+	l := c.logger
+	if l == nil {
+		l = logger.Nop
+	}
+	c.credsMu.RLock()
+	creds := c.lastCreds
+	c.credsMu.RUnlock()
+	return logger.Redact(l, creds.APIKey, creds.APISecret)
+}
+
+// Logger returns the logger of the client, with its credentials redacted.
+func (c *Client) Logger() logger.Interface {
+	return c.log()
+}
+
+// NewClientWithEnvironment creates a new client instance for the hosts of env.
+func NewClientWithEnvironment(key, secretKey string, env Environment) *Client {
+	client := NewClient(key, secretKey, env == Testnet)
+	client.Environment = env
+	return client
+}
+
+// baseURL returns the base URL of the REST API the client sends requests to.
+func (c *Client) baseURL() string {
+	if !c.Environment.IsZero() {
+		return c.Environment.RESTURL
+	}
+	return EnvironmentFor(c.IsTestNet).RESTURL
+}
+
 // Get method performs a GET request to the specified API path with params
 func (c *Client) Get(path string, params Params) (Response, error) {
 	return c.doRequest(GET, path, params)
@@ -109,6 +164,10 @@ func (c *Client) doRequest(method Method, path string, params Params) (Response,
 		return nil, fmt.Errorf("endpointLimiter is not initialized")
 	}
 
+	if err := validateCategory(params); err != nil {
+		return nil, err
+	}
+
 	// Generate the endpoint key
 	endpointKey := fmt.Sprintf("%s %s", method, path)
 
@@ -134,25 +193,42 @@ func (c *Client) doRequest(method Method, path string, params Params) (Response,
 	return c.do(req)
 }
 
-// do handles the actual execution of the HTTP request
-func (c *Client) do(req *Request) (Response, error) {
-	c.QueryParams = make(url.Values)
-	baseURL := BaseURL
-	if c.IsTestNet {
-		baseURL = TestnetBaseURL
+// validateCategory rejects requests whose category parameter is not a supported Category.
+func validateCategory(params Params) error {
+	value, ok := params["category"]
+	if !ok {
+		return nil
 	}
+	switch v := value.(type) {
+	case Category:
+		return v.Validate()
+	case *Category:
+		if v == nil {
+			return nil
+		}
+		return v.Validate()
+	default:
+		return Category(fmt.Sprintf("%v", v)).Validate()
+	}
+}
+
+// do handles the actual execution of the HTTP request. The request is signed
+// with credentials read for it alone, so concurrent requests never mix keys.
+func (c *Client) do(req *Request) (Response, error) {
+	baseURL := c.baseURL()
 
 	var (
 		httpReq *http.Request
+		payload string
 		err     error
 	)
 
 	// Prepare the GET or POST request based on the method
 	switch req.method {
 	case GET:
-		httpReq, err = c.newGETRequest(baseURL, req)
+		httpReq, payload, err = newGETRequest(baseURL, req)
 	case POST:
-		httpReq, err = c.newPOSTRequest(baseURL, req)
+		httpReq, payload, err = newPOSTRequest(baseURL, req)
 	default:
 		return nil, errors.New("unsupported method")
 	}
@@ -161,67 +237,79 @@ func (c *Client) do(req *Request) (Response, error) {
 		return nil, err
 	}
 
+	// Read the credentials again, in case they were rotated
+	c.credsMu.RLock()
+	provider := c.credentials
+	c.credsMu.RUnlock()
+	creds, err := provider.Credentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	c.credsMu.Lock()
+	c.lastCreds = creds
+	c.credsMu.Unlock()
+
 	// Set common headers for the request
-	c.setCommonHeaders(httpReq)
+	setCommonHeaders(httpReq, creds, payload)
 
 	// Execute the request
+	start := time.Now()
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
+		c.log().Error("request failed", "method", req.method, "path", req.path, "error", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
+	c.log().Debug("request sent", "method", req.method, "path", req.path, "status", resp.StatusCode, "duration", time.Since(start))
 
 	// Process and return the response
 	return NewResponse(resp), nil
 }
-func (c *Client) newGETRequest(baseURL string, req *Request) (*http.Request, error) {
-	c.QueryParams = url.Values{}
+
+// newGETRequest returns a GET request of req and its query string, which is signed.
+func newGETRequest(baseURL string, req *Request) (*http.Request, string, error) {
+	query := url.Values{}
 	for k, v := range req.params {
-		c.QueryParams.Set(k, fmt.Sprintf("%v", v))
+		query.Set(k, fmt.Sprintf("%v", v))
 	}
-
-	return http.NewRequest(string(GET), baseURL+req.path+"?"+c.QueryParams.Encode(), http.NoBody)
+	// Encode sorts the parameters alphabetically, as the signature requires.
+	payload := query.Encode()
+	httpReq, err := http.NewRequest(string(GET), baseURL+req.path+"?"+payload, http.NoBody)
+	return httpReq, payload, err
 }
 
-func (c *Client) newPOSTRequest(baseURL string, req *Request) (*http.Request, error) {
+// newPOSTRequest returns a POST request of req and its JSON body, which is signed.
+func newPOSTRequest(baseURL string, req *Request) (*http.Request, string, error) {
 	jsonData, err := json.Marshal(req.params)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	c.params = jsonData
-	return http.NewRequest(string(POST), baseURL+req.path, bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequest(string(POST), baseURL+req.path, bytes.NewReader(jsonData))
+	return httpReq, string(jsonData), err
 }
-func (c *Client) setCommonHeaders(req *http.Request) {
+
+// setCommonHeaders sets the authentication headers of req, signing payload
+// with creds.
+func setCommonHeaders(req *http.Request, creds credentials.Credentials, payload string) {
 	timestamp := strconv.FormatInt(GetCurrentTime(), 10) // Get the current timestamp in milliseconds
 	req.Header.Set(signTypeKey, "2")
-	req.Header.Set(apiRequestKey, c.key)
+	req.Header.Set(apiRequestKey, creds.APIKey)
 	req.Header.Set(timestampKey, timestamp)
 	req.Header.Set(recvWindowKey, "5000") // Match Bybit's recvWindow of 5000 ms
-
-	var signatureBase []byte
 	if req.Method == "POST" {
 		req.Header.Set("Content-Type", "application/json")
-		// Concatenate timestamp, API key, recvWindow, and the request body for POST requests
-		signatureBase = []byte(timestamp + c.key + "5000" + string(c.params))
-	} else {
-		// Alphabetically sort query parameters and concatenate them with other fields for GET requests
-		queryString := c.QueryParams.Encode() // Automatically sorts the parameters alphabetically
-		signatureBase = []byte(timestamp + c.key + "5000" + queryString)
 	}
 
-	// Generate the HMAC-SHA256 signature
-	hmac256 := hmac.New(sha256.New, []byte(c.secretKey))
-	hmac256.Write(signatureBase)
+	// The signature covers the timestamp, API key, recvWindow and the query
+	// string of GET requests or the body of POST requests.
+	hmac256 := hmac.New(sha256.New, []byte(creds.APISecret))
+	hmac256.Write([]byte(timestamp + creds.APIKey + "5000" + payload))
 	signature := hex.EncodeToString(hmac256.Sum(nil))
 
 	// Set the signature in the headers
 	req.Header.Set(signatureKey, signature)
-
-	// Debug logging for troubleshooting
-	// 	log.Printf("Signature Base String: %s", string(signatureBase))
-	// 	log.Printf("Generated Signature: %s", signature)
-	// 	log.Printf("Headers: X-BAPI-API-KEY=%s, X-BAPI-TIMESTAMP=%s, X-BAPI-SIGN=%s", c.key, timestamp, signature)
 }
+
 func GetCurrentTime() int64 {
 	return time.Now().UnixNano() / int64(time.Millisecond)
 }
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RotatedCredentials(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(apiRequestKey))
+		_, _ = w.Write([]byte(`{"retCode":0}`))
+	}))
+	defer srv.Close()
+
+	key := "old"
+	c := NewClientWithEnvironment("", "", Environment{Name: "test", RESTURL: srv.URL})
+	c.SetCredentials(credentials.ProviderFunc(func() (credentials.Credentials, error) {
+		return credentials.Credentials{APIKey: key, APISecret: "secret"}, nil
+	}))
+
+	_, err := c.Get("/v5/user/query-api", Params{})
+	require.NoError(t, err)
+	key = "new"
+	_, err = c.Get("/v5/user/query-api", Params{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"old", "new"}, keys)
+}
+
+// TestClient_ConcurrentRotation checks, under -race, that concurrent requests
+// are each signed with the secret of the key they are sent with while the
+// credentials rotate.
+func TestClient_ConcurrentRotation(t *testing.T) {
+	var mismatched atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := r.URL.RawQuery
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			payload = string(body)
+		}
+		key := r.Header.Get(apiRequestKey)
+		mac := hmac.New(sha256.New, []byte(strings.Replace(key, "key", "secret", 1)))
+		mac.Write([]byte(r.Header.Get(timestampKey) + key + r.Header.Get(recvWindowKey) + payload))
+		if hex.EncodeToString(mac.Sum(nil)) != r.Header.Get(signatureKey) {
+			mismatched.Add(1)
+		}
+		_, _ = w.Write([]byte(`{"retCode":0}`))
+	}))
+	defer srv.Close()
+
+	var n atomic.Int64
+	c := NewClientWithEnvironment("", "", Environment{Name: "test", RESTURL: srv.URL})
+	c.SetCredentials(credentials.ProviderFunc(func() (credentials.Credentials, error) {
+		i := n.Add(1)
+		return credentials.Credentials{APIKey: fmt.Sprintf("key%d", i), APISecret: fmt.Sprintf("secret%d", i)}, nil
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, err := c.Get("/v5/test/rotation", Params{"n": i})
+			assert.NoError(t, err)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			_, err := c.Post("/v5/test/rotation", Params{"n": i})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 40, n.Load())
+	assert.Zero(t, mismatched.Load())
+}
+
+// TestClient_SetCredentialsWhileInUse checks, under -race, that the provider
+// can be replaced while requests are in flight.
+func TestClient_SetCredentialsWhileInUse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"retCode":0}`))
+	}))
+	defer srv.Close()
+	c := NewClientWithEnvironment("key0", "secret0", Environment{Name: "test", RESTURL: srv.URL})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := c.Get("/v5/test/rotation", Params{})
+			assert.NoError(t, err)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			c.SetCredentials(credentials.Static(fmt.Sprintf("key%d", i), fmt.Sprintf("secret%d", i)))
+		}(i)
+	}
+	wg.Wait()
+}
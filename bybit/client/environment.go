@@ -0,0 +1,83 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Environment is a set of Bybit hosts: the REST API and the public and
+// private WebSocket streams.
+type Environment struct {
+	Name             string
+	RESTURL          string // RESTURL is the base URL of the REST API.
+	PublicStreamURL  string // PublicStreamURL is the base URL of the public streams, without the /v5/public path.
+	PrivateStreamURL string // PrivateStreamURL is the base URL of the private stream, without the /v5/private path.
+}
+
+var (
+	Mainnet = newEnvironment("mainnet", "bybit.com")
+	Testnet = newEnvironment("testnet", "bybit.com")
+	// Demo is Bybit's demo trading. It has its own REST API and private
+	// stream; market data is served by the mainnet public streams.
+	Demo = Environment{
+		Name:             "demo",
+		RESTURL:          "https://api-demo.bybit.com",
+		PublicStreamURL:  "wss://stream.bybit.com",
+		PrivateStreamURL: "wss://stream-demo.bybit.com",
+	}
+	Bytick      = newEnvironment("bytick", "bytick.com")
+	Netherlands = newEnvironment("netherlands", "bybit.nl")
+	EEA         = newEnvironment("eea", "bybit.eu")
+	HongKong    = newEnvironment("hongkong", "byhkbit.com")
+	Turkey      = newEnvironment("turkey", "bybit-tr.com")
+	Kazakhstan  = newEnvironment("kazakhstan", "bybit.kz")
+)
+
+// newEnvironment returns the environment served from the api and stream hosts
+// of domain, or from their -testnet variants for the testnet.
+func newEnvironment(name, domain string) Environment {
+	suffix := ""
+	if name == "testnet" {
+		suffix = "-testnet"
+	}
+	return Environment{
+		Name:             name,
+		RESTURL:          fmt.Sprintf("https://api%s.%s", suffix, domain),
+		PublicStreamURL:  fmt.Sprintf("wss://stream%s.%s", suffix, domain),
+		PrivateStreamURL: fmt.Sprintf("wss://stream%s.%s", suffix, domain),
+	}
+}
+
+// Environments returns every predefined environment.
+func Environments() []Environment {
+	return []Environment{Mainnet, Testnet, Demo, Bytick, Netherlands, EEA, HongKong, Turkey, Kazakhstan}
+}
+
+// EnvironmentFor returns Testnet if isTestNet is set and Mainnet otherwise.
+func EnvironmentFor(isTestNet bool) Environment {
+	if isTestNet {
+		return Testnet
+	}
+	return Mainnet
+}
+
+// ParseEnvironment returns the predefined environment called name.
+func ParseEnvironment(name string) (Environment, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, env := range Environments() {
+		if env.Name == name {
+			return env, nil
+		}
+	}
+	return Environment{}, fmt.Errorf("unknown environment %q", name)
+}
+
+// IsZero reports whether e is the zero Environment.
+func (e Environment) IsZero() bool {
+	return e == Environment{}
+}
+
+// String returns the name of the environment.
+func (e Environment) String() string {
+	return e.Name
+}
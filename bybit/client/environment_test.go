@@ -0,0 +1,30 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironments(t *testing.T) {
+	assert.Equal(t, BaseURL, Mainnet.RESTURL)
+	assert.Equal(t, TestnetBaseURL, Testnet.RESTURL)
+	assert.Equal(t, "wss://stream-testnet.bybit.com", Testnet.PrivateStreamURL)
+	assert.Equal(t, "https://api-demo.bybit.com", Demo.RESTURL)
+	assert.Equal(t, "wss://stream-demo.bybit.com", Demo.PrivateStreamURL)
+	assert.Equal(t, "https://api.bybit.nl", Netherlands.RESTURL)
+	assert.Equal(t, "wss://stream.bytick.com", Bytick.PublicStreamURL)
+
+	env, err := ParseEnvironment("Demo")
+	require.NoError(t, err)
+	assert.Equal(t, Demo, env)
+	_, err = ParseEnvironment("staging")
+	assert.Error(t, err)
+}
+
+func TestClient_BaseURL(t *testing.T) {
+	assert.Equal(t, TestnetBaseURL, NewClient("", "", true).baseURL())
+	assert.Equal(t, BaseURL, NewClient("", "", false).baseURL())
+	assert.Equal(t, "https://api.bybit.eu", NewClientWithEnvironment("", "", EEA).baseURL())
+}
@@ -1,5 +1,7 @@
 package market
 
+import "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+
 type APIResponse struct {
 	RetCode    int    `json:"retCode"`
 	RetMsg     string `json:"retMsg"`
@@ -10,12 +12,12 @@ type APIResponse struct {
 
 // KlineRequest represents a request for querying historical klines
 type KlineRequest struct {
-	Category string `json:"category,omitempty"` // Optional: 'spot', 'linear', 'inverse'. Defaults to 'linear' if not specified.
-	Symbol   string `json:"symbol"`             // Required: Symbol name.
-	Interval string `json:"interval"`           // Required: Kline interval. Accepts '1', '3', '5', '15', '30', '60', '120', '240', '360', '720', 'D', 'M', 'W'.
-	Start    *int64 `json:"start,omitempty"`    // Optional: The start timestamp in milliseconds.
-	End      *int64 `json:"end,omitempty"`      // Optional: The end timestamp in milliseconds.
-	Limit    *int   `json:"limit,omitempty"`    // Optional: Limit the number of klines returned.
+	Category client.Category `json:"category,omitempty"` // Optional: 'spot', 'linear', 'inverse'. Defaults to 'linear' if not specified.
+	Symbol   string          `json:"symbol"`             // Required: Symbol name.
+	Interval string          `json:"interval"`           // Required: Kline interval. Accepts '1', '3', '5', '15', '30', '60', '120', '240', '360', '720', 'D', 'M', 'W'.
+	Start    *int64          `json:"start,omitempty"`    // Optional: The start timestamp in milliseconds.
+	End      *int64          `json:"end,omitempty"`      // Optional: The end timestamp in milliseconds.
+	Limit    *int            `json:"limit,omitempty"`    // Optional: Limit the number of klines returned.
 }
 
 type KlineResult struct {
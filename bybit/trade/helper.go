@@ -154,6 +154,9 @@ func ConvertGetOrderHistoryRequestToParams(req *GetOrderHistoryRequest) client.P
 	if req.OrderID != nil {
 		params["orderId"] = *req.OrderID
 	}
+	if req.OrderLinkID != nil {
+		params["orderLinkId"] = *req.OrderLinkID
+	}
 	if req.OrderFilter != nil {
 		params["orderFilter"] = *req.OrderFilter
 	}
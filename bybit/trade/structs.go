@@ -1,34 +1,36 @@
 package trade
 
+import "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+
 type PlaceOrderRequest struct {
-	Category         string  `json:"category"`
-	Symbol           string  `json:"symbol"`
-	IsLeverage       int     `json:"isLeverage"`
-	Side             string  `json:"side"`
-	OrderType        string  `json:"orderType"`
-	Qty              string  `json:"qty"`
-	Price            string  `json:"price,omitempty"`
-	TriggerPrice     *string `json:"triggerPrice,omitempty"`
-	TriggerDirection *int    `json:"triggerDirection,omitempty"`
-	TriggerBy        *string `json:"triggerBy,omitempty"`
-	OrderFilter      *string `json:"orderFilter,omitempty"`
-	OrderIv          *string `json:"orderIv,omitempty"`
-	TimeInForce      string  `json:"timeInForce"`
-	PositionIdx      *int    `json:"positionIdx,omitempty"`
-	OrderLinkID      string  `json:"orderLinkId"`
-	TakeProfit       *string `json:"takeProfit,omitempty"`
-	StopLoss         *string `json:"stopLoss,omitempty"`
-	TpTriggerBy      *string `json:"tpTriggerBy,omitempty"`
-	SlTriggerBy      *string `json:"slTriggerBy,omitempty"`
-	ReduceOnly       *bool   `json:"reduceOnly,omitempty"`
-	CloseOnTrigger   *bool   `json:"closeOnTrigger,omitempty"`
-	SmpType          *string `json:"smpType,omitempty"`
-	Mmp              *bool   `json:"mmp,omitempty"`
-	TpslMode         *string `json:"tpslMode,omitempty"`
-	TpLimitPrice     *string `json:"tpLimitPrice,omitempty"`
-	SlLimitPrice     *string `json:"slLimitPrice,omitempty"`
-	TpOrderType      *string `json:"tpOrderType,omitempty"`
-	SlOrderType      *string `json:"slOrderType,omitempty"`
+	Category         client.Category `json:"category"`
+	Symbol           string          `json:"symbol"`
+	IsLeverage       int             `json:"isLeverage"`
+	Side             string          `json:"side"`
+	OrderType        string          `json:"orderType"`
+	Qty              string          `json:"qty"`
+	Price            string          `json:"price,omitempty"`
+	TriggerPrice     *string         `json:"triggerPrice,omitempty"`
+	TriggerDirection *int            `json:"triggerDirection,omitempty"`
+	TriggerBy        *string         `json:"triggerBy,omitempty"`
+	OrderFilter      *string         `json:"orderFilter,omitempty"`
+	OrderIv          *string         `json:"orderIv,omitempty"`
+	TimeInForce      string          `json:"timeInForce"`
+	PositionIdx      *int            `json:"positionIdx,omitempty"`
+	OrderLinkID      string          `json:"orderLinkId"`
+	TakeProfit       *string         `json:"takeProfit,omitempty"`
+	StopLoss         *string         `json:"stopLoss,omitempty"`
+	TpTriggerBy      *string         `json:"tpTriggerBy,omitempty"`
+	SlTriggerBy      *string         `json:"slTriggerBy,omitempty"`
+	ReduceOnly       *bool           `json:"reduceOnly,omitempty"`
+	CloseOnTrigger   *bool           `json:"closeOnTrigger,omitempty"`
+	SmpType          *string         `json:"smpType,omitempty"`
+	Mmp              *bool           `json:"mmp,omitempty"`
+	TpslMode         *string         `json:"tpslMode,omitempty"`
+	TpLimitPrice     *string         `json:"tpLimitPrice,omitempty"`
+	SlLimitPrice     *string         `json:"slLimitPrice,omitempty"`
+	TpOrderType      *string         `json:"tpOrderType,omitempty"`
+	SlOrderType      *string         `json:"slOrderType,omitempty"`
 }
 
 type PlaceOrderResponse struct {
@@ -44,22 +46,22 @@ type PlaceOrderResponse struct {
 }
 
 type AmendOrderRequest struct {
-	Category     string  `json:"category"`
-	Symbol       string  `json:"symbol"`
-	OrderID      *string `json:"orderId,omitempty"`
-	OrderLinkID  *string `json:"orderLinkId,omitempty"`
-	OrderIv      *string `json:"orderIv,omitempty"`
-	TriggerPrice *string `json:"triggerPrice,omitempty"`
-	Qty          *string `json:"qty,omitempty"`
-	Price        *string `json:"price,omitempty"`
-	TpslMode     *string `json:"tpslMode,omitempty"`
-	TakeProfit   *string `json:"takeProfit,omitempty"`
-	StopLoss     *string `json:"stopLoss,omitempty"`
-	TpTriggerBy  *string `json:"tpTriggerBy,omitempty"`
-	SlTriggerBy  *string `json:"slTriggerBy,omitempty"`
-	TriggerBy    *string `json:"triggerBy,omitempty"`
-	TpLimitPrice *string `json:"tpLimitPrice,omitempty"`
-	SlLimitPrice *string `json:"slLimitPrice,omitempty"`
+	Category     client.Category `json:"category"`
+	Symbol       string          `json:"symbol"`
+	OrderID      *string         `json:"orderId,omitempty"`
+	OrderLinkID  *string         `json:"orderLinkId,omitempty"`
+	OrderIv      *string         `json:"orderIv,omitempty"`
+	TriggerPrice *string         `json:"triggerPrice,omitempty"`
+	Qty          *string         `json:"qty,omitempty"`
+	Price        *string         `json:"price,omitempty"`
+	TpslMode     *string         `json:"tpslMode,omitempty"`
+	TakeProfit   *string         `json:"takeProfit,omitempty"`
+	StopLoss     *string         `json:"stopLoss,omitempty"`
+	TpTriggerBy  *string         `json:"tpTriggerBy,omitempty"`
+	SlTriggerBy  *string         `json:"slTriggerBy,omitempty"`
+	TriggerBy    *string         `json:"triggerBy,omitempty"`
+	TpLimitPrice *string         `json:"tpLimitPrice,omitempty"`
+	SlLimitPrice *string         `json:"slLimitPrice,omitempty"`
 }
 type AmendOrderResponse struct {
 	RetCode int    `json:"retCode"`
@@ -72,11 +74,11 @@ type AmendOrderResponse struct {
 	Time       int64 `json:"time"`
 }
 type CancelOrderRequest struct {
-	Category    string  `json:"category"`
-	Symbol      string  `json:"symbol"`
-	OrderID     *string `json:"orderId,omitempty"`
-	OrderLinkID *string `json:"orderLinkId,omitempty"`
-	OrderFilter *string `json:"orderFilter,omitempty"` // Valid for spot only
+	Category    client.Category `json:"category"`
+	Symbol      string          `json:"symbol"`
+	OrderID     *string         `json:"orderId,omitempty"`
+	OrderLinkID *string         `json:"orderLinkId,omitempty"`
+	OrderFilter *string         `json:"orderFilter,omitempty"` // Valid for spot only
 }
 type CancelOrderResponse struct {
 	RetCode int    `json:"retCode"`
@@ -89,7 +91,7 @@ type CancelOrderResponse struct {
 	Time       int64 `json:"time"`
 }
 type GetOpenOrdersRequest struct {
-	Category    string
+	Category    client.Category
 	Symbol      *string
 	BaseCoin    *string
 	SettleCoin  *string
@@ -156,12 +158,12 @@ type OrderDetails struct {
 	UpdatedTime        string `json:"updatedTime"`
 }
 type CancelAllOrdersRequest struct {
-	Category      string  `json:"category"`
-	Symbol        *string `json:"symbol,omitempty"`
-	BaseCoin      *string `json:"baseCoin,omitempty"`
-	SettleCoin    *string `json:"settleCoin,omitempty"`
-	OrderFilter   *string `json:"orderFilter,omitempty"`
-	StopOrderType *string `json:"stopOrderType,omitempty"`
+	Category      client.Category `json:"category"`
+	Symbol        *string         `json:"symbol,omitempty"`
+	BaseCoin      *string         `json:"baseCoin,omitempty"`
+	SettleCoin    *string         `json:"settleCoin,omitempty"`
+	OrderFilter   *string         `json:"orderFilter,omitempty"`
+	StopOrderType *string         `json:"stopOrderType,omitempty"`
 }
 type CancelAllOrdersResponse struct {
 	RetCode int    `json:"retCode"`
@@ -177,17 +179,18 @@ type CancelAllOrdersResponse struct {
 	Time       int64 `json:"time"`
 }
 type GetOrderHistoryRequest struct {
-	Category    string  `json:"category"`
-	Symbol      *string `json:"symbol"`
-	BaseCoin    *string `json:"baseCoin,omitempty"`
-	SettleCoin  *string `json:"settleCoin,omitempty"`
-	OrderID     *string `json:"orderId,omitempty"`
-	OrderFilter *string `json:"orderFilter,omitempty"`
-	OrderStatus *string `json:"orderStatus,omitempty"`
-	StartTime   *int64  `json:"startTime,omitempty"`
-	EndTime     *int64  `json:"endTime,omitempty"`
-	Limit       *int    `json:"limit"`
-	Cursor      *string `json:"cursor"`
+	Category    client.Category `json:"category"`
+	Symbol      *string         `json:"symbol"`
+	BaseCoin    *string         `json:"baseCoin,omitempty"`
+	SettleCoin  *string         `json:"settleCoin,omitempty"`
+	OrderID     *string         `json:"orderId,omitempty"`
+	OrderLinkID *string         `json:"orderLinkId,omitempty"`
+	OrderFilter *string         `json:"orderFilter,omitempty"`
+	OrderStatus *string         `json:"orderStatus,omitempty"`
+	StartTime   *int64          `json:"startTime,omitempty"`
+	EndTime     *int64          `json:"endTime,omitempty"`
+	Limit       *int            `json:"limit"`
+	Cursor      *string         `json:"cursor"`
 }
 type GetOrderHistoryResponse struct {
 	RetCode int    `json:"retCode"`
@@ -201,7 +204,7 @@ type GetOrderHistoryResponse struct {
 	Time       int64 `json:"time"`
 }
 type GetTradeHistoryRequest struct {
-	Category    string
+	Category    client.Category
 	Symbol      *string
 	OrderID     *string
 	OrderLinkID *string
@@ -256,8 +259,8 @@ type Details struct {
 }
 
 type BatchPlaceOrderRequest struct {
-	Category string         `json:"category"`
-	Request  []OrderRequest `json:"request"`
+	Category client.Category `json:"category"`
+	Request  []OrderRequest  `json:"request"`
 }
 
 type OrderRequest struct {
@@ -308,7 +311,7 @@ type BatchPlaceOrderResponse struct {
 	Time int64 `json:"time"`
 }
 type BatchAmendOrderRequest struct {
-	Category string              `json:"category"`
+	Category client.Category     `json:"category"`
 	Request  []AmendOrderRequest `json:"request"`
 }
 
@@ -332,7 +335,7 @@ type BatchAmendOrderResponse struct {
 	Time int64 `json:"time"`
 }
 type BatchCancelOrderRequest struct {
-	Category string               `json:"category"`
+	Category client.Category      `json:"category"`
 	Request  []CancelOrderRequest `json:"request"`
 }
 type BatchCancelOrderResponse struct {
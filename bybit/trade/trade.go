@@ -144,12 +144,8 @@ func (t *tradeImpl) AmendOrder(req *AmendOrderRequest) (*AmendOrderResponse, err
 	if err != nil {
 		return nil, err
 	}
-	data, err := json.Marshal(res)
-	if err != nil {
-		return nil, err
-	}
 	var response AmendOrderResponse
-	err = json.Unmarshal(data, &response)
+	err = res.Unmarshal(&response)
 	if err != nil {
 		return nil, err
 	}
@@ -167,12 +163,8 @@ func (t *tradeImpl) CancelOrder(req *CancelOrderRequest) (*CancelOrderResponse,
 	if err != nil {
 		return nil, err
 	}
-	data, err := json.Marshal(resBytes)
-	if err != nil {
-		return nil, err
-	}
 	var response CancelOrderResponse
-	err = json.Unmarshal(data, &response)
+	err = resBytes.Unmarshal(&response)
 	if err != nil {
 		return nil, err
 	}
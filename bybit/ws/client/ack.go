@@ -0,0 +1,147 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAckTimeout is how long subscribe, unsubscribe and auth requests wait
+// for the server's acknowledgement by default.
+const DefaultAckTimeout = 10 * time.Second
+
+// ErrAckTimeout is returned when the server does not acknowledge a request
+// within the client's AckTimeout.
+var ErrAckTimeout = errors.New("timed out waiting for acknowledgement")
+
+// OpError is returned when the server rejects a subscribe, unsubscribe or auth request.
+type OpError struct {
+	Op     string   // Op is the rejected operation.
+	ReqID  string   // ReqID is the req_id sent with the request.
+	Args   []string // Args are the topics of a subscribe or unsubscribe request.
+	RetMsg string   // RetMsg is the reason given by the server.
+}
+
+func (e *OpError) Error() string {
+	if len(e.Args) == 0 {
+		return fmt.Sprintf("%s request %s rejected: %s", e.Op, e.ReqID, e.RetMsg)
+	}
+	return fmt.Sprintf("%s request %s for %s rejected: %s", e.Op, e.ReqID, strings.Join(e.Args, ","), e.RetMsg)
+}
+
+// ackResult is the outcome of a request as reported by the server.
+type ackResult struct {
+	success bool
+	retMsg  string
+}
+
+// pendingAck is a request waiting for its acknowledgement.
+type pendingAck struct {
+	op     string
+	result chan ackResult
+}
+
+// acks correlates acknowledgements with the requests waiting for them.
+type acks struct {
+	mu      sync.Mutex
+	pending map[string]pendingAck
+}
+
+// expect registers a request and returns the channel its acknowledgement is delivered to.
+func (a *acks) expect(reqID, op string) <-chan ackResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.pending == nil {
+		a.pending = make(map[string]pendingAck)
+	}
+	result := make(chan ackResult, 1)
+	a.pending[reqID] = pendingAck{op: op, result: result}
+	return result
+}
+
+// forget drops a request that is no longer waited for.
+func (a *acks) forget(reqID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pending, reqID)
+}
+
+// resolve delivers an acknowledgement to the request it answers. Auth
+// responses do not always echo the req_id, so those are matched by op.
+func (a *acks) resolve(env envelope) {
+	if !env.isAck() {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	reqID := env.ReqID
+	if _, ok := a.pending[reqID]; !ok && env.Op == AuthOperation {
+		for id, p := range a.pending {
+			if p.op == AuthOperation {
+				reqID = id
+				break
+			}
+		}
+	}
+	p, ok := a.pending[reqID]
+	if !ok {
+		return
+	}
+	delete(a.pending, reqID)
+	p.result <- ackResult{success: env.Success != nil && *env.Success, retMsg: env.RetMsg}
+}
+
+// isAck reports whether the envelope answers a subscribe, unsubscribe or auth request.
+func (e envelope) isAck() bool {
+	switch e.Op {
+	case SubscribeOperation, UnsubscribeOperation, AuthOperation:
+		return e.Success != nil
+	default:
+		return false
+	}
+}
+
+// request writes a frame built for a fresh req_id and, unless AckTimeout is
+// negative, waits for the server to acknowledge it. It must not be called from
+// a Handler, since acknowledgements are read by the same goroutine that runs
+// the handlers.
+func (c *Client) request(op string, args []string, frame func(reqID string) ([]byte, error)) error {
+	reqID := randomString(eightNumber)
+	msg, err := frame(reqID)
+	if err != nil {
+		return err
+	}
+	if c.AckTimeout < 0 {
+		return c.write(msg)
+	}
+
+	result := c.acks.expect(reqID, op)
+	defer c.acks.forget(reqID)
+
+	if err := c.write(msg); err != nil {
+		return err
+	}
+
+	timeout := c.AckTimeout
+	if timeout == 0 {
+		timeout = DefaultAckTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case ack := <-result:
+		if !ack.success {
+			return &OpError{Op: op, ReqID: reqID, Args: args, RetMsg: ack.retMsg}
+		}
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%s request %s: %w", op, reqID, ErrAckTimeout)
+	case <-c.done:
+		return errors.New("connection closed while waiting for acknowledgement")
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe_Rejected(t *testing.T) {
+	s := wstest.NewServer(t)
+	s.Respond(func(req wstest.Request) []string {
+		if req.Op == SubscribeOperation {
+			return []string{wstest.Reply(req, false, "error:handler not found,topic:kline.1.NOPE")}
+		}
+		return nil
+	})
+	c := newTestClient(t, s, "linear")
+
+	_, err := c.Subscribe([]string{"kline.1.NOPE"}, func([]byte) {})
+
+	var opErr *OpError
+	require.ErrorAs(t, err, &opErr)
+	assert.Equal(t, SubscribeOperation, opErr.Op)
+	assert.Equal(t, []string{"kline.1.NOPE"}, opErr.Args)
+	assert.Contains(t, opErr.RetMsg, "handler not found")
+	assert.Equal(t, s.Requests(SubscribeOperation)[0].ReqID, opErr.ReqID)
+	assert.Empty(t, c.ActiveTopics())
+}
+
+func TestSubscribe_AckTimeout(t *testing.T) {
+	s := wstest.NewServer(t)
+	s.Respond(func(req wstest.Request) []string {
+		return []string{} // Never acknowledge.
+	})
+	c := newTestClient(t, s, "linear")
+	c.AckTimeout = 50 * time.Millisecond
+
+	_, err := c.Subscribe([]string{"tickers.BTCUSDT"}, func([]byte) {})
+	assert.ErrorIs(t, err, ErrAckTimeout)
+
+	c.AckTimeout = -1
+	_, err = c.Subscribe([]string{"tickers.BTCUSDT"}, func([]byte) {})
+	assert.NoError(t, err)
+}
+
+func TestConnect_AuthRejected(t *testing.T) {
+	c, s := newLocalPrivateClient(t)
+	defer c.Close()
+	s.Respond(func(req wstest.Request) []string {
+		if req.Op == AuthOperation {
+			// Auth responses may omit the req_id.
+			req.ReqID = ""
+			return []string{wstest.Reply(req, false, "Params Error")}
+		}
+		return nil
+	})
+
+	err := c.Connect()
+
+	var opErr *OpError
+	require.ErrorAs(t, err, &opErr)
+	assert.Equal(t, AuthOperation, opErr.Op)
+	assert.Equal(t, "Params Error", opErr.RetMsg)
+	assert.Nil(t, c.currentConn())
+
+	// A later Connect dials and authenticates again.
+	s.Respond(wstest.Ack)
+	require.NoError(t, c.Connect())
+	assert.NotNil(t, c.currentConn())
+	assert.Len(t, s.Requests(AuthOperation), 2)
+}
@@ -13,22 +13,27 @@ import (
 	"sync"
 	"time"
 
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+	"github.com/cploutarchou/crypto-sdk-suite/logger"
 	"github.com/gorilla/websocket"
 )
 
 const (
-	DefaultScheme       = "wss"
-	PingInterval        = 20 * time.Second
-	PingOperation       = "ping"
-	AuthOperation       = "auth"
-	ReconnectionRetries = 3
-	ReconnectionDelay   = 10 * time.Second
-	Public              = "public"
-	Private             = "private"
+	DefaultScheme = "wss"
+	PingInterval  = 20 * time.Second
+	PingOperation = "ping"
+	AuthOperation = "auth"
+	Public        = "public"
+	Private       = "private"
 )
 
 var (
 	DefaultReqID = randomString(eightNumber)
+
+	// DefaultLogger is the logger of new clients. It writes messages at INFO
+	// level and above to stdout; pings and authentication are logged at DEBUG.
+	DefaultLogger = logger.NewStd(log.New(os.Stdout, "[WebSocketClient] ", log.LstdFlags), logger.INFO)
 )
 
 const eightNumber = 8
@@ -44,43 +49,83 @@ type ChannelType string
 
 // Client is the main WebSocket client struct, managing the connection and its state.
 type Client struct {
-	closeOnce         sync.Once
-	connOnce          sync.Once
-	isClosed          bool
-	logger            *log.Logger
-	IsTestNet         bool
-	APIKey            string
-	APISecret         string
+	closeOnce   sync.Once
+	dialMu      sync.Mutex
+	isClosed    bool
+	logMu       sync.RWMutex
+	logger      logger.Interface
+	authCreds   credentials.Credentials // authCreds are the credentials of the last authentication.
+	IsTestNet   bool
+	Environment Environment // Environment selects the stream hosts; when unset, IsTestNet picks mainnet or testnet.
+	APIKey      string
+	APISecret   string
+	// Credentials, when set, is read on every authentication instead of
+	// APIKey and APISecret, so keys rotated between reconnects are picked up.
+	Credentials       credentials.Provider
 	Channel           ChannelType
 	Path              string
 	Connected         chan struct{}
 	OnConnected       func()
 	OnConnectionError func(err error)
-	Category          string
-	MaxActiveTime     string
-	wsURL             string // WebSocket URL for dependency injection in tests
+	OnEvent           func(event ConnectionEvent)
+	// AckTimeout bounds how long subscribe, unsubscribe and auth requests wait
+	// for the server's acknowledgement; 0 uses DefaultAckTimeout and a negative
+	// value sends requests without waiting.
+	AckTimeout    time.Duration
+	Reconnect     ReconnectPolicy
+	Heartbeat     HeartbeatPolicy
+	Category      rest.Category
+	MaxActiveTime string
+	wsURL         string // WebSocket URL for dependency injection in tests
 
 	Conn     *websocket.Conn
 	connLock sync.Mutex
+	writeMu  sync.Mutex
+
+	subsMu    sync.RWMutex
+	topics    map[string]map[uint64]Handler
+	pending   map[string]*pendingTopic // pending are the topics waiting for their subscribe ack.
+	listeners map[uint64]Handler
+	nextID    uint64
+	refs      int
+	done      chan struct{}
+
+	heartbeat heartbeat
+	acks      acks
 }
 
-// NewPublicClient initializes a new public WSClient instance.
-func NewPublicClient(isTestNet bool, category string) (*Client, error) {
+// NewPublicClient initializes a new public WSClient instance for the stream
+// serving category. The legacy names such as "usdt_contract" are accepted.
+func NewPublicClient(isTestNet bool, category rest.Category) (*Client, error) {
+	category, err := rest.ParseCategory(string(category))
+	if err != nil {
+		return nil, err
+	}
 	client := &Client{
-		logger:    log.New(os.Stdout, "[WebSocketClient] ", log.LstdFlags),
+		logger:    DefaultLogger,
 		IsTestNet: isTestNet,
 		Channel:   Public,
 		Connected: make(chan struct{}),
 		Category:  category,
+		Reconnect: DefaultReconnectPolicy,
+		Heartbeat: DefaultHeartbeatPolicy,
+		done:      make(chan struct{}),
 	}
 	DefaultReqID = randomString(eightNumber)
 	return client, nil
 }
 
-// NewPrivateClient initializes a new private WSClient instance.
-func NewPrivateClient(apiKey, apiSecret string, isTestNet bool, maxActiveTime string, category string) (*Client, error) {
+// NewPrivateClient initializes a new private WSClient instance. The private
+// stream serves every category, so category may be left empty.
+func NewPrivateClient(apiKey, apiSecret string, isTestNet bool, maxActiveTime string, category rest.Category) (*Client, error) {
+	if category != "" {
+		var err error
+		if category, err = rest.ParseCategory(string(category)); err != nil {
+			return nil, err
+		}
+	}
 	client := &Client{
-		logger:        log.New(os.Stdout, "[WebSocketClient] ", log.LstdFlags),
+		logger:        DefaultLogger,
 		IsTestNet:     isTestNet,
 		APIKey:        apiKey,
 		APISecret:     apiSecret,
@@ -88,41 +133,95 @@ func NewPrivateClient(apiKey, apiSecret string, isTestNet bool, maxActiveTime st
 		Connected:     make(chan struct{}),
 		MaxActiveTime: maxActiveTime,
 		Category:      category,
+		Reconnect:     DefaultReconnectPolicy,
+		Heartbeat:     DefaultHeartbeatPolicy,
+		done:          make(chan struct{}),
 	}
 	DefaultReqID = randomString(eightNumber)
 	return client, nil
 }
 
 // Connect establishes a WebSocket connection to the server based on the configuration.
+// It is a no-op if the client is already connected. Private clients are
+// authenticated as part of connecting.
 func (c *Client) Connect() error {
-	var err error
-	c.connOnce.Do(func() {
-		c.connLock.Lock()
-		defer c.connLock.Unlock()
+	c.dialMu.Lock()
+	defer c.dialMu.Unlock()
+
+	c.connLock.Lock()
+	closed, conn := c.isClosed, c.Conn
+	c.connLock.Unlock()
 
-		if c.isClosed {
-			err = errors.New("connection already closed")
+	if closed {
+		err := errors.New("connection already closed")
+		c.handleConnectionError(err)
+		return err
+	}
+	if conn != nil {
+		return nil
+	}
+
+	if c.Channel == Public && c.wsURL == "" {
+		if err := c.Category.Validate(); err != nil {
 			c.handleConnectionError(err)
-			return
+			return err
 		}
+	}
 
-		url := c.buildURL()
-		c.Conn, _, err = websocket.DefaultDialer.Dial(url, nil)
-		if err != nil {
-			c.handleConnectionError(fmt.Errorf("failed to dial %s: %v", url, err))
-			c.Conn = nil
-			return
-		}
+	url := c.buildURL()
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		c.handleConnectionError(fmt.Errorf("failed to dial %s: %v", url, err))
+		return err
+	}
 
-		c.logger.Printf("Connected to %s", url)
-		if c.OnConnected != nil {
-			c.OnConnected()
-		}
-		closeOnce(c.Connected)
+	c.connLock.Lock()
+	if c.isClosed {
+		c.connLock.Unlock()
+		_ = conn.Close()
+		return errors.New("connection closed while connecting")
+	}
+	c.Conn = conn
+	c.connLock.Unlock()
+
+	c.log().Info("connected", "url", url)
+	go c.readLoop(conn)
+	go c.keepAlive(conn)
+
+	if err := c.authenticateIfRequired(); err != nil {
+		// The connection is dropped so that the next Connect, or reconnection
+		// attempt, dials and authenticates again instead of reusing it.
+		c.dropConn(conn)
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
 
-		go c.keepAlive()
-	})
-	return err
+	if c.OnConnected != nil {
+		c.OnConnected()
+	}
+	closeOnce(c.Connected)
+	return nil
+}
+
+// dropConn closes conn and clears it if it is still the active connection.
+// Its reader and keepalive stop without triggering a reconnection.
+func (c *Client) dropConn(conn *websocket.Conn) {
+	c.connLock.Lock()
+	if c.Conn == conn {
+		c.Conn = nil
+	}
+	c.connLock.Unlock()
+	_ = conn.Close()
+}
+
+// SetURL overrides the WebSocket URL the client connects to, for example to
+// point it at a local server in tests.
+func (c *Client) SetURL(url string) {
+	c.wsURL = url
+}
+
+// URL returns the WebSocket URL the client connects to.
+func (c *Client) URL() string {
+	return c.buildURL()
 }
 
 // buildURL constructs the WebSocket URL based on client configuration.
@@ -131,42 +230,39 @@ func (c *Client) buildURL() string {
 		return c.wsURL
 	}
 
-	var baseURL string
-	if c.IsTestNet {
-		baseURL = "stream-testnet.bybit.com"
-	} else {
-		baseURL = "stream.bybit.com"
+	env := c.Environment
+	if env.IsZero() {
+		env = rest.EnvironmentFor(c.IsTestNet)
 	}
 
 	switch c.Channel {
-	case Public:
-		switch c.Category {
-		case "spot":
-			return fmt.Sprintf("%s://%s/v5/public/spot", DefaultScheme, baseURL)
-		case "usdt_contract", "usdc_contract", "usdc_futures":
-			return fmt.Sprintf("%s://%s/v5/public/linear", DefaultScheme, baseURL)
-		case "inverse_contract":
-			return fmt.Sprintf("%s://%s/v5/public/inverse", DefaultScheme, baseURL)
-		case "usdc_option":
-			return fmt.Sprintf("%s://%s/v5/public/option", DefaultScheme, baseURL)
-		default:
-			return fmt.Sprintf("%s://%s/v5/public/linear", DefaultScheme, baseURL) // default to linear (USDT/USDC)
-		}
 	case Private:
-		return fmt.Sprintf("%s://%s/v5/private", DefaultScheme, baseURL)
+		return env.PrivateStreamURL + "/v5/private"
 	default:
-		return fmt.Sprintf("%s://%s/v5/public/linear", DefaultScheme, baseURL) // default URL
+		// An invalid category yields an empty path, which Connect rejects before dialing.
+		path, _ := c.Category.StreamPath()
+		return fmt.Sprintf("%s/v5/public/%s", env.PublicStreamURL, path)
 	}
 }
 
 // authenticateIfRequired authenticates the WebSocket client if the channel is private.
 func (c *Client) authenticateIfRequired() error {
 	if c.Channel == Private {
+		creds := credentials.Credentials{APIKey: c.APIKey, APISecret: c.APISecret}
+		if c.Credentials != nil {
+			var err error
+			if creds, err = c.Credentials.Credentials(); err != nil {
+				return fmt.Errorf("failed to get credentials: %w", err)
+			}
+		}
+		c.logMu.Lock()
+		c.authCreds = creds
+		c.logMu.Unlock()
+
 		expires := fmt.Sprintf("%d", time.Now().UnixMilli()+1000)
 		signatureData := fmt.Sprintf("GET/realtime%s", expires)
-		signed := GenerateWsSignature(c.APISecret, signatureData)
-		c.logger.Printf("Authenticating with apiKey %s, expires %s, signed %s", c.APIKey, expires, signed)
-		return c.Authenticate(c.APIKey, expires, signed)
+		signed := GenerateWsSignature(creds.APISecret, signatureData)
+		return c.Authenticate(creds.APIKey, expires, signed)
 	}
 	return nil
 }
@@ -181,61 +277,80 @@ func GenerateWsSignature(apiSecret, data string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// keepAlive sends a ping message to the WebSocket server every PingInterval and handles reconnection if the ping fails.
-func (c *Client) keepAlive() {
-	ticker := time.NewTicker(PingInterval)
+// keepAlive sends a ping message to the WebSocket server every heartbeat interval until conn is replaced or the client is closed.
+func (c *Client) keepAlive(conn *websocket.Conn) {
+	interval := c.Heartbeat.Interval
+	if interval <= 0 {
+		interval = PingInterval
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		c.sendPingAndHandleReconnection()
+	for {
+		select {
+		case <-c.done:
+			return
+		case now := <-ticker.C:
+			if c.currentConn() != conn {
+				return
+			}
+			c.heartbeat.sample(now)
+			c.sendPingAndHandleReconnection()
+		}
 	}
 }
 
+// currentConn returns the active connection, or nil if the client is disconnected.
+func (c *Client) currentConn() *websocket.Conn {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+	return c.Conn
+}
+
 // sendPingAndHandleReconnection sends a ping message to the WebSocket server and handles reconnection if the ping fails.
 func (c *Client) sendPingAndHandleReconnection() {
 	c.connLock.Lock()
-	defer c.connLock.Unlock()
+	closed, conn := c.isClosed, c.Conn
+	c.connLock.Unlock()
 
-	if c.isClosed || c.Conn == nil {
+	if closed || conn == nil {
 		return
 	}
 
 	pingMsg := PingMsg{
-		ReqID: DefaultReqID,
+		ReqID: randomString(eightNumber),
 		Op:    PingOperation,
 	}
 	jsonData, err := json.Marshal(pingMsg)
 	if err != nil {
-		c.logger.Printf("Error marshaling ping message: %v", err)
+		c.log().Error("failed to marshal ping", "error", err)
 		return
 	}
 
-	if err = c.Conn.WriteMessage(websocket.TextMessage, jsonData); err != nil {
-		c.logger.Printf("Error sending ping: %v", err)
-		go c.handleReconnection()
+	c.heartbeat.pingSent(pingMsg.ReqID, time.Now())
+	if err = c.write(jsonData); err != nil {
+		c.log().Warn("failed to send ping", "error", err)
+		go c.handleReconnection(conn, err)
 		return
 	}
-	c.logger.Println("Ping sent")
+	c.log().Debug("ping sent", "req_id", pingMsg.ReqID)
 }
 
-// Authenticate sends an authentication request to the WebSocket server.
+// Authenticate sends an authentication request to the WebSocket server and
+// waits for it to be acknowledged. A rejected request is reported as an *OpError.
 func (c *Client) Authenticate(apiKey, expires, signature string) error {
-	c.connLock.Lock()
-	defer c.connLock.Unlock()
-
 	if c.Channel != Private {
 		return errors.New("cannot authenticate on a public channel")
 	}
-	c.logger.Printf("Authenticating with apiKey %s, expires %s, signed %s", apiKey, expires, signature)
-	authRequest := map[string]any{
-		"op":   AuthOperation,
-		"args": []any{apiKey, expires, signature},
-	}
-	jsonData, err := json.Marshal(authRequest)
+	c.log().Debug("authenticating", "apiKey", apiKey, "expires", expires)
+	err := c.request(AuthOperation, nil, func(reqID string) ([]byte, error) {
+		return json.Marshal(map[string]any{
+			"op":     AuthOperation,
+			"req_id": reqID,
+			"args":   []any{apiKey, expires, signature},
+		})
+	})
 	if err != nil {
-		return err
-	}
-	if err := c.Conn.WriteMessage(websocket.TextMessage, jsonData); err != nil {
 		c.handleConnectionError(err)
 		return err
 	}
@@ -249,7 +364,10 @@ func (c *Client) Close() {
 		defer c.connLock.Unlock()
 
 		c.isClosed = true
-		c.logger.Println("Connection closed")
+		if c.done != nil {
+			close(c.done)
+		}
+		c.log().Info("connection closed")
 		if c.Conn != nil {
 			if err := c.Conn.Close(); err != nil && c.OnConnectionError != nil {
 				c.OnConnectionError(err)
@@ -268,82 +386,109 @@ func randomString(n int) string {
 
 // Send sends a message to the WebSocket server.
 func (c *Client) Send(message []byte) error {
+	if err := c.ensureConnected(); err != nil {
+		return err
+	}
+
+	if err := c.write(message); err != nil {
+		c.log().Error("failed to send message", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// ensureConnected connects the client if it is not connected yet.
+func (c *Client) ensureConnected() error {
 	c.connLock.Lock()
-	defer c.connLock.Unlock()
+	closed, conn := c.isClosed, c.Conn
+	c.connLock.Unlock()
 
-	if c.isClosed {
+	if closed {
 		return errors.New("attempt to send message on closed connection")
 	}
 
-	if c.Conn == nil {
-		log.Println("Connection is nil, attempting to reconnect...")
+	if conn == nil {
+		c.log().Info("not connected, connecting")
 		if err := c.Connect(); err != nil {
-			log.Printf("Reconnection failed: %v", err)
+			c.log().Error("failed to connect", "error", err)
 			return err
 		}
 	}
+	return nil
+}
 
-	if c.Conn == nil {
-		return errors.New("connection is still nil after attempting to reconnect")
-	}
+// write serializes writes to the underlying connection, which supports only one concurrent writer.
+func (c *Client) write(message []byte) error {
+	c.connLock.Lock()
+	conn := c.Conn
+	c.connLock.Unlock()
 
-	if err := c.Conn.WriteMessage(websocket.TextMessage, message); err != nil {
-		log.Printf("Error sending message: %v", err)
-		return err
+	if conn == nil {
+		return errors.New("connection is nil")
 	}
 
-	return nil
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, message)
 }
 
-// Receive listens for a message from the WebSocket server and returns it.
+// Receive waits for the next message from the WebSocket server and returns it.
+// Messages are read by the client's own reader, so Receive only observes
+// messages that arrive while it is waiting.
 func (c *Client) Receive() ([]byte, error) {
 	c.connLock.Lock()
-	defer c.connLock.Unlock()
+	conn := c.Conn
+	c.connLock.Unlock()
 
-	if c.Conn == nil {
+	if conn == nil {
 		return nil, errors.New("attempt to receive message on nil connection")
 	}
 
-	_, message, err := c.Conn.ReadMessage()
-	if err != nil {
-		log.Printf("Error receiving message: %v", err)
-		go c.handleReconnection()
-		return nil, err
-	}
-
-	return message, nil
-}
-
-// handleReconnection attempts to reconnect to the WebSocket server.
-func (c *Client) handleReconnection() {
-	c.connLock.Lock()
-	defer c.connLock.Unlock()
+	ch := make(chan []byte, 1)
+	remove := c.AddListener(func(message []byte) {
+		select {
+		case ch <- message:
+		default:
+		}
+	})
+	defer remove()
 
-	if c.isClosed {
-		return // No need to reconnect if the client is intentionally closed
+	select {
+	case message := <-ch:
+		return message, nil
+	case <-c.done:
+		return nil, errors.New("connection closed while receiving")
 	}
+}
 
-	c.logger.Println("Attempting to reconnect...")
-	if c.Conn != nil {
-		_ = c.Conn.Close()
-		c.Conn = nil
+// SetLogger sets the logger the client writes to; nil discards the logs. The
+// client's API key and secret are redacted from everything it logs.
+func (c *Client) SetLogger(l logger.Interface) {
+	if l == nil {
+		l = logger.Nop
 	}
+	c.logMu.Lock()
+	defer c.logMu.Unlock()
+	c.logger = l
+}
 
-	for i := 0; i < ReconnectionRetries; i++ {
-		time.Sleep(ReconnectionDelay)
-		if err := c.Connect(); err == nil {
-			c.logger.Printf("Reconnection attempt %d successful", i+1)
-			return
-		}
-		c.logger.Printf("Reconnection attempt %d failed", i+1)
+// log returns the client's logger with its credentials redacted.
+func (c *Client) log() logger.Interface {
+	c.logMu.RLock()
+	l, creds := c.logger, c.authCreds
+	c.logMu.RUnlock()
+	if l == nil {
+		l = DefaultLogger
 	}
+	return logger.Redact(l, c.APIKey, c.APISecret, creds.APIKey, creds.APISecret)
 }
 
 func (c *Client) handleConnectionError(err error) {
 	if c.OnConnectionError != nil {
 		c.OnConnectionError(err)
 	}
-	c.logger.Printf("Connection error: %v", err)
+	c.log().Error("connection error", "error", err)
 }
 
 // closeOnce ensures the channel is only closed once
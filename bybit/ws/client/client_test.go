@@ -2,19 +2,26 @@
 package client
 
 import (
-	"encoding/json"
+	"bytes"
+	"log"
 	"os"
 	"testing"
 	"time"
 
-	"github.com/gorilla/websocket"
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/cploutarchou/crypto-sdk-suite/logger"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
 )
 
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
 // Constants
 const (
-	testnetBaseURL = "wss://stream-testnet.bybit.com/v5"
-	maxActiveTime  = "1m"
+	maxActiveTime = "1m"
 )
 
 // Environment variables
@@ -50,81 +57,73 @@ func TestNewPrivateClient(t *testing.T) {
 	assert.Equal(t, maxActiveTime, client.MaxActiveTime)
 }
 
-// TestClient_Connect verifies the Connect function establishes a WebSocket connection correctly.
-// It tests if the function connects to the correct WebSocket URL and sets the appropriate callbacks.
-func TestClient_Connect(t *testing.T) {
-	apiKey := testnetAPIKey
-	apiSecret := testnetAPISecret
-	client, err := NewPrivateClient(apiKey, apiSecret, true, maxActiveTime, "usdt_contract")
+// newLocalPrivateClient creates a private client pointed at a local test server.
+func newLocalPrivateClient(t *testing.T) (*Client, *wstest.Server) {
+	t.Helper()
+	s := wstest.NewServer(t)
+	client, err := NewPrivateClient(testnetAPIKey, testnetAPISecret, true, maxActiveTime, "usdt_contract")
 	assert.NoError(t, err)
+	client.SetURL(s.URL())
+	return client, s
+}
 
-	client.wsURL = testnetBaseURL + "/private"
+// TestClient_Connect verifies the Connect function establishes a WebSocket connection correctly.
+// It tests if the function connects and authenticates a private client and invokes the callbacks.
+func TestClient_Connect(t *testing.T) {
+	client, s := newLocalPrivateClient(t)
+	defer client.Close()
 
+	connected := false
 	client.OnConnected = func() {
-		t.Log("Connected to the WebSocket server")
+		connected = true
 	}
 
 	client.OnConnectionError = func(err error) {
 		t.Errorf("Connection error: %v", err)
 	}
 
-	err = client.Connect()
+	err := client.Connect()
 	assert.NoError(t, err)
 	assert.NotNil(t, client.Conn)
+	assert.True(t, connected)
+	assert.Eventually(t, func() bool {
+		return len(s.Requests(AuthOperation)) == 1
+	}, time.Second, 10*time.Millisecond)
 }
 
 // TestClient_Send verifies the Send function sends a message correctly.
 // It tests if the function sends a message to the WebSocket server.
 func TestClient_Send(t *testing.T) {
-	apiKey := testnetAPIKey
-	apiSecret := testnetAPISecret
-
-	client, err := NewPrivateClient(apiKey, apiSecret, true, maxActiveTime, "usdt_contract")
-	assert.NoError(t, err)
+	client, s := newLocalPrivateClient(t)
+	defer client.Close()
 
-	client.wsURL = testnetBaseURL + "/private"
-
-	err = client.Connect()
+	err := client.Connect()
 	assert.NoError(t, err)
 
 	message := []byte(`{"op":"ping","req_id":"test21"}`)
 	err = client.Send(message)
 	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		pings := s.Requests(PingOperation)
+		return len(pings) == 1 && pings[0].ReqID == "test21"
+	}, time.Second, 10*time.Millisecond)
 }
 
 // TestClient_Receive verifies the Receiving function receives a message correctly.
 // It tests if the function receives a message from the WebSocket server and contains the expected content.
 func TestClient_Receive(t *testing.T) {
-	apiKey := testnetAPIKey
-	apiSecret := testnetAPISecret
-
-	client, err := NewPrivateClient(apiKey, apiSecret, true, maxActiveTime, "usdt_contract")
-	assert.NoError(t, err)
-
-	client.wsURL = testnetBaseURL + "/private"
+	client, s := newLocalPrivateClient(t)
+	defer client.Close()
 
-	err = client.Connect()
-	assert.NoError(t, err)
-
-	// Wait to receive a successful authentication message
-	_, authMsg, err := client.Conn.ReadMessage()
-	assert.NoError(t, err)
-	var authResponse map[string]any
-	err = json.Unmarshal(authMsg, &authResponse)
+	err := client.Connect()
 	assert.NoError(t, err)
-	assert.False(t, authResponse["success"].(bool), "Authentication failed")
 
 	// Generate a random request ID
 	reqID := randomString(4)
-	// Simulate sending a pong message from server after successful authentication
+	// Simulate the server answering a ping
 	go func() {
-		time.Sleep(3 * time.Second)
-		requestPayload := `{"op":"ping","req_id":"` + reqID + `"}`
-		err = client.Conn.WriteMessage(websocket.TextMessage, []byte(requestPayload))
-		if err != nil {
-			t.Errorf("Error sending pong message: %v", err)
-			return
-		}
+		time.Sleep(100 * time.Millisecond)
+		s.Publish(`{"success":true,"ret_msg":"pong","conn_id":"1","req_id":"` + reqID + `","op":"ping"}`)
 	}()
 
 	// Now test receiving a pong message
@@ -136,17 +135,43 @@ func TestClient_Receive(t *testing.T) {
 // TestClient_Close verifies the Close function closes the connection correctly.
 // It tests if the function closes the WebSocket connection and sets the appropriate flag.
 func TestClient_Close(t *testing.T) {
-	apiKey := testnetAPIKey
-	apiSecret := testnetAPISecret
-	client, err := NewPrivateClient(apiKey, apiSecret, true, maxActiveTime, "usdt_contract")
-	assert.NoError(t, err)
-
-	client.wsURL = testnetBaseURL + "/private"
+	client, _ := newLocalPrivateClient(t)
 
-	err = client.Connect()
+	err := client.Connect()
 	assert.NoError(t, err)
 	assert.NotNil(t, client.Conn)
 
 	client.Close()
 	assert.True(t, client.isClosed)
+	assert.Error(t, client.Send([]byte(`{"op":"ping"}`)))
+}
+
+// TestClient_URL verifies the stream URLs follow the client's environment.
+func TestClient_URL(t *testing.T) {
+	public, err := NewPublicClient(true, "spot")
+	assert.NoError(t, err)
+	assert.Equal(t, "wss://stream-testnet.bybit.com/v5/public/spot", public.URL())
+
+	public.Environment = rest.Demo
+	assert.Equal(t, "wss://stream.bybit.com/v5/public/spot", public.URL())
+
+	m := NewManagerWithEnvironment(testnetAPIKey, testnetAPISecret, rest.Demo)
+	defer m.Close()
+	assert.Equal(t, "wss://stream-demo.bybit.com/v5/private", m.Private().URL())
+	assert.Equal(t, rest.Demo, m.Environment())
+}
+
+// TestClient_LogsRedactCredentials verifies credentials never reach the logger.
+func TestClient_LogsRedactCredentials(t *testing.T) {
+	client, _ := newLocalPrivateClient(t)
+	client.APIKey, client.APISecret = "test-api-key", "test-api-secret"
+	var buf bytes.Buffer
+	client.SetLogger(logger.NewStd(log.New(&buf, "", 0), logger.DEBUG))
+
+	assert.NoError(t, client.Connect())
+	client.Close()
+
+	assert.Contains(t, buf.String(), "authenticating")
+	assert.NotContains(t, buf.String(), "test-api-key")
+	assert.NotContains(t, buf.String(), "test-api-secret")
 }
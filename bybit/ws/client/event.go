@@ -0,0 +1,52 @@
+package client
+
+import "encoding/json"
+
+const (
+	// TypeSnapshot marks a message carrying the full state of a topic.
+	TypeSnapshot = "snapshot"
+	// TypeDelta marks a message carrying the changes since the previous message.
+	TypeDelta = "delta"
+)
+
+// Event is a stream message whose data has been decoded into T.
+type Event[T any] struct {
+	Topic        string `json:"topic"`
+	Type         string `json:"type"`         // Type is TypeSnapshot or TypeDelta.
+	ID           string `json:"id"`           // ID identifies the message on private streams.
+	TS           int64  `json:"ts"`           // TS is when the data was generated, in milliseconds.
+	CTS          int64  `json:"cts"`          // CTS is the matching engine timestamp, when the topic provides one.
+	CreationTime int64  `json:"creationTime"` // CreationTime is when the message was created, on private streams.
+	Data         T      `json:"data"`
+}
+
+// Decode decodes a raw stream message into an Event.
+func Decode[T any](message []byte) (Event[T], error) {
+	var event Event[T]
+	err := json.Unmarshal(message, &event)
+	return event, err
+}
+
+// Subscribe subscribes to topic and calls handler with every message decoded
+// into an Event[T]. Messages that cannot be decoded are reported through
+// OnConnectionError and skipped.
+func Subscribe[T any](c *Client, topic string, handler func(Event[T])) (*Subscription, error) {
+	return SubscribeTopics(c, []string{topic}, handler)
+}
+
+// SubscribeTopics is like Subscribe for several topics sharing one handler.
+func SubscribeTopics[T any](c *Client, topics []string, handler func(Event[T])) (*Subscription, error) {
+	return c.Subscribe(topics, decoder(c, handler))
+}
+
+// decoder adapts a typed handler to a raw Handler.
+func decoder[T any](c *Client, handler func(Event[T])) Handler {
+	return func(message []byte) {
+		event, err := Decode[T](message)
+		if err != nil {
+			c.handleConnectionError(err)
+			return
+		}
+		handler(event)
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTrade struct {
+	Symbol string `json:"s"`
+	Price  string `json:"p"`
+}
+
+func TestDecode(t *testing.T) {
+	e, err := Decode[[]testTrade]([]byte(`{"topic":"publicTrade.BTCUSDT","type":"snapshot","ts":1672304486868,"cts":1672304486860,"data":[{"s":"BTCUSDT","p":"16578.50"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "publicTrade.BTCUSDT", e.Topic)
+	assert.Equal(t, TypeSnapshot, e.Type)
+	assert.Equal(t, int64(1672304486868), e.TS)
+	assert.Equal(t, int64(1672304486860), e.CTS)
+	assert.Equal(t, []testTrade{{Symbol: "BTCUSDT", Price: "16578.50"}}, e.Data)
+}
+
+func TestSubscribe_Typed(t *testing.T) {
+	s := wstest.NewServer(t)
+	c := newTestClient(t, s, "linear")
+
+	events := make(chan Event[[]testTrade], 1)
+	_, err := Subscribe(c, "publicTrade.BTCUSDT", func(e Event[[]testTrade]) { events <- e })
+	require.NoError(t, err)
+
+	s.Publish(`{"topic":"publicTrade.BTCUSDT","type":"snapshot","ts":1,"data":[{"s":"BTCUSDT","p":"1"}]}`)
+
+	select {
+	case e := <-events:
+		assert.Equal(t, "BTCUSDT", e.Data[0].Symbol)
+	case <-time.After(time.Second):
+		t.Fatal("event was not dispatched")
+	}
+}
+
+func TestStream_ClosedWithContext(t *testing.T) {
+	s := wstest.NewServer(t)
+	c := newTestClient(t, s, "linear")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := NewStream(ctx, c)
+	require.NoError(t, SubscribeStream(stream, []string{"publicTrade.BTCUSDT"}, func(Event[[]testTrade]) {}))
+	assert.Equal(t, []string{"publicTrade.BTCUSDT"}, c.ActiveTopics())
+
+	cancel()
+	assert.Eventually(t, func() bool { return len(c.ActiveTopics()) == 0 }, time.Second, 10*time.Millisecond)
+	_, _, err := stream.Listen()
+	assert.ErrorIs(t, err, ErrStreamStopped)
+}
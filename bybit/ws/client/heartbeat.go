@@ -0,0 +1,111 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrStaleConnection is reported when neither data nor a pong arrived within
+// the heartbeat's stale timeout. The client reconnects when it happens.
+var ErrStaleConnection = errors.New("websocket connection is stale")
+
+// PongOperation is the op Bybit uses in pong frames on the spot and private streams.
+const PongOperation = "pong"
+
+// HeartbeatPolicy configures the ping interval and stale-connection detection.
+type HeartbeatPolicy struct {
+	// Interval is the time between pings.
+	Interval time.Duration
+	// StaleTimeout is how long the connection may go without receiving any
+	// message, pongs included, before it is considered dead; 0 disables it.
+	StaleTimeout time.Duration
+}
+
+// DefaultHeartbeatPolicy is the policy used by new clients.
+var DefaultHeartbeatPolicy = HeartbeatPolicy{
+	Interval:     PingInterval,
+	StaleTimeout: 3 * PingInterval,
+}
+
+// ConnectionStats reports the health of a connection.
+type ConnectionStats struct {
+	Latency     time.Duration // Latency is the round-trip time of the last answered ping.
+	LastPong    time.Time     // LastPong is when the last pong arrived.
+	LastMessage time.Time     // LastMessage is when the last message of any kind arrived.
+	Messages    uint64        // Messages is the number of messages received since the client was created.
+	MessageRate float64       // MessageRate is the number of messages per second over the last ping interval.
+}
+
+// heartbeat tracks pings in flight and the message flow of a connection.
+type heartbeat struct {
+	mu        sync.Mutex
+	pending   map[string]time.Time
+	stats     ConnectionStats
+	rateAt    time.Time
+	rateCount uint64
+}
+
+// pingSent records a ping sent with reqID.
+func (h *heartbeat) pingSent(reqID string, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.pending == nil {
+		h.pending = make(map[string]time.Time)
+	}
+	// Pings that were never answered are dropped once a newer one is sent.
+	for id := range h.pending {
+		delete(h.pending, id)
+	}
+	h.pending[reqID] = at
+}
+
+// received records an incoming message and, if it is a pong, the round trip of its ping.
+func (h *heartbeat) received(env envelope, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.stats.Messages++
+	h.stats.LastMessage = at
+
+	if !env.isPong() {
+		return
+	}
+	h.stats.LastPong = at
+	if sent, ok := h.pending[env.ReqID]; ok {
+		h.stats.Latency = at.Sub(sent)
+		delete(h.pending, env.ReqID)
+	}
+}
+
+// sample updates the message rate since the previous sample.
+func (h *heartbeat) sample(at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.rateAt.IsZero() {
+		if elapsed := at.Sub(h.rateAt).Seconds(); elapsed > 0 {
+			h.stats.MessageRate = float64(h.stats.Messages-h.rateCount) / elapsed
+		}
+	}
+	h.rateAt, h.rateCount = at, h.stats.Messages
+}
+
+func (h *heartbeat) snapshot() ConnectionStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.stats
+}
+
+// isPong reports whether the envelope is an answer to a ping. Linear, inverse
+// and option streams echo the ping op with ret_msg "pong"; spot and private
+// streams answer with op "pong".
+func (e envelope) isPong() bool {
+	return e.Op == PongOperation || (e.Op == PingOperation && e.RetMsg == PongOperation)
+}
+
+// Stats returns the health statistics of the connection.
+func (c *Client) Stats() ConnectionStats {
+	return c.heartbeat.snapshot()
+}
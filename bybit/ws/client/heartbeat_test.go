@@ -0,0 +1,67 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope_IsPong(t *testing.T) {
+	assert.True(t, envelope{Op: "ping", RetMsg: "pong"}.isPong())
+	assert.True(t, envelope{Op: "pong"}.isPong())
+	assert.False(t, envelope{Op: "subscribe", RetMsg: ""}.isPong())
+	assert.False(t, envelope{Topic: "tickers.BTCUSDT"}.isPong())
+}
+
+func TestClient_HeartbeatMeasuresLatency(t *testing.T) {
+	s := wstest.NewServer(t)
+	s.Respond(wstest.Pong)
+
+	c, err := NewPublicClient(true, "linear")
+	require.NoError(t, err)
+	c.SetURL(s.URL())
+	c.Heartbeat = HeartbeatPolicy{Interval: 20 * time.Millisecond, StaleTimeout: time.Second}
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	require.Eventually(t, func() bool {
+		stats := c.Stats()
+		return !stats.LastPong.IsZero() && stats.Latency > 0 && stats.MessageRate > 0
+	}, time.Second, 10*time.Millisecond)
+	assert.NotZero(t, c.Stats().Messages)
+}
+
+func TestClient_StaleConnectionReconnects(t *testing.T) {
+	s := wstest.NewServer(t)
+
+	c, err := NewPublicClient(true, "linear")
+	require.NoError(t, err)
+	c.SetURL(s.URL())
+	c.Heartbeat = HeartbeatPolicy{Interval: time.Hour, StaleTimeout: 50 * time.Millisecond}
+	c.Reconnect = ReconnectPolicy{InitialDelay: time.Millisecond, Multiplier: 1, MaxRetries: 1}
+
+	disconnected := make(chan error, 1)
+	c.OnEvent = func(e ConnectionEvent) {
+		if e.Type == EventDisconnected {
+			select {
+			case disconnected <- e.Err:
+			default:
+			}
+		}
+	}
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	s.Conn(t)
+	select {
+	case err := <-disconnected:
+		assert.True(t, errors.Is(err, ErrStaleConnection))
+	case <-time.After(time.Second):
+		t.Fatal("stale connection was not detected")
+	}
+	s.Conn(t)
+}
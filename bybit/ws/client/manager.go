@@ -0,0 +1,138 @@
+package client
+
+import (
+	"sync"
+
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+	"github.com/cploutarchou/crypto-sdk-suite/logger"
+)
+
+// Manager shares a single Client per WebSocket URL, so every stream on the
+// same endpoint (spot, linear, inverse, option or private) is multiplexed
+// over one connection.
+type Manager struct {
+	mu        sync.Mutex
+	apiKey    string
+	apiSecret string
+	creds     credentials.Provider
+	env       Environment
+	clients   map[string]*Client
+	logger    logger.Interface
+}
+
+// NewManager creates a Manager for the given credentials and network.
+func NewManager(apiKey, apiSecret string, isTestNet bool) *Manager {
+	return NewManagerWithEnvironment(apiKey, apiSecret, rest.EnvironmentFor(isTestNet))
+}
+
+// NewManagerWithEnvironment creates a Manager for the given credentials whose
+// connections target the hosts of env.
+func NewManagerWithEnvironment(apiKey, apiSecret string, env Environment) *Manager {
+	return &Manager{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+		env:       env,
+		clients:   make(map[string]*Client),
+	}
+}
+
+// IsTestNet reports whether the manager's connections target the testnet.
+func (m *Manager) IsTestNet() bool {
+	return m.env == rest.Testnet
+}
+
+// Environment returns the environment the manager's connections target.
+func (m *Manager) Environment() Environment {
+	return m.env
+}
+
+// Public returns the shared public client serving category.
+func (m *Manager) Public(category rest.Category) (*Client, error) {
+	c, err := NewPublicClient(m.IsTestNet(), category)
+	if err != nil {
+		return nil, err
+	}
+	c.Environment = m.env
+	return m.share(c), nil
+}
+
+// Private returns the shared private client.
+func (m *Manager) Private() *Client {
+	c, _ := NewPrivateClient(m.apiKey, m.apiSecret, m.IsTestNet(), "", "")
+	c.Environment = m.env
+	m.mu.Lock()
+	c.Credentials = m.creds
+	m.mu.Unlock()
+	return m.share(c)
+}
+
+// SetCredentials sets the provider private connections opened later read
+// their credentials from when they authenticate, instead of the manager's
+// API key and secret.
+func (m *Manager) SetCredentials(p credentials.Provider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.creds = p
+}
+
+// Register adds an existing client to the manager, so that it is reused for
+// its URL. If a client is already registered for that URL, it is kept and returned.
+func (m *Manager) Register(c *Client) *Client {
+	return m.share(c)
+}
+
+// SetLogger sets the logger of every connection held by the manager and of
+// the ones it opens later.
+func (m *Manager) SetLogger(l logger.Interface) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.logger = l
+	for _, c := range m.clients {
+		c.SetLogger(l)
+	}
+}
+
+// Stats returns the health statistics of every connection, keyed by URL.
+func (m *Manager) Stats() map[string]ConnectionStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make(map[string]ConnectionStats, len(m.clients))
+	for url, c := range m.clients {
+		stats[url] = c.Stats()
+	}
+	return stats
+}
+
+// Close closes every connection held by the manager.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	clients := m.clients
+	m.clients = make(map[string]*Client)
+	m.mu.Unlock()
+
+	for _, c := range clients {
+		c.Close()
+	}
+}
+
+// share returns the client registered for c's URL, registering c if there is none.
+func (m *Manager) share(c *Client) *Client {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	url := c.URL()
+	if existing, ok := m.clients[url]; ok {
+		return existing
+	}
+	if m.logger != nil {
+		c.SetLogger(m.logger)
+	}
+	// The manager holds a reference of its own, so the connection stays open
+	// while streams come and go.
+	c.Acquire()
+	m.clients[url] = c
+	return c
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+)
+
+// OverflowPolicy decides what a stream does with a message when its messages
+// channel is full because the consumer is not keeping up.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until the consumer makes room. It delays the
+	// subscription callbacks and every stream on the connection, including
+	// its acknowledgements and pongs, so the channel must be drained.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered message to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the incoming message.
+	OverflowDropNewest
+	// OverflowConflate keeps only the latest pending message of each topic, so
+	// the consumer always reads the most recent state of every topic.
+	OverflowConflate
+)
+
+// String returns the name of the policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowConflate:
+		return "conflate"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// StreamConfig configures the messages channel of a stream.
+type StreamConfig struct {
+	// Buffer is the capacity of the messages channel; 0 uses the default.
+	Buffer int
+	// Overflow is applied when the messages channel is full.
+	Overflow OverflowPolicy
+}
+
+// DefaultStreamConfig is the configuration of new streams. Its policy never
+// blocks, so streams whose messages channel is not read, because they are
+// only consumed through their callbacks, cannot stall the connection.
+var DefaultStreamConfig = StreamConfig{
+	Buffer:   defaultStreamBuffer,
+	Overflow: OverflowDropOldest,
+}
+
+// conflator holds the latest undelivered message of each topic, in the order
+// the topics first became pending.
+type conflator struct {
+	mu     sync.Mutex
+	latest map[string][]byte
+	order  []string
+	ready  chan struct{}
+}
+
+func newConflator() *conflator {
+	return &conflator{
+		latest: make(map[string][]byte),
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+// put stores msg as the latest message of topic. It reports whether it
+// replaced a message that was never delivered.
+func (c *conflator) put(topic string, msg []byte) bool {
+	c.mu.Lock()
+	_, replaced := c.latest[topic]
+	if !replaced {
+		c.order = append(c.order, topic)
+	}
+	c.latest[topic] = msg
+	c.mu.Unlock()
+
+	select {
+	case c.ready <- struct{}{}:
+	default:
+	}
+	return replaced
+}
+
+// next removes and returns the oldest pending message.
+func (c *conflator) next() ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.order) == 0 {
+		return nil, false
+	}
+	topic := c.order[0]
+	c.order = c.order[1:]
+	msg := c.latest[topic]
+	delete(c.latest, topic)
+	return msg, true
+}
+
+// pump delivers pending messages to out until stop is closed.
+func (c *conflator) pump(out chan<- []byte, stop <-chan struct{}) {
+	for {
+		select {
+		case <-c.ready:
+		case <-stop:
+			return
+		}
+		for {
+			msg, ok := c.next()
+			if !ok {
+				break
+			}
+			select {
+			case out <- msg:
+			case <-stop:
+				return
+			}
+		}
+	}
+}
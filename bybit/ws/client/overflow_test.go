@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStream(t *testing.T, config StreamConfig) *Stream {
+	t.Helper()
+	s := wstest.NewServer(t)
+	stream := NewStream(context.Background(), newTestClient(t, s, "linear"))
+	t.Cleanup(stream.Close)
+	require.NoError(t, stream.Configure(config))
+	return stream
+}
+
+func message(topic string, n int) []byte {
+	return []byte(fmt.Sprintf(`{"topic":%q,"ts":%d}`, topic, n))
+}
+
+func drain(t *testing.T, stream *Stream, n int) []string {
+	t.Helper()
+	out := make([]string, 0, n)
+	for len(out) < n {
+		select {
+		case msg := <-stream.GetMessagesChan():
+			out = append(out, string(msg))
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d messages", len(out), n)
+		}
+	}
+	return out
+}
+
+func TestStream_OverflowDropNewest(t *testing.T) {
+	stream := newTestStream(t, StreamConfig{Buffer: 2, Overflow: OverflowDropNewest})
+	for i := 1; i <= 4; i++ {
+		stream.forward("a", message("a", i))
+	}
+
+	assert.Equal(t, uint64(2), stream.Dropped())
+	assert.Equal(t, []string{string(message("a", 1)), string(message("a", 2))}, drain(t, stream, 2))
+}
+
+func TestStream_OverflowDropOldest(t *testing.T) {
+	stream := newTestStream(t, StreamConfig{Buffer: 2, Overflow: OverflowDropOldest})
+	for i := 1; i <= 4; i++ {
+		stream.forward("a", message("a", i))
+	}
+
+	assert.Equal(t, uint64(2), stream.Dropped())
+	assert.Equal(t, []string{string(message("a", 3)), string(message("a", 4))}, drain(t, stream, 2))
+}
+
+func TestStream_OverflowConflate(t *testing.T) {
+	stream := newTestStream(t, StreamConfig{Overflow: OverflowConflate})
+	stream.forward("a", message("a", 1))
+	// The pump may already hold the first message; wait until it does so the
+	// rest of the test is deterministic.
+	time.Sleep(20 * time.Millisecond)
+	for i := 2; i <= 4; i++ {
+		stream.forward("a", message("a", i))
+	}
+	stream.forward("b", message("b", 1))
+
+	assert.Equal(t, uint64(2), stream.Dropped())
+	assert.Equal(t, []string{
+		string(message("a", 1)),
+		string(message("a", 4)),
+		string(message("b", 1)),
+	}, drain(t, stream, 3))
+}
+
+func TestStream_ConfigureAfterSubscribe(t *testing.T) {
+	stream := newTestStream(t, DefaultStreamConfig)
+	require.NoError(t, SubscribeStream(stream, []string{"publicTrade.BTCUSDT"}, func(Event[[]testTrade]) {}))
+
+	assert.ErrorIs(t, stream.Configure(StreamConfig{Overflow: OverflowDropNewest}), ErrStreamConfigured)
+	assert.Equal(t, OverflowDropOldest, stream.Config().Overflow)
+}
+
+func TestStream_UndrainedDoesNotStallConnection(t *testing.T) {
+	s := wstest.NewServer(t)
+	m := NewManager("", "", true)
+	t.Cleanup(m.Close)
+	c := m.Register(newTestClient(t, s, "linear"))
+
+	// The messages channel of idle is never read.
+	idle := NewStream(context.Background(), c)
+	t.Cleanup(idle.Close)
+	require.NoError(t, SubscribeStream(idle, []string{"publicTrade.BTCUSDT"}, func(Event[[]testTrade]) {}))
+
+	received := make(chan struct{}, 1)
+	other := NewStream(context.Background(), c)
+	t.Cleanup(other.Close)
+	require.NoError(t, SubscribeStream(other, []string{"publicTrade.ETHUSDT"}, func(Event[[]testTrade]) {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+	}))
+
+	for i := 0; i < 2*defaultStreamBuffer; i++ {
+		s.Publish(`{"topic":"publicTrade.BTCUSDT","data":[]}`)
+	}
+	s.Publish(`{"topic":"publicTrade.ETHUSDT","data":[]}`)
+
+	select {
+	case <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("an undrained stream stalled the connection")
+	}
+	assert.Eventually(t, func() bool {
+		return idle.Dropped() == defaultStreamBuffer
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/internal/backoff"
+	"github.com/gorilla/websocket"
+)
+
+// ReconnectPolicy configures how the client reconnects after the connection drops.
+// The delay before attempt n is InitialDelay * Multiplier^(n-1), capped at
+// MaxDelay and randomized by ±Jitter (a fraction between 0 and 1).
+type ReconnectPolicy = backoff.Policy
+
+// DefaultReconnectPolicy is the policy used by new clients.
+var DefaultReconnectPolicy = backoff.DefaultPolicy
+
+// ConnectionEventType identifies a change in the state of the connection.
+type ConnectionEventType string
+
+const (
+	EventDisconnected ConnectionEventType = "disconnected"
+	EventReconnecting ConnectionEventType = "reconnecting"
+	EventReconnected  ConnectionEventType = "reconnected"
+	EventResubscribed ConnectionEventType = "resubscribed"
+	EventGaveUp       ConnectionEventType = "gave_up"
+)
+
+// ConnectionEvent describes a change in the state of the connection.
+type ConnectionEvent struct {
+	Type    ConnectionEventType
+	Attempt int           // Attempt is the reconnection attempt, for reconnecting and reconnected events.
+	Delay   time.Duration // Delay is the wait before the attempt, for reconnecting events.
+	Topics  []string      // Topics are the topics replayed, for resubscribed events.
+	Err     error         // Err is the error that caused the event, if any.
+}
+
+// emit delivers event to the OnEvent callback.
+func (c *Client) emit(event ConnectionEvent) {
+	if c.OnEvent != nil {
+		c.OnEvent(event)
+	}
+}
+
+// handleReconnection replaces a failed connection. Only the first caller for a
+// given connection reconnects; later calls for the same connection are ignored.
+func (c *Client) handleReconnection(failed *websocket.Conn, cause error) {
+	c.connLock.Lock()
+	if c.isClosed || c.Conn != failed {
+		c.connLock.Unlock()
+		return // No need to reconnect if the client is intentionally closed or already reconnected
+	}
+	c.Conn = nil
+	c.connLock.Unlock()
+	_ = failed.Close()
+
+	c.emit(ConnectionEvent{Type: EventDisconnected, Err: cause})
+	c.reconnect()
+}
+
+// reconnect dials again following the reconnect policy and replays the
+// active subscriptions. An attempt only succeeds once the replay does.
+func (c *Client) reconnect() {
+	policy := c.Reconnect
+	_, err := policy.Retry(c.done, false, func(attempt int, delay time.Duration) {
+		c.emit(ConnectionEvent{Type: EventReconnecting, Attempt: attempt, Delay: delay})
+		c.log().Info("reconnecting", "attempt", attempt, "delay", delay)
+	}, func(attempt int) error {
+		if err := c.Connect(); err != nil {
+			c.log().Warn("reconnection attempt failed", "attempt", attempt, "error", err)
+			return err
+		}
+		c.log().Info("reconnected", "attempt", attempt)
+		c.emit(ConnectionEvent{Type: EventReconnected, Attempt: attempt})
+		if err := c.resubscribe(); err != nil {
+			// A connection without its subscriptions would stay silent, so it
+			// is dropped and the next attempt replays them on a new one.
+			c.handleConnectionError(fmt.Errorf("failed to resubscribe: %w", err))
+			if conn := c.currentConn(); conn != nil {
+				c.dropConn(conn)
+			}
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, backoff.ErrGaveUp) {
+		c.log().Error("giving up reconnecting", "attempts", policy.MaxRetries)
+		c.emit(ConnectionEvent{Type: EventGaveUp, Attempt: policy.MaxRetries})
+	}
+}
+
+// resubscribe replays every active topic on the current connection.
+func (c *Client) resubscribe() error {
+	topics := c.ActiveTopics()
+	if len(topics) == 0 {
+		return nil
+	}
+	if err := c.sendOp(SubscribeOperation, topics); err != nil {
+		return err
+	}
+	c.emit(ConnectionEvent{Type: EventResubscribed, Topics: topics})
+	return nil
+}
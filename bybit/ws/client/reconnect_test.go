@@ -0,0 +1,126 @@
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ReconnectResubscribes(t *testing.T) {
+	s := wstest.NewServer(t)
+	c, err := NewPublicClient(true, "linear")
+	require.NoError(t, err)
+	c.SetURL(s.URL())
+	c.Reconnect = ReconnectPolicy{InitialDelay: 10 * time.Millisecond, Multiplier: 2, MaxRetries: 3}
+
+	var (
+		mu     sync.Mutex
+		events []ConnectionEventType
+	)
+	c.OnEvent = func(e ConnectionEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e.Type)
+	}
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	_, err = c.Subscribe([]string{"tickers.BTCUSDT"}, func([]byte) {})
+	require.NoError(t, err)
+
+	first := s.Conn(t)
+	require.Eventually(t, func() bool {
+		return len(s.Requests(SubscribeOperation)) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, first.Close())
+
+	s.Conn(t)
+
+	assert.Eventually(t, func() bool {
+		return len(s.Requests(SubscribeOperation)) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"tickers.BTCUSDT"}, s.Requests(SubscribeOperation)[1].Args)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) == 4
+	}, time.Second, 10*time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []ConnectionEventType{EventDisconnected, EventReconnecting, EventReconnected, EventResubscribed}, events)
+	mu.Unlock()
+}
+
+func TestClient_ReconnectRetriesRejectedReplay(t *testing.T) {
+	s := wstest.NewServer(t)
+	var subscribes int
+	s.Respond(func(req wstest.Request) []string {
+		if req.Op != SubscribeOperation {
+			return nil
+		}
+		subscribes++
+		// The first replay, after the first subscription, is rejected.
+		return []string{wstest.Reply(req, subscribes != 2, "rejected")}
+	})
+	c, err := NewPublicClient(true, "linear")
+	require.NoError(t, err)
+	c.SetURL(s.URL())
+	c.Reconnect = ReconnectPolicy{InitialDelay: 10 * time.Millisecond, Multiplier: 1, MaxRetries: 3}
+
+	resubscribed := make(chan ConnectionEvent, 1)
+	c.OnEvent = func(e ConnectionEvent) {
+		if e.Type == EventResubscribed {
+			resubscribed <- e
+		}
+	}
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	_, err = c.Subscribe([]string{"tickers.BTCUSDT"}, func([]byte) {})
+	require.NoError(t, err)
+	require.NoError(t, s.Conn(t).Close())
+
+	// The connection the replay was rejected on is dropped, and the replay
+	// succeeds on the next one.
+	s.Conn(t)
+	s.Conn(t)
+	select {
+	case e := <-resubscribed:
+		assert.Equal(t, []string{"tickers.BTCUSDT"}, e.Topics)
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not resubscribe")
+	}
+	assert.Len(t, s.Requests(SubscribeOperation), 3)
+}
+
+func TestClient_ReconnectGivesUp(t *testing.T) {
+	s := wstest.NewServer(t)
+	c, err := NewPublicClient(true, "linear")
+	require.NoError(t, err)
+	c.SetURL(s.URL())
+	c.Reconnect = ReconnectPolicy{InitialDelay: time.Millisecond, Multiplier: 1, MaxRetries: 2}
+
+	gaveUp := make(chan ConnectionEvent, 1)
+	c.OnEvent = func(e ConnectionEvent) {
+		if e.Type == EventGaveUp {
+			gaveUp <- e
+		}
+	}
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	first := s.Conn(t)
+	s.Close()
+	_ = first.Close()
+
+	select {
+	case e := <-gaveUp:
+		assert.Equal(t, 2, e.Attempt)
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not give up")
+	}
+}
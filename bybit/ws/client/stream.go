@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrStreamStopped is returned by Stream.Listen once the stream is stopped.
+var ErrStreamStopped = errors.New("stream stopped")
+
+// ErrStreamConfigured is returned by Stream.Configure once the stream has subscriptions.
+var ErrStreamConfigured = errors.New("stream cannot be configured after subscribing")
+
+// defaultStreamBuffer is the size of a stream's messages channel.
+const defaultStreamBuffer = 100
+
+// Stream holds what every topic stream shares: its subscriptions on the
+// connection, the channel of raw messages and its lifecycle. Streams are
+// closed when their context is cancelled or Close is called.
+type Stream struct {
+	client        *Client
+	config        StreamConfig
+	messages      chan []byte
+	conflator     *conflator
+	dropped       atomic.Uint64
+	stop          chan struct{}
+	mu            sync.Mutex
+	subscriptions []*Subscription
+	stopContext   func() bool
+	stopOnce      sync.Once
+	closeOnce     sync.Once
+}
+
+// NewStream creates a Stream on c configured with DefaultStreamConfig. The
+// stream holds a reference to c until it is closed.
+func NewStream(ctx context.Context, c *Client) *Stream {
+	c.Acquire()
+	s := &Stream{
+		client: c,
+		stop:   make(chan struct{}),
+	}
+	s.mu.Lock()
+	s.configure(DefaultStreamConfig)
+	s.stopContext = context.AfterFunc(ctx, s.Close)
+	s.mu.Unlock()
+	return s
+}
+
+// Configure sets the buffer size and overflow policy of the messages channel.
+// It must be called before the first subscription; the channel returned by an
+// earlier call to GetMessagesChan is not used anymore.
+func (s *Stream) Configure(config StreamConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.subscriptions) > 0 {
+		return ErrStreamConfigured
+	}
+	s.configure(config)
+	return nil
+}
+
+// configure replaces the messages channel. s.mu must be held.
+func (s *Stream) configure(config StreamConfig) {
+	if config.Buffer <= 0 {
+		config.Buffer = defaultStreamBuffer
+	}
+	s.config = config
+	s.conflator = nil
+
+	if config.Overflow == OverflowConflate {
+		// Pending messages are held by the conflator, one per topic, so the
+		// channel itself does not buffer stale ones.
+		s.messages = make(chan []byte)
+		s.conflator = newConflator()
+		go s.conflator.pump(s.messages, s.stop)
+		return
+	}
+	s.messages = make(chan []byte, config.Buffer)
+}
+
+// Config returns the configuration of the messages channel.
+func (s *Stream) Config() StreamConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config
+}
+
+// Dropped returns the number of messages discarded by the overflow policy. A
+// growing count means the consumer of the messages channel is falling behind.
+func (s *Stream) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Client returns the client the stream is built on.
+func (s *Stream) Client() *Client {
+	return s.client
+}
+
+// SubscribeStream subscribes s to topics. Every message is passed to the
+// stream's messages channel and then, decoded, to handler.
+func SubscribeStream[T any](s *Stream, topics []string, handler func(Event[T])) error {
+	sub, err := s.client.Subscribe(topics, func(message []byte) {
+		event, err := Decode[T](message)
+		s.forward(event.Topic, message)
+		if err != nil {
+			s.client.handleConnectionError(err)
+			return
+		}
+		handler(event)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.subscriptions = append(s.subscriptions, sub)
+	s.mu.Unlock()
+	return nil
+}
+
+// Unsubscribe releases the given topics, or every topic of the stream if none are given.
+func (s *Stream) Unsubscribe(topics ...string) error {
+	s.mu.Lock()
+	subscriptions := append([]*Subscription(nil), s.subscriptions...)
+	s.mu.Unlock()
+
+	for _, sub := range subscriptions {
+		if err := sub.Unsubscribe(topics...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Listen returns the next message received on the stream's topics.
+func (s *Stream) Listen() (int, []byte, error) {
+	select {
+	case msg := <-s.GetMessagesChan():
+		return WSMessageText, msg, nil
+	case <-s.stop:
+		return 0, nil, ErrStreamStopped
+	}
+}
+
+// GetMessagesChan returns the channel of raw messages received on the stream's topics.
+func (s *Stream) GetMessagesChan() <-chan []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messages
+}
+
+// Stop stops delivering messages to the messages channel.
+func (s *Stream) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+}
+
+// Close stops the stream, unsubscribes from its topics and releases the connection.
+func (s *Stream) Close() {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		stopContext := s.stopContext
+		s.mu.Unlock()
+		if stopContext != nil {
+			stopContext()
+		}
+		s.Stop()
+		_ = s.Unsubscribe()
+		s.client.Release()
+	})
+}
+
+// forward passes a message of topic to the messages channel, applying the
+// overflow policy when the channel is full.
+func (s *Stream) forward(topic string, msg []byte) {
+	s.mu.Lock()
+	messages, policy, conflator := s.messages, s.config.Overflow, s.conflator
+	s.mu.Unlock()
+
+	switch policy {
+	case OverflowConflate:
+		if conflator.put(topic, msg) {
+			s.dropped.Add(1)
+		}
+	case OverflowDropNewest:
+		select {
+		case messages <- msg:
+		default:
+			s.dropped.Add(1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case messages <- msg:
+				return
+			default:
+			}
+			select {
+			case <-messages:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case messages <- msg:
+		case <-s.stop:
+		}
+	}
+}
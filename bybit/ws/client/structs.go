@@ -5,6 +5,7 @@ package client
 // handle various response formats for both public and private messages.
 
 import (
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
 	"github.com/gorilla/websocket"
 )
 
@@ -63,8 +64,9 @@ type SuccessResponse struct {
 	RetMsg  string `json:"ret_msg"` // RetMsg provides details on the return message of the request
 }
 
-// Environment denotes the environment in which the Bybit API operates (e.g., "production", "development").
-type Environment string
+// Environment is the set of Bybit hosts the client connects to. It is shared
+// with the REST client so both can be pointed at the same environment.
+type Environment = rest.Environment
 
 // SubChannel represents a sub-channel for Bybit API's WebSocket communications.
 type SubChannel string
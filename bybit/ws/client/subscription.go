@@ -0,0 +1,396 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	SubscribeOperation   = "subscribe"
+	UnsubscribeOperation = "unsubscribe"
+
+	// spotMaxArgs is the maximum number of topics Bybit accepts in a single
+	// subscribe request on the spot stream.
+	spotMaxArgs = 10
+	// maxArgsLength is the maximum combined length of the topics in a single
+	// request on the other streams.
+	maxArgsLength = 21000
+)
+
+// Handler processes a raw message received on the connection.
+type Handler func(message []byte)
+
+// opRequest is the frame used for subscribe and unsubscribe operations.
+type opRequest struct {
+	Op    string   `json:"op"`
+	ReqID string   `json:"req_id,omitempty"`
+	Args  []string `json:"args"`
+}
+
+// envelope holds the fields used to route an incoming message.
+type envelope struct {
+	Topic   string `json:"topic"`
+	Op      string `json:"op"`
+	ReqID   string `json:"req_id"`
+	Success *bool  `json:"success"`
+	RetMsg  string `json:"ret_msg"`
+}
+
+// pendingTopic is a topic whose subscribe request awaits its acknowledgement.
+// done is closed once it is settled, with err set if it was not subscribed.
+type pendingTopic struct {
+	done chan struct{}
+	err  error
+}
+
+// Subscription is a handle to the topics registered through Client.Subscribe.
+// Topics are reference counted on the connection: the server is only asked to
+// unsubscribe once the last subscription holding a topic is released.
+type Subscription struct {
+	client *Client
+	id     uint64
+	mu     sync.Mutex
+	topics []string
+}
+
+// Topics returns the topics still held by the subscription.
+func (s *Subscription) Topics() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.topics...)
+}
+
+// Unsubscribe releases the given topics, or every topic held by the
+// subscription if none are given. Topics the subscription does not hold are ignored.
+func (s *Subscription) Unsubscribe(topics ...string) error {
+	s.mu.Lock()
+	var released, kept []string
+	for _, topic := range s.topics {
+		if len(topics) == 0 || contains(topics, topic) {
+			released = append(released, topic)
+		} else {
+			kept = append(kept, topic)
+		}
+	}
+	s.topics = kept
+	s.mu.Unlock()
+
+	if len(released) == 0 {
+		return nil
+	}
+	return s.client.release(s.id, released)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe registers handler for the given topics and subscribes to the ones
+// that are not yet active on the connection. Requests are split in batches that
+// respect Bybit's per-request limits, and Subscribe returns once the server has
+// acknowledged every batch. Topics another call is still subscribing to are
+// waited for, and share the outcome of its request. A rejected batch is
+// reported as an *OpError; the handler is then released from every topic,
+// and topics the server rejected are dropped.
+func (c *Client) Subscribe(topics []string, handler Handler) (*Subscription, error) {
+	if handler == nil {
+		return nil, errors.New("handler must not be nil")
+	}
+
+	c.subsMu.Lock()
+	if c.topics == nil {
+		c.topics = make(map[string]map[uint64]Handler)
+	}
+	if c.pending == nil {
+		c.pending = make(map[string]*pendingTopic)
+	}
+	c.nextID++
+	id := c.nextID
+	var (
+		fresh   []string
+		awaited []*pendingTopic
+	)
+	for _, topic := range topics {
+		handlers, ok := c.topics[topic]
+		if !ok {
+			handlers = make(map[uint64]Handler)
+			c.topics[topic] = handlers
+			c.pending[topic] = &pendingTopic{done: make(chan struct{})}
+			fresh = append(fresh, topic)
+		} else if p, ok := c.pending[topic]; ok {
+			awaited = append(awaited, p)
+		}
+		handlers[id] = handler
+	}
+	c.subsMu.Unlock()
+
+	err := c.subscribe(fresh)
+	for _, p := range awaited {
+		<-p.done
+		if err == nil {
+			err = p.err
+		}
+	}
+	if err != nil {
+		// Topics the server accepted are unsubscribed if no one else holds them.
+		if releaseErr := c.release(id, topics); releaseErr != nil {
+			c.log().Warn("failed to release topics of a failed subscription", "error", releaseErr)
+		}
+		return nil, err
+	}
+
+	return &Subscription{client: c, id: id, topics: append([]string(nil), topics...)}, nil
+}
+
+// subscribe sends the subscribe requests of topics, which must be pending, and
+// settles them. Topics the server rejected, or that were never sent, are
+// dropped along with every handler registered on them.
+func (c *Client) subscribe(topics []string) error {
+	if len(topics) == 0 {
+		return nil
+	}
+	err := c.ensureConnected()
+	rejected := topics
+	if err == nil {
+		rejected = nil
+		batches := batchTopics(topics, c.maxArgs(), maxArgsLength)
+		for i, batch := range batches {
+			if err = c.sendBatch(SubscribeOperation, batch); err != nil {
+				rejected = rejectedTopics(batch, err)
+				for _, unsent := range batches[i+1:] {
+					rejected = append(rejected, unsent...)
+				}
+				break
+			}
+		}
+	}
+
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	for _, topic := range topics {
+		p := c.pending[topic]
+		delete(c.pending, topic)
+		if contains(rejected, topic) {
+			delete(c.topics, topic)
+			p.err = err
+		}
+		close(p.done)
+	}
+	return err
+}
+
+// rejectedTopics returns the topics of batch the server rejected with err. A
+// rejection names the topics at fault in its reason; when it names none, or
+// the request failed otherwise, the whole batch is taken as rejected.
+func rejectedTopics(batch []string, err error) []string {
+	var opErr *OpError
+	if !errors.As(err, &opErr) {
+		return batch
+	}
+	var named []string
+	for _, topic := range batch {
+		if strings.Contains(opErr.RetMsg, topic) {
+			named = append(named, topic)
+		}
+	}
+	if len(named) == 0 {
+		return batch
+	}
+	return named
+}
+
+// ActiveTopics returns the topics currently subscribed on the connection.
+func (c *Client) ActiveTopics() []string {
+	c.subsMu.RLock()
+	defer c.subsMu.RUnlock()
+
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// AddListener registers a handler that receives every message read from the
+// connection. The returned function removes the listener.
+func (c *Client) AddListener(handler Handler) (remove func()) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.listeners == nil {
+		c.listeners = make(map[uint64]Handler)
+	}
+	c.nextID++
+	id := c.nextID
+	c.listeners[id] = handler
+
+	return func() {
+		c.subsMu.Lock()
+		defer c.subsMu.Unlock()
+		delete(c.listeners, id)
+	}
+}
+
+// Acquire registers a user of the client. Streams acquire the client they are
+// built on and release it when closed.
+func (c *Client) Acquire() {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	c.refs++
+}
+
+// Release drops a user of the client and closes the connection once the last user is gone.
+func (c *Client) Release() {
+	c.subsMu.Lock()
+	c.refs--
+	last := c.refs <= 0
+	c.subsMu.Unlock()
+
+	if last {
+		c.Close()
+	}
+}
+
+// release removes the handlers of a subscription and unsubscribes from the
+// topics that have no handlers left.
+func (c *Client) release(id uint64, topics []string) error {
+	return c.sendOp(UnsubscribeOperation, c.removeHandlers(id, topics))
+}
+
+// removeHandlers removes the handler registered under id and returns the topics left without handlers.
+func (c *Client) removeHandlers(id uint64, topics []string) []string {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	var orphaned []string
+	for _, topic := range topics {
+		handlers, ok := c.topics[topic]
+		if !ok {
+			continue
+		}
+		delete(handlers, id)
+		if len(handlers) == 0 {
+			delete(c.topics, topic)
+			orphaned = append(orphaned, topic)
+		}
+	}
+	return orphaned
+}
+
+// sendOp sends op for the topics, split into batches, and waits for each
+// batch to be acknowledged.
+func (c *Client) sendOp(op string, topics []string) error {
+	if len(topics) == 0 {
+		return nil
+	}
+	if err := c.ensureConnected(); err != nil {
+		return err
+	}
+	for _, batch := range batchTopics(topics, c.maxArgs(), maxArgsLength) {
+		if err := c.sendBatch(op, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendBatch sends op for a batch of topics and waits for its acknowledgement.
+func (c *Client) sendBatch(op string, batch []string) error {
+	return c.request(op, batch, func(reqID string) ([]byte, error) {
+		return json.Marshal(opRequest{Op: op, ReqID: reqID, Args: batch})
+	})
+}
+
+// maxArgs returns the maximum number of topics per request, or 0 if unlimited.
+func (c *Client) maxArgs() int {
+	if c.Channel == Public && c.Category == rest.CategorySpot {
+		return spotMaxArgs
+	}
+	return 0
+}
+
+// batchTopics splits topics into batches of at most maxArgs topics whose
+// combined length does not exceed maxLength. A maxArgs of 0 means no limit.
+func batchTopics(topics []string, maxArgs, maxLength int) [][]string {
+	var (
+		batches [][]string
+		current []string
+		length  int
+	)
+	for _, topic := range topics {
+		if len(current) > 0 && ((maxArgs > 0 && len(current) == maxArgs) || length+len(topic) > maxLength) {
+			batches = append(batches, current)
+			current, length = nil, 0
+		}
+		current = append(current, topic)
+		length += len(topic)
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// readLoop reads messages from conn and dispatches them until the connection
+// fails or goes without messages for longer than the heartbeat's stale timeout.
+func (c *Client) readLoop(conn *websocket.Conn) {
+	stale := c.Heartbeat.StaleTimeout
+	for {
+		if stale > 0 {
+			_ = conn.SetReadDeadline(time.Now().Add(stale))
+		}
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			c.connLock.Lock()
+			closed := c.isClosed
+			c.connLock.Unlock()
+			if !closed {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					err = fmt.Errorf("%w: %v", ErrStaleConnection, err)
+				}
+				c.handleConnectionError(err)
+				go c.handleReconnection(conn, err)
+			}
+			return
+		}
+		c.dispatch(message)
+	}
+}
+
+// dispatch delivers message to the listeners and to the handlers of its topic.
+func (c *Client) dispatch(message []byte) {
+	var env envelope
+	_ = json.Unmarshal(message, &env)
+	c.heartbeat.received(env, time.Now())
+	c.acks.resolve(env)
+
+	c.subsMu.RLock()
+	handlers := make([]Handler, 0, len(c.listeners)+len(c.topics[env.Topic]))
+	for _, h := range c.listeners {
+		handlers = append(handlers, h)
+	}
+	if env.Topic != "" {
+		for _, h := range c.topics[env.Topic] {
+			handlers = append(handlers, h)
+		}
+	}
+	c.subsMu.RUnlock()
+
+	for _, h := range handlers {
+		h(message)
+	}
+}
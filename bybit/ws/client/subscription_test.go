@@ -0,0 +1,166 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, s *wstest.Server, category rest.Category) *Client {
+	t.Helper()
+	c, err := NewPublicClient(true, category)
+	require.NoError(t, err)
+	c.SetURL(s.URL())
+	require.NoError(t, c.Connect())
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestClient_SubscribeReferenceCounting(t *testing.T) {
+	s := wstest.NewServer(t)
+	c := newTestClient(t, s, "linear")
+
+	first, err := c.Subscribe([]string{"tickers.BTCUSDT"}, func([]byte) {})
+	require.NoError(t, err)
+	second, err := c.Subscribe([]string{"tickers.BTCUSDT", "tickers.ETHUSDT"}, func([]byte) {})
+	require.NoError(t, err)
+
+	require.NoError(t, first.Unsubscribe())
+	assert.ElementsMatch(t, []string{"tickers.BTCUSDT", "tickers.ETHUSDT"}, c.ActiveTopics())
+
+	require.NoError(t, second.Unsubscribe("tickers.ETHUSDT"))
+	assert.Equal(t, []string{"tickers.BTCUSDT"}, second.Topics())
+	require.NoError(t, second.Unsubscribe())
+	assert.Empty(t, c.ActiveTopics())
+
+	assert.Eventually(t, func() bool {
+		return len(s.Requests(SubscribeOperation)) == 2 && len(s.Requests(UnsubscribeOperation)) == 2
+	}, time.Second, 10*time.Millisecond)
+	subs := s.Requests(SubscribeOperation)
+	assert.Equal(t, []string{"tickers.BTCUSDT"}, subs[0].Args)
+	assert.Equal(t, []string{"tickers.ETHUSDT"}, subs[1].Args)
+}
+
+func TestClient_SubscribeWaitsForPendingAck(t *testing.T) {
+	s := wstest.NewServer(t)
+	pending := make(chan wstest.Request, 1)
+	s.Respond(func(req wstest.Request) []string {
+		if req.Op == SubscribeOperation {
+			pending <- req
+			return []string{} // The ack is sent by the test.
+		}
+		return nil
+	})
+	c := newTestClient(t, s, "linear")
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.Subscribe([]string{"tickers.BTCUSDT"}, func([]byte) {})
+		first <- err
+	}()
+	req := <-pending
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := c.Subscribe([]string{"tickers.BTCUSDT"}, func([]byte) {})
+		second <- err
+	}()
+	select {
+	case err := <-second:
+		t.Fatalf("second subscribe returned %v before the ack", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.Publish(wstest.Reply(req, false, "rejected"))
+	var opErr *OpError
+	require.ErrorAs(t, <-first, &opErr)
+	require.ErrorAs(t, <-second, &opErr)
+	assert.Empty(t, c.ActiveTopics())
+	assert.Len(t, s.Requests(SubscribeOperation), 1)
+}
+
+func TestClient_SubscribeRollsBackRejectedTopics(t *testing.T) {
+	s := wstest.NewServer(t)
+	s.Respond(func(req wstest.Request) []string {
+		if req.Op == SubscribeOperation && len(req.Args) > 1 {
+			return []string{wstest.Reply(req, false, "error:handler not found,topic:tickers.BADUSDT")}
+		}
+		return nil
+	})
+	c := newTestClient(t, s, "linear")
+
+	_, err := c.Subscribe([]string{"tickers.BTCUSDT"}, func([]byte) {})
+	require.NoError(t, err)
+	_, err = c.Subscribe([]string{"tickers.BTCUSDT", "tickers.ETHUSDT", "tickers.BADUSDT"}, func([]byte) {})
+	var opErr *OpError
+	require.ErrorAs(t, err, &opErr)
+
+	// ETHUSDT was accepted by the server, so it is unsubscribed rather than
+	// forgotten; BADUSDT was rejected and is only dropped.
+	assert.Equal(t, []string{"tickers.BTCUSDT"}, c.ActiveTopics())
+	require.Len(t, s.Requests(UnsubscribeOperation), 1)
+	assert.Equal(t, []string{"tickers.ETHUSDT"}, s.Requests(UnsubscribeOperation)[0].Args)
+}
+
+func TestClient_DispatchByTopic(t *testing.T) {
+	s := wstest.NewServer(t)
+	c := newTestClient(t, s, "linear")
+
+	btc := make(chan []byte, 1)
+	eth := make(chan []byte, 1)
+	_, err := c.Subscribe([]string{"tickers.BTCUSDT"}, func(msg []byte) { btc <- msg })
+	require.NoError(t, err)
+	_, err = c.Subscribe([]string{"tickers.ETHUSDT"}, func(msg []byte) { eth <- msg })
+	require.NoError(t, err)
+
+	s.Publish(`{"topic":"tickers.ETHUSDT","type":"snapshot"}`)
+
+	select {
+	case msg := <-eth:
+		assert.Contains(t, string(msg), "ETHUSDT")
+	case <-time.After(time.Second):
+		t.Fatal("message was not dispatched")
+	}
+	assert.Empty(t, btc)
+}
+
+func TestBatchTopics(t *testing.T) {
+	topics := make([]string, 25)
+	for i := range topics {
+		topics[i] = "orderbook.1.BTCUSDT"
+	}
+
+	batches := batchTopics(topics, spotMaxArgs, maxArgsLength)
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0], 10)
+	assert.Len(t, batches[2], 5)
+
+	batches = batchTopics(topics, 0, 60)
+	require.Len(t, batches, 9)
+	assert.Len(t, batches[0], 3)
+
+	assert.Empty(t, batchTopics(nil, spotMaxArgs, maxArgsLength))
+}
+
+func TestManager_SharesConnectionPerURL(t *testing.T) {
+	m := NewManager("", "", true)
+	defer m.Close()
+
+	public := func(category rest.Category) *Client {
+		c, err := m.Public(category)
+		require.NoError(t, err)
+		return c
+	}
+
+	assert.Same(t, public("usdt_contract"), public(rest.CategoryLinear))
+	assert.NotSame(t, public(rest.CategorySpot), public(rest.CategoryLinear))
+	assert.Same(t, m.Private(), m.Private())
+	assert.True(t, public(rest.CategorySpot).IsTestNet)
+
+	_, err := m.Public("futures")
+	assert.Error(t, err)
+}
@@ -0,0 +1,175 @@
+// Package wstest provides a local WebSocket server that mimics the Bybit v5
+// streams closely enough to test the WebSocket clients without network access.
+package wstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// connTimeout bounds how long Conn waits for a client to connect.
+const connTimeout = 2 * time.Second
+
+// Request is an operation frame received from a client.
+type Request struct {
+	Op    string   `json:"op"`
+	ReqID string   `json:"req_id"`
+	Args  []string `json:"args"`
+}
+
+// Server is a local WebSocket server that records the requests it receives.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	requests  []Request
+	active    []*websocket.Conn
+	conns     chan *websocket.Conn
+	responder func(Request) []string
+}
+
+// NewServer starts a Server that is shut down when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{conns: make(chan *websocket.Conn, 16)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.active = append(s.active, conn)
+		s.mu.Unlock()
+		s.conns <- conn
+		s.serve(conn)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// URL returns the ws:// URL of the server.
+func (s *Server) URL() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+// Conn waits for the next client connection and returns the server side of it.
+func (s *Server) Conn(t testing.TB) *websocket.Conn {
+	t.Helper()
+	select {
+	case conn := <-s.conns:
+		return conn
+	case <-time.After(connTimeout):
+		t.Fatal("wstest: no client connected")
+		return nil
+	}
+}
+
+// Requests returns the requests received for op, or every request if op is empty.
+func (s *Server) Requests(op string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Request
+	for _, r := range s.requests {
+		if op == "" || r.Op == op {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Respond sets a function that returns the replies to send for each request.
+// Requests the responder returns no replies for are answered by Ack.
+func (s *Server) Respond(responder func(Request) []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responder = responder
+}
+
+// Pong is a responder that answers pings the way Bybit's linear stream does.
+func Pong(req Request) []string {
+	if req.Op != "ping" {
+		return nil
+	}
+	return []string{`{"success":true,"ret_msg":"pong","conn_id":"wstest","req_id":"` + req.ReqID + `","op":"ping"}`}
+}
+
+// Ack is the default responder. It acknowledges subscribe, unsubscribe and
+// auth requests the way Bybit does.
+func Ack(req Request) []string {
+	switch req.Op {
+	case "subscribe", "unsubscribe", "auth":
+		return []string{Reply(req, true, "")}
+	default:
+		return nil
+	}
+}
+
+// Reply returns the acknowledgement of req with the given outcome.
+func Reply(req Request, success bool, retMsg string) string {
+	reply, _ := json.Marshal(map[string]any{
+		"success": success,
+		"ret_msg": retMsg,
+		"conn_id": "wstest",
+		"req_id":  req.ReqID,
+		"op":      req.Op,
+	})
+	return string(reply)
+}
+
+// Publish writes message to every connected client.
+func (s *Server) Publish(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, conn := range s.active {
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(message))
+	}
+}
+
+// Close closes every client connection and shuts the server down.
+func (s *Server) Close() {
+	s.mu.Lock()
+	for _, conn := range s.active {
+		_ = conn.Close()
+	}
+	s.active = nil
+	s.mu.Unlock()
+
+	s.Server.Close()
+}
+
+// serve records the requests received on conn until it is closed.
+func (s *Server) serve(conn *websocket.Conn) {
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req Request
+		if err := json.Unmarshal(msg, &req); err != nil {
+			continue
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		var replies []string
+		if s.responder != nil {
+			replies = s.responder(req)
+		}
+		if replies == nil {
+			replies = Ack(req)
+		}
+		for _, reply := range replies {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(reply))
+		}
+		s.mu.Unlock()
+	}
+}
@@ -1,6 +1,7 @@
 package private
 
 import (
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private/dcp"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private/execution"
@@ -11,77 +12,44 @@ import (
 )
 
 type Private interface {
-	Dcp(category string) dcp.Dcp
-	Execution(category string) execution.Execution
-	Greek(category string) greek.Greek
-	Order(category string) order.Order
-	Position(category string) position.Position
-	Wallet(category string) wallet.Wallet
+	Dcp(category rest.Category) dcp.Dcp
+	Execution(category rest.Category) execution.Execution
+	Greek(category rest.Category) greek.Greek
+	Order(category rest.Category) order.Order
+	Position(category rest.Category) position.Position
+	Wallet(category rest.Category) wallet.Wallet
 }
 
 type implPrivate struct {
-	client *client.Client
-	isTest bool
+	manager *client.Manager
 }
 
-func (i *implPrivate) Dcp(category string) dcp.Dcp {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return dcp.New(cli)
+func (i *implPrivate) Dcp(category rest.Category) dcp.Dcp {
+	return dcp.New(i.manager.Private())
 }
 
-func (i *implPrivate) Execution(category string) execution.Execution {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return execution.New(cli)
+func (i *implPrivate) Execution(category rest.Category) execution.Execution {
+	return execution.New(i.manager.Private())
 }
 
-func (i *implPrivate) Greek(category string) greek.Greek {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return greek.New(cli)
+func (i *implPrivate) Greek(category rest.Category) greek.Greek {
+	return greek.New(i.manager.Private())
 }
 
-func (i *implPrivate) Order(category string) order.Order {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return order.New(cli)
+func (i *implPrivate) Order(category rest.Category) order.Order {
+	return order.New(i.manager.Private())
 }
 
-func (i *implPrivate) Position(category string) position.Position {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return position.New(cli)
+func (i *implPrivate) Position(category rest.Category) position.Position {
+	return position.New(i.manager.Private())
 }
 
-func (i *implPrivate) Wallet(category string) wallet.Wallet {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return wallet.New(cli)
+func (i *implPrivate) Wallet(category rest.Category) wallet.Wallet {
+	return wallet.New(i.manager.Private())
 }
 
-func (i *implPrivate) SetClient(client_ *client.Client) Private {
-	if client_ != nil {
-		return &implPrivate{
-			client: client_,
-			isTest: i.isTest,
-		}
-	} else {
-		return nil
-	}
-}
-func New(wsClient *client.Client, isTestnet bool) Private {
-	return &implPrivate{client: wsClient, isTest: isTestnet}
+// New creates the private streams. All of them share the manager's single
+// private connection, since Bybit serves every private topic from one endpoint.
+func New(manager *client.Manager) Private {
+	return &implPrivate{manager: manager}
 }
@@ -1,7 +1,8 @@
 package kline
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
@@ -22,12 +23,19 @@ type Kline interface {
 	// Listen reads the next message from the kline channel.
 	Listen() (int, []byte, error)
 
-	// Close closes the connection to the kline channel.
+	// Close unsubscribes from the kline topics and releases the connection.
 	Close()
 
 	// GetMessagesChan returns a channel that receives messages from the kline channel.
 	GetMessagesChan() <-chan []byte
 
+	// Configure sets the buffer size and overflow policy of the messages
+	// channel. It must be called before subscribing.
+	Configure(config client.StreamConfig) error
+
+	// Dropped returns the number of messages discarded by the overflow policy.
+	Dropped() uint64
+
 	// Stop stops the kline functionality.
 	Stop()
 }
@@ -55,129 +63,41 @@ type Data struct {
 	Timestamp int64  `json:"timestamp"`
 }
 
-// New creates a new instance of KlineImpl.
-func New(c *client.Client) (Kline, error) {
-	var k klineImpl
-	k.client = c
-	k.Messages = make(chan []byte, 100)
-	k.StopChan = make(chan struct{}, 1)
-	k.isTest = c.IsTestNet
-	err := k.client.Connect()
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect: %w", err)
-	}
-
-	<-k.client.Connected
-	fmt.Println("Connected to WS")
-
-	go k.listenForMessages()
-
-	return &k, nil
-}
-
-type topicCallback struct {
-	callback func(data Data)
+// New creates a new instance of KlineImpl. The connection is established by
+// the first subscription. The stream is closed when ctx is cancelled or Close
+// is called, whichever happens first.
+func New(ctx context.Context, c *client.Client) Kline {
+	return &klineImpl{client.NewStream(ctx, c)}
 }
 
 type klineImpl struct {
-	client         *client.Client
-	Messages       chan []byte
-	StopChan       chan struct{}
-	isTest         bool
-	topicCallbacks map[string]topicCallback
+	*client.Stream
 }
 
-func (k *klineImpl) SetClient(c *client.Client) error {
-	k.client = c
-	return nil
+func (k *klineImpl) SetClient(*client.Client) error {
+	return errors.New("the client of an open kline stream cannot be replaced")
 }
 
 func (k *klineImpl) Subscribe(symbols []string, interval string, callback func(response Data)) error {
-	if k.topicCallbacks == nil {
-		k.topicCallbacks = make(map[string]topicCallback)
-	}
-
 	topics := make([]string, len(symbols))
 	for i, symbol := range symbols {
-		topic := fmt.Sprintf("kline.%s.%s", interval, symbol)
-		topics[i] = topic
-		k.topicCallbacks[topic] = topicCallback{callback: callback}
-	}
-
-	subscription := map[string]any{
-		"op":   "subscribe",
-		"args": topics,
+		topics[i] = fmt.Sprintf("kline.%s.%s", interval, symbol)
 	}
 
-	msg, err := json.Marshal(subscription)
+	err := client.SubscribeStream(k.Stream, topics, func(e client.Event[[]Data]) {
+		for _, data := range e.Data {
+			callback(data)
+		}
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal subscription message: %v", err)
-	}
-
-	if err := k.client.Send(msg); err != nil {
 		return fmt.Errorf("failed to subscribe to kline channel: %v", err)
 	}
-
 	return nil
 }
 
 func (k *klineImpl) Unsubscribe(topics ...string) error {
-	unsubscription := map[string]any{
-		"op":   "unsubscribe",
-		"args": topics,
-	}
-	msg, err := json.Marshal(unsubscription)
-	if err != nil {
-		return fmt.Errorf("failed to marshal unsubscription message: %v", err)
-	}
-
-	if err := k.client.Send(msg); err != nil {
+	if err := k.Stream.Unsubscribe(topics...); err != nil {
 		return fmt.Errorf("failed to unsubscribe from kline channel: %v", err)
 	}
-
 	return nil
 }
-
-func (k *klineImpl) Listen() (int, []byte, error) {
-	return k.client.Conn.ReadMessage()
-}
-
-func (k *klineImpl) Close() {
-	k.client.Close()
-}
-
-func (k *klineImpl) GetMessagesChan() <-chan []byte {
-	return k.Messages
-}
-
-func (k *klineImpl) Stop() {
-	k.StopChan <- struct{}{}
-}
-
-func (k *klineImpl) listenForMessages() {
-	for {
-		select {
-		case <-k.StopChan:
-			return
-		default:
-			_, msg, err := k.client.Conn.ReadMessage()
-			if err != nil {
-				// Handle error, possibly logging and breaking the loop or attempting to reconnect
-				return
-			}
-			k.Messages <- msg
-
-			var resp Response
-			if err := json.Unmarshal(msg, &resp); err != nil {
-				// Handle unmarshal error
-				continue
-			}
-
-			if tc, exists := k.topicCallbacks[resp.Topic]; exists {
-				for _, data := range resp.Data {
-					tc.callback(data)
-				}
-			}
-		}
-	}
-}
@@ -1,46 +1,85 @@
 package kline
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 )
 
-// TestSubscribe tests the Subscribe method of the klineImpl struct.
-func TestSubscribe(t *testing.T) {
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func newTestKline(ctx context.Context, t *testing.T, s *wstest.Server) Kline {
+	t.Helper()
 	cli, err := client.NewPublicClient(true, "linear")
-	if err != nil {
-		t.Fatalf("Failed to create public client: %v", err)
-	}
+	require.NoError(t, err)
+	cli.SetURL(s.URL())
 
-	kl, err := New(cli)
-	if err != nil {
-		t.Fatalf("Failed to initialize kline service: %v", err)
-	}
+	return New(ctx, cli)
+}
+
+// TestSubscribe tests the Subscribe method of the klineImpl struct.
+func TestSubscribe(t *testing.T) {
+	s := wstest.NewServer(t)
+	kl := newTestKline(context.Background(), t, s)
+	defer kl.Close()
 
-	err = kl.Subscribe([]string{"BTCUSDT", "SOLUSDT"}, "1", func(data Data) {
-		t.Logf("Received kline update: %+v\n", data)
+	updates := make(chan Data, 1)
+	err := kl.Subscribe([]string{"BTCUSDT", "SOLUSDT"}, "1", func(data Data) {
+		updates <- data
 	})
-	if err != nil {
-		t.Fatalf("Failed to subscribe to kline updates: %v", err)
-	}
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(s.Requests("subscribe")) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"kline.1.BTCUSDT", "kline.1.SOLUSDT"}, s.Requests("subscribe")[0].Args)
 
-	go func() {
-		for msg := range kl.GetMessagesChan() {
-			t.Logf("Received raw message: %s", string(msg))
-		}
-	}()
+	s.Publish(`{"topic":"kline.1.SOLUSDT","type":"snapshot","ts":1,"data":[{"interval":"1","open":"100","confirm":true}]}`)
 
-	// Let the test run for a short while to capture some messages
 	select {
-	case <-time.After(60 * time.Second):
-		t.Fatalf("Timed out waiting for kline updates")
-	case msg := <-kl.GetMessagesChan():
-		t.Log("Received kline update")
-		t.Log(string(msg))
+	case data := <-updates:
+		assert.Equal(t, "100", data.Open)
+		assert.True(t, data.Confirm)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for kline update")
 	}
 
-	kl.Stop()
-	kl.Close()
+	_, msg, err := kl.Listen()
+	require.NoError(t, err)
+	assert.Contains(t, string(msg), "kline.1.SOLUSDT")
+}
+
+// TestContextCancel verifies that cancelling the context unsubscribes and closes the connection.
+func TestContextCancel(t *testing.T) {
+	s := wstest.NewServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	kl := newTestKline(ctx, t, s)
+
+	require.NoError(t, kl.Subscribe([]string{"BTCUSDT"}, "1", func(Data) {}))
+	cancel()
+
+	require.Eventually(t, func() bool {
+		return len(s.Requests("unsubscribe")) == 1
+	}, time.Second, 10*time.Millisecond)
+	_, _, err := kl.Listen()
+	assert.Error(t, err)
+}
+
+// TestNewConnectsLazily verifies that the connection is dialled by the first subscription.
+func TestNewConnectsLazily(t *testing.T) {
+	cli, err := client.NewPublicClient(true, "linear")
+	require.NoError(t, err)
+	cli.SetURL("ws://127.0.0.1:1")
+
+	kl := New(context.Background(), cli)
+	defer kl.Close()
+
+	assert.Error(t, kl.Subscribe([]string{"BTCUSDT"}, "1", func(Data) {}))
 }
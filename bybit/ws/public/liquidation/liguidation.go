@@ -1,14 +1,13 @@
 package liquidation
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 )
 
-var oneHundred = 100
-
 // Liquidation represents the interface for the liquidation functionality.
 type Liquidation interface {
 	// SetClient sets the client for the liquidation functionality.
@@ -24,12 +23,19 @@ type Liquidation interface {
 	// Listen reads the next message from the liquidation channel.
 	Listen() (int, []byte, error)
 
-	// Close closes the connection to the liquidation channel.
+	// Close unsubscribes from the liquidation topics and releases the connection.
 	Close()
 
 	// GetMessagesChan returns a channel that receives messages from the liquidation channel.
 	GetMessagesChan() <-chan []byte
 
+	// Configure sets the buffer size and overflow policy of the messages
+	// channel. It must be called before subscribing.
+	Configure(config client.StreamConfig) error
+
+	// Dropped returns the number of messages discarded by the overflow policy.
+	Dropped() uint64
+
 	// Stop stops the liquidation functionality.
 	Stop()
 }
@@ -51,125 +57,39 @@ type Data struct {
 	Side        string `json:"side"`
 }
 
-// New creates a new instance of LiquidationImpl.
-func New(cli *client.Client) Liquidation {
-	var l liquidationImpl
-	l.client = cli
-	l.Messages = make(chan []byte, oneHundred)
-	l.StopChan = make(chan struct{}, 1)
-	l.isTest = cli.IsTestNet
-	err := l.client.Connect()
-	if err != nil {
-		fmt.Printf("Failed to connect: %v", err)
-	}
-
-	<-l.client.Connected
-	fmt.Println("Connected to WS")
-
-	go l.listenForMessages()
-
-	return &l
-}
-
-type topicCallback struct {
-	callback func(data Data)
+// New creates a new instance of LiquidationImpl. The connection is established
+// by the first subscription. The stream is closed when ctx is cancelled or
+// Close is called, whichever happens first.
+func New(ctx context.Context, cli *client.Client) Liquidation {
+	return &liquidationImpl{client.NewStream(ctx, cli)}
 }
 
 type liquidationImpl struct {
-	client         *client.Client
-	Messages       chan []byte
-	StopChan       chan struct{}
-	isTest         bool
-	topicCallbacks map[string]topicCallback
+	*client.Stream
 }
 
-func (l *liquidationImpl) SetClient(c *client.Client) error {
-	l.client = c
-	return nil
+func (l *liquidationImpl) SetClient(*client.Client) error {
+	return errors.New("the client of an open liquidation stream cannot be replaced")
 }
 
 func (l *liquidationImpl) Subscribe(symbols []string, callback func(response Data)) error {
-	if l.topicCallbacks == nil {
-		l.topicCallbacks = make(map[string]topicCallback)
-	}
-
 	topics := make([]string, len(symbols))
 	for i, symbol := range symbols {
-		topic := fmt.Sprintf("liquidation.%s", symbol)
-		topics[i] = topic
-		l.topicCallbacks[topic] = topicCallback{callback: callback}
+		topics[i] = fmt.Sprintf("liquidation.%s", symbol)
 	}
 
-	subscription := map[string]any{
-		"op":   "subscribe",
-		"args": topics,
-	}
-
-	msg, err := json.Marshal(subscription)
+	err := client.SubscribeStream(l.Stream, topics, func(e client.Event[Data]) {
+		callback(e.Data)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal subscription message: %v", err)
-	}
-
-	if err := l.client.Send(msg); err != nil {
 		return fmt.Errorf("failed to subscribe to liquidation channel: %v", err)
 	}
-
 	return nil
 }
 
 func (l *liquidationImpl) Unsubscribe(topics ...string) error {
-	unsubscription := map[string]any{
-		"op":   "unsubscribe",
-		"args": topics,
-	}
-	msg, err := json.Marshal(unsubscription)
-	if err != nil {
-		return fmt.Errorf("failed to marshal unsubscription message: %v", err)
-	}
-
-	if err := l.client.Send(msg); err != nil {
+	if err := l.Stream.Unsubscribe(topics...); err != nil {
 		return fmt.Errorf("failed to unsubscribe from liquidation channel: %v", err)
 	}
-
 	return nil
 }
-
-func (l *liquidationImpl) Listen() (int, []byte, error) {
-	return l.client.Conn.ReadMessage()
-}
-
-func (l *liquidationImpl) Close() {
-	l.client.Close()
-}
-
-func (l *liquidationImpl) GetMessagesChan() <-chan []byte {
-	return l.Messages
-}
-
-func (l *liquidationImpl) Stop() {
-	l.StopChan <- struct{}{}
-}
-
-func (l *liquidationImpl) listenForMessages() {
-	for {
-		select {
-		case <-l.StopChan:
-			return
-		default:
-			_, msg, err := l.client.Conn.ReadMessage()
-			if err != nil {
-				continue
-			}
-			l.Messages <- msg
-
-			var resp Response
-			if err := json.Unmarshal(msg, &resp); err != nil {
-				continue
-			}
-
-			if tc, exists := l.topicCallbacks[resp.Topic]; exists {
-				tc.callback(resp.Data)
-			}
-		}
-	}
-}
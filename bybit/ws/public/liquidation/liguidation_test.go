@@ -1,43 +1,53 @@
 package liquidation
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 )
 
-// TestSubscribe tests the Subscribe method of the klineImpl struct.
-func TestSubscribe(t *testing.T) {
-	cli, err := client.NewPublicClient(true, "usdt_contract")
-	if err != nil {
-		t.Fatalf("Failed to create public client: %v", err)
-	}
-
-	kl := New(cli)
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
 
-	err = kl.Subscribe([]string{"GALAUSDT"}, func(data Data) {
-		t.Logf("Received liquidation update: %+v\n", data)
+// TestSubscribe tests the Subscribe method of the liquidationImpl struct.
+func TestSubscribe(t *testing.T) {
+	s := wstest.NewServer(t)
+	cli, err := client.NewPublicClient(true, "linear")
+	require.NoError(t, err)
+	cli.SetURL(s.URL())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	liq := New(ctx, cli)
+
+	updates := make(chan Data, 1)
+	err = liq.Subscribe([]string{"BTCUSDT"}, func(data Data) {
+		updates <- data
 	})
-	if err != nil {
-		t.Fatalf("Failed to subscribe to liquidation updates: %v", err)
-	}
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(s.Requests("subscribe")) == 1
+	}, time.Second, 10*time.Millisecond)
 
-	go func() {
-		for msg := range kl.GetMessagesChan() {
-			t.Logf("Received raw message: %s", string(msg))
-		}
-	}()
+	s.Publish(`{"topic":"liquidation.BTCUSDT","type":"snapshot","ts":1,"data":{"symbol":"BTCUSDT","side":"Buy","price":"100","size":"1"}}`)
 
-	// Let the test run for a short while to capture some messages
 	select {
-	case <-time.After(120 * time.Second):
-		t.Fatalf("Timed out waiting for liquidation updates")
-	case msg := <-kl.GetMessagesChan():
-		t.Log("Received liquidation update")
-		t.Log(string(msg))
+	case data := <-updates:
+		assert.Equal(t, "BTCUSDT", data.Symbol)
+		assert.Equal(t, "Buy", data.Side)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for liquidation update")
 	}
 
-	kl.Stop()
-	kl.Close()
+	liq.Close()
+	require.Eventually(t, func() bool {
+		return len(s.Requests("unsubscribe")) == 1
+	}, time.Second, 10*time.Millisecond)
 }
@@ -1,47 +1,51 @@
 package lt_kline
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 )
 
-// TestSubscribe tests the Subscribe method of the klineImpl struct.
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+// TestSubscribe tests the Subscribe method of the ltKlineImpl struct.
 func TestSubscribe(t *testing.T) {
-	cli, err := client.NewPublicClient(true, "")
-	if err != nil {
-		t.Fatalf("Failed to create public client: %v", err)
-	}
+	s := wstest.NewServer(t)
+	cli, err := client.NewPublicClient(true, "spot")
+	require.NoError(t, err)
+	cli.SetURL(s.URL())
 
-	ltKline := New(cli)
+	ctx, cancel := context.WithCancel(context.Background())
+	ltKline := New(ctx, cli)
 
+	responses := make(chan LTKlineResponse, 1)
 	err = ltKline.Subscribe("30", "BTC3SUSDT", func(response LTKlineResponse) {
-		assert.Equal(t, "kline_lt.30.BTC3SUSDT", response.Topic)
-		assert.Equal(t, "snapshot", response.Type)
-		assert.Len(t, response.Data, 1)
-		assert.Equal(t, "30", response.Data[0].Interval)
+		responses <- response
 	})
-	if err != nil {
-		t.Fatalf("Failed to subscribe to liquidation updates: %v", err)
-	}
+	require.NoError(t, err)
 
-	go func() {
-		for msg := range ltKline.GetMessagesChan() {
-			t.Logf("Received raw message: %s", string(msg))
-		}
-	}()
+	s.Publish(`{"topic":"kline_lt.30.BTC3SUSDT","type":"snapshot","ts":1,"data":[{"interval":"30","open":"0.5"}]}`)
 
-	// Let the test run for a short while to capture some messages
 	select {
-	case <-time.After(120 * time.Second):
-		t.Fatalf("Timed out waiting for liquidation updates")
-	case msg := <-ltKline.GetMessagesChan():
-		t.Log("Received liquidation update")
-		t.Log(string(msg))
+	case response := <-responses:
+		assert.Equal(t, "kline_lt.30.BTC3SUSDT", response.Topic)
+		assert.Equal(t, "snapshot", response.Type)
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, "30", response.Data[0].Interval)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for LT kline updates")
 	}
 
-	ltKline.Stop()
-	ltKline.Close()
+	cancel()
+	require.Eventually(t, func() bool {
+		return len(s.Requests("unsubscribe")) == 1
+	}, time.Second, 10*time.Millisecond)
 }
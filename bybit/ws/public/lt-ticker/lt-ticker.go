@@ -1,11 +1,18 @@
 package lt_ticker
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
 
 type LtTicker struct {
-	*client.Client
+	*client.Stream
 }
 
-func New(cli *client.Client) LtTicker {
-	return LtTicker{cli}
+// New creates the LT ticker stream. The connection is established by the first
+// subscription. The stream is closed when ctx is cancelled or Close is called,
+// whichever happens first.
+func New(ctx context.Context, cli *client.Client) LtTicker {
+	return LtTicker{client.NewStream(ctx, cli)}
 }
@@ -1,11 +1,18 @@
 package ltnav
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
 
 type LtNav struct {
-	*client.Client
+	*client.Stream
 }
 
-func New(cli *client.Client) LtNav {
-	return LtNav{cli}
+// New creates the LT NAV stream. The connection is established by the first
+// subscription. The stream is closed when ctx is cancelled or Close is called,
+// whichever happens first.
+func New(ctx context.Context, cli *client.Client) LtNav {
+	return LtNav{client.NewStream(ctx, cli)}
 }
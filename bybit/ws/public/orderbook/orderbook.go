@@ -1,11 +1,18 @@
 package orderbook
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
 
 type OrderBook struct {
-	*client.Client
+	*client.Stream
 }
 
-func New(cli *client.Client) OrderBook {
-	return OrderBook{cli}
+// New creates the order book stream. The connection is established by the first
+// subscription. The stream is closed when ctx is cancelled or Close is called,
+// whichever happens first.
+func New(ctx context.Context, cli *client.Client) OrderBook {
+	return OrderBook{client.NewStream(ctx, cli)}
 }
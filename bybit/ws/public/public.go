@@ -1,6 +1,9 @@
 package public
 
 import (
+	"context"
+
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/kline"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/liquidation"
@@ -13,87 +16,86 @@ import (
 )
 
 type Public interface {
-	Kline(category string) (kline.Kline, error)
-	Liquidation(category string) liquidation.Liquidation
-	LtKline(category string) ltkline.LTKline
-	LtNav(category string) ltnav.LtNav
-	LtTickers(category string) ltticker.LtTicker
-	OrderBook(category string) orderbook.OrderBook
-	Ticker(category string) ticker.Ticker
-	Trade(category string) trade.Trade
+	Kline(ctx context.Context, category rest.Category) (kline.Kline, error)
+	Liquidation(ctx context.Context, category rest.Category) (liquidation.Liquidation, error)
+	LtKline(ctx context.Context, category rest.Category) (ltkline.LTKline, error)
+	LtNav(ctx context.Context, category rest.Category) (ltnav.LtNav, error)
+	LtTickers(ctx context.Context, category rest.Category) (ltticker.LtTicker, error)
+	OrderBook(ctx context.Context, category rest.Category) (orderbook.OrderBook, error)
+	Ticker(ctx context.Context, category rest.Category) (*ticker.Ticker, error)
+	Trade(ctx context.Context, category rest.Category) (trade.Trade, error)
 }
 
 type implPublic struct {
-	client *client.Client
+	manager *client.Manager
 }
 
-func (i *implPublic) Kline(category string) (kline.Kline, error) {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return kline.New(cli)
-}
-func (i *implPublic) Liquidation(category string) liquidation.Liquidation {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return liquidation.New(cli)
+func (i *implPublic) Kline(ctx context.Context, category rest.Category) (kline.Kline, error) {
+	c, err := i.manager.Public(category)
+	if err != nil {
+		return nil, err
+	}
+	return kline.New(ctx, c), nil
 }
 
-func (i *implPublic) LtKline(category string) ltkline.LTKline {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return ltkline.New(cli)
+func (i *implPublic) Liquidation(ctx context.Context, category rest.Category) (liquidation.Liquidation, error) {
+	c, err := i.manager.Public(category)
+	if err != nil {
+		return nil, err
+	}
+	return liquidation.New(ctx, c), nil
 }
 
-func (i *implPublic) LtNav(category string) ltnav.LtNav {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return ltnav.New(cli)
+func (i *implPublic) LtKline(ctx context.Context, category rest.Category) (ltkline.LTKline, error) {
+	c, err := i.manager.Public(category)
+	if err != nil {
+		return nil, err
+	}
+	return ltkline.New(ctx, c), nil
 }
 
-func (i *implPublic) LtTickers(category string) ltticker.LtTicker {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return ltticker.New(cli)
+func (i *implPublic) LtNav(ctx context.Context, category rest.Category) (ltnav.LtNav, error) {
+	c, err := i.manager.Public(category)
+	if err != nil {
+		return ltnav.LtNav{}, err
+	}
+	return ltnav.New(ctx, c), nil
 }
 
-func (i *implPublic) OrderBook(category string) orderbook.OrderBook {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return orderbook.New(cli)
+func (i *implPublic) LtTickers(ctx context.Context, category rest.Category) (ltticker.LtTicker, error) {
+	c, err := i.manager.Public(category)
+	if err != nil {
+		return ltticker.LtTicker{}, err
+	}
+	return ltticker.New(ctx, c), nil
 }
 
-func (i *implPublic) Ticker(category string) ticker.Ticker {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return ticker.New(cli)
+func (i *implPublic) OrderBook(ctx context.Context, category rest.Category) (orderbook.OrderBook, error) {
+	c, err := i.manager.Public(category)
+	if err != nil {
+		return orderbook.OrderBook{}, err
+	}
+	return orderbook.New(ctx, c), nil
 }
 
-func (i *implPublic) Trade(category string) trade.Trade {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return trade.New(cli)
+func (i *implPublic) Ticker(ctx context.Context, category rest.Category) (*ticker.Ticker, error) {
+	c, err := i.manager.Public(category)
+	if err != nil {
+		return nil, err
+	}
+	return ticker.New(ctx, c), nil
 }
 
-func New(wsClient *client.Client, isPublic bool) Public {
-	if isPublic {
-		return &implPublic{client: wsClient}
-	} else {
-		return &implPublic{client: wsClient}
+func (i *implPublic) Trade(ctx context.Context, category rest.Category) (trade.Trade, error) {
+	c, err := i.manager.Public(category)
+	if err != nil {
+		return trade.Trade{}, err
 	}
+	return trade.New(ctx, c), nil
+}
+
+// New creates the public streams. Streams of the same category share the
+// manager's connection for that category.
+func New(manager *client.Manager) Public {
+	return &implPublic{manager: manager}
 }
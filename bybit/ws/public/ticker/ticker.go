@@ -2,22 +2,13 @@ package ticker
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"sync"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 )
 
-type response struct {
-	Topic string `json:"topic"`
-	Type  string `json:"type"`
-	Data  Data   `json:"data"`
-	CS    int64  `json:"cs"`
-	TS    int64  `json:"ts"`
-}
-
 type Data struct {
 	Symbol            string `json:"symbol"`
 	TickDirection     string `json:"tickDirection"`
@@ -43,117 +34,103 @@ type Data struct {
 
 // Ticker manages ticker subscriptions and updates.
 type Ticker struct {
-	client      *client.Client
-	subscribers map[string]func(Data)
-	ctx         context.Context
-	cancel      context.CancelFunc
-	mu          sync.RWMutex
-	sendCh      chan []byte
+	client        *client.Client
+	subscriptions map[string]*client.Subscription
+	ctx           context.Context
+	cancel        context.CancelFunc
+	mu            sync.RWMutex
+	released      bool
 }
 
-// New initializes a new Ticker instance with context for graceful shutdown.
-func New(client *client.Client) Ticker {
-	ctx, cancel := context.WithCancel(context.Background())
-	t := Ticker{
-		client:      client,
-		subscribers: make(map[string]func(Data)),
-		ctx:         ctx,
-		cancel:      cancel,
-		sendCh:      make(chan []byte),
+// New initializes a new Ticker instance. The ticker is shut down when ctx is
+// cancelled or Shutdown is called, whichever happens first.
+func New(ctx context.Context, cli *client.Client) *Ticker {
+	ctx, cancel := context.WithCancel(ctx)
+	cli.Acquire()
+	t := &Ticker{
+		client:        cli,
+		subscriptions: make(map[string]*client.Subscription),
+		ctx:           ctx,
+		cancel:        cancel,
 	}
-
-	go t.writer()
-
+	context.AfterFunc(ctx, t.release)
 	return t
 }
 
-// writer is a goroutine that handles all outgoing messages to the WebSocket connection.
-func (t *Ticker) writer() {
-	for msg := range t.sendCh {
-		err := t.client.Send(msg)
-		if err != nil {
-			log.Printf("Error sending message: %v", err)
-		}
-	}
-}
-
 // Subscribe to the ticker updates for a given symbol.
 func (t *Ticker) Subscribe(symbol string, callback func(Data)) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	topic := fmt.Sprintf("tickers.%s", symbol)
-	t.subscribers[topic] = callback
-
-	// Correctly construct the subscription message with "args"
-	subscriptionMessage := map[string]any{
-		"op":   "subscribe",
-		"args": []string{topic}, // Use an array for topics as per API requirements
+	if t.ctx.Err() != nil {
+		return errors.New("ticker is shut down")
+	}
+	if err := t.client.Connect(); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
 	}
-	msg, err := json.Marshal(subscriptionMessage)
+
+	topic := fmt.Sprintf("tickers.%s", symbol)
+	sub, err := client.Subscribe(t.client, topic, func(e client.Event[Data]) {
+		if e.Type == client.TypeSnapshot || e.Type == client.TypeDelta {
+			callback(e.Data)
+		}
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal subscription message: %v", err)
+		return fmt.Errorf("failed to subscribe to ticker channel: %w", err)
 	}
 
-	// Send the subscription message
-	t.sendCh <- msg
+	t.mu.Lock()
+	if t.released {
+		t.mu.Unlock()
+		_ = sub.Unsubscribe()
+		return errors.New("ticker is shut down")
+	}
+	previous := t.subscriptions[topic]
+	t.subscriptions[topic] = sub
+	t.mu.Unlock()
+
+	if previous != nil {
+		return previous.Unsubscribe()
+	}
 	return nil
 }
 
-// Listen method modified to support graceful shutdown.
+// Listen blocks until the ticker is shut down. Updates are delivered to the
+// subscription callbacks by the connection's reader.
 func (t *Ticker) Listen() {
-	for {
-		select {
-		case <-t.ctx.Done(): // Check if shutdown has been initiated.
-			close(t.sendCh)
-			return
-		default:
-			message, err := t.client.Receive()
-			if err != nil {
-				log.Printf("Error receiving message: %v", err)
-				continue
-			}
-
-			var res response
-			if err := json.Unmarshal(message, &res); err != nil {
-				log.Printf("Error unmarshalling message: %v", err)
-				continue
-			}
-
-			t.mu.RLock()
-			callback, exists := t.subscribers[res.Topic]
-			t.mu.RUnlock()
-
-			if exists && (res.Type == "snapshot" || res.Type == "delta") {
-				go callback(res.Data)
-			}
-		}
-	}
+	<-t.ctx.Done()
 }
 
 // Unsubscribe from the ticker updates for a given symbol.
 func (t *Ticker) Unsubscribe(symbol string) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
 	topic := fmt.Sprintf("tickers.%s", symbol)
 
-	delete(t.subscribers, topic)
+	t.mu.Lock()
+	sub, ok := t.subscriptions[topic]
+	delete(t.subscriptions, topic)
+	t.mu.Unlock()
 
-	// Construct the unsubscription message
-	unsubscriptionMessage := map[string]any{
-		"op":   "unsubscribe",
-		"args": []string{topic},
+	if !ok {
+		return nil
 	}
-	msg, err := json.Marshal(unsubscriptionMessage)
-	if err != nil {
-		return fmt.Errorf("failed to marshal unsubscription message: %v", err)
+	if err := sub.Unsubscribe(); err != nil {
+		return fmt.Errorf("failed to unsubscribe from ticker channel: %w", err)
 	}
-
-	// Send the unsubscription message
-	t.sendCh <- msg
 	return nil
 }
 
-// Shutdown method to cleanly terminate the Listen loop.
+// Shutdown unsubscribes from every ticker topic and releases the connection.
 func (t *Ticker) Shutdown() {
-	t.cancel() // Trigger context cancellation.
+	t.cancel() // Trigger context cancellation, which releases the ticker.
+}
+
+// release unsubscribes from every ticker topic and releases the connection.
+func (t *Ticker) release() {
+	t.mu.Lock()
+	t.released = true
+	subscriptions := t.subscriptions
+	t.subscriptions = make(map[string]*client.Subscription)
+	t.mu.Unlock()
+
+	for _, sub := range subscriptions {
+		_ = sub.Unsubscribe()
+	}
+	t.client.Release()
 }
@@ -0,0 +1,53 @@
+package ticker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/internal/wstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+// TestSubscribe tests the Subscribe method of the Ticker struct.
+func TestSubscribe(t *testing.T) {
+	s := wstest.NewServer(t)
+	cli, err := client.NewPublicClient(true, "linear")
+	require.NoError(t, err)
+	cli.SetURL(s.URL())
+
+	tk := New(context.Background(), cli)
+	listening := make(chan struct{})
+	go func() {
+		tk.Listen()
+		close(listening)
+	}()
+
+	updates := make(chan Data, 1)
+	require.NoError(t, tk.Subscribe("BTCUSDT", func(data Data) {
+		updates <- data
+	}))
+
+	s.Publish(`{"topic":"tickers.BTCUSDT","type":"snapshot","cs":1,"ts":1,"data":{"symbol":"BTCUSDT","lastPrice":"100"}}`)
+
+	select {
+	case data := <-updates:
+		assert.Equal(t, "100", data.LastPrice)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for ticker update")
+	}
+
+	tk.Shutdown()
+	<-listening
+	assert.Error(t, tk.Subscribe("ETHUSDT", func(Data) {}))
+	require.Eventually(t, func() bool {
+		return len(s.Requests("unsubscribe")) == 1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -1,11 +1,18 @@
 package trade
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
 
 type Trade struct {
-	*client.Client
+	*client.Stream
 }
 
-func New(cli *client.Client) Trade {
-	return Trade{cli}
+// New creates the trade stream. The connection is established by the first
+// subscription. The stream is closed when ctx is cancelled or Close is called,
+// whichever happens first.
+func New(ctx context.Context, cli *client.Client) Trade {
+	return Trade{client.NewStream(ctx, cli)}
 }
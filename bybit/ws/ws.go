@@ -1,21 +1,31 @@
 package ws
 
 import (
+	rest "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public"
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
+	"github.com/cploutarchou/crypto-sdk-suite/logger"
 )
 
 type WebSocket interface {
 	Private() (private.Private, error)
 	Public() (public.Public, error)
+	// SetLogger sets the logger of every connection, current and future.
+	SetLogger(l logger.Interface)
 }
 
 type implWebSocket struct {
+	manager *client.Manager
 	private private.Private
 	public  public.Public
 }
 
+func (i *implWebSocket) SetLogger(l logger.Interface) {
+	i.manager.SetLogger(l)
+}
+
 func (i *implWebSocket) Private() (private.Private, error) {
 	return i.private, nil
 }
@@ -23,9 +33,46 @@ func (i *implWebSocket) Private() (private.Private, error) {
 func (i *implWebSocket) Public() (public.Public, error) {
 	return i.public, nil
 }
+
+// New creates the WebSocket streams. The given clients are registered with a
+// connection manager and reused for their endpoints; connections to the other
+// endpoints are opened on demand with the same credentials and network.
 func New(publicClient, privateClient *client.Client, isTestnet bool) WebSocket {
+	return NewWithEnvironment(publicClient, privateClient, rest.EnvironmentFor(isTestnet))
+}
+
+// NewWithEnvironment is like New for the stream hosts of env. The given
+// clients are switched to env as well.
+func NewWithEnvironment(publicClient, privateClient *client.Client, env client.Environment) WebSocket {
+	var apiKey, apiSecret string
+	var creds credentials.Provider
+	if privateClient != nil {
+		apiKey, apiSecret = privateClient.APIKey, privateClient.APISecret
+		creds = privateClient.Credentials
+	}
+	manager := client.NewManagerWithEnvironment(apiKey, apiSecret, env)
+	manager.SetCredentials(creds)
+	for _, c := range []*client.Client{publicClient, privateClient} {
+		if c != nil {
+			c.Environment = env
+			manager.Register(c)
+		}
+	}
+	return NewWithManager(manager)
+}
+
+// NewWithManager creates the WebSocket streams on top of an existing connection manager.
+func NewWithManager(manager *client.Manager) WebSocket {
 	return &implWebSocket{
-		private: private.New(privateClient, isTestnet),
-		public:  public.New(publicClient, isTestnet),
+		manager: manager,
+		private: private.New(manager),
+		public:  public.New(manager),
 	}
 }
+
+// Subscribe subscribes conn to topic and calls handler with every message
+// decoded into an Event[T]. Handlers run on the connection's reader, so they
+// should return quickly.
+func Subscribe[T any](conn *client.Client, topic string, handler func(client.Event[T])) (*client.Subscription, error) {
+	return client.Subscribe(conn, topic, handler)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -66,7 +67,7 @@ func getCoinGreeks() (any, error) {
 func getFeeRates() (any, error) {
 	fmt.Println("getFeeRates")
 	feeRates := acc.FeeRates()
-	return feeRates.GetFeeRate("taker", "BTCUSDT", "USDT")
+	return feeRates.GetFeeRate(client.CategoryLinear, "BTCUSDT", "")
 }
 
 func getInfo() (any, error) {
@@ -133,7 +134,11 @@ func wsConnectTicker() {
 		return
 	}
 
-	ticker := publicWS.Ticker("linear")
+	ticker, err := publicWS.Ticker(context.Background(), client.CategoryLinear)
+	if err != nil {
+		log.Printf("ERROR: Failed to create ticker stream: %v", err)
+		return
+	}
 
 	err = ticker.Subscribe("BTCUSDT", func(data ticker2.Data) {
 		if data.LastPrice != "" {
@@ -172,11 +177,7 @@ func wsConnectKline() {
 		return
 	}
 
-	klineService, err := kline2.New(client_)
-	if err != nil {
-		log.Printf("ERROR: Failed to initialize kline service: %v", err)
-		return
-	}
+	klineService := kline2.New(context.Background(), client_)
 
 	err = klineService.Subscribe([]string{"BTCUSDT", "SOLUSDT"}, "1", func(data kline2.Data) {
 		log.Printf("Received kline update: %+v\n", data)
@@ -5,10 +5,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"sync"
 
+	"github.com/cploutarchou/crypto-sdk-suite/credentials"
 	"github.com/sirupsen/logrus"
 )
 
@@ -25,10 +27,11 @@ type Requester interface {
 }
 
 type Client struct {
-	apiKey     string
-	httpClient *http.Client
-	IsTestNet  bool
-	lock       sync.RWMutex // Might be used for thread safety in the future
+	apiKey      string
+	credentials credentials.Provider
+	httpClient  *http.Client
+	IsTestNet   bool
+	lock        sync.RWMutex // Might be used for thread safety in the future
 }
 
 type Method string
@@ -52,6 +55,15 @@ func NewClient(key string, isTestnet bool, httpClient ...*http.Client) *Client {
 	}
 }
 
+// NewClientWithProvider creates a client whose API key is read from p before
+// every request, so it can be rotated while the client is in use. Only the
+// API key of the credentials is used.
+func NewClientWithProvider(p credentials.Provider, isTestnet bool, httpClient ...*http.Client) *Client {
+	c := NewClient("", isTestnet, httpClient...)
+	c.credentials = p
+	return c
+}
+
 func (c *Client) SetIsTestNet(isTestNet bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -104,7 +116,15 @@ func (c *Client) do(req *Request) (Response, error) {
 		return nil, err
 	}
 
-	c.setCommonHeaders(httpReq)
+	apiKey := c.apiKey
+	if c.credentials != nil {
+		creds, err := c.credentials.Credentials()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credentials: %w", err)
+		}
+		apiKey = creds.APIKey
+	}
+	c.setCommonHeaders(httpReq, apiKey)
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -136,8 +156,8 @@ func (c *Client) newPOSTRequest(baseURL string, req *Request) (*http.Request, er
 	return http.NewRequest(string(POST), baseURL+req.path, bytes.NewBuffer(jsonData))
 }
 
-func (c *Client) setCommonHeaders(req *http.Request) {
+func (c *Client) setCommonHeaders(req *http.Request, apiKey string) {
 	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("X-CMC_PRO_API_KEY", c.apiKey)
+	req.Header.Add("X-CMC_PRO_API_KEY", apiKey)
 	req.Header.Add("Accept", "application/json")
 }
@@ -1,14 +1,23 @@
 package idmap
 
 type Data struct {
-	ID                  int    `json:"id"`
-	Name                string `json:"name"`
-	Symbol              string `json:"symbol"`
-	Platform            string `json:"platform"`
-	FirstHistoricalData string `json:"first_historical_data"`
-	LastHistoricalData  string `json:"last_historical_data"`
-	IsActive            int    `json:"is_active"`
-	Status              string `json:"status"`
+	ID                  int       `json:"id"`
+	Name                string    `json:"name"`
+	Symbol              string    `json:"symbol"`
+	Platform            *Platform `json:"platform"` // Platform is nil for coins and set for tokens.
+	FirstHistoricalData string    `json:"first_historical_data"`
+	LastHistoricalData  string    `json:"last_historical_data"`
+	IsActive            int       `json:"is_active"`
+	Status              string    `json:"status"`
+}
+
+// Platform is the blockchain a token is issued on.
+type Platform struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Symbol       string `json:"symbol"`
+	Slug         string `json:"slug"`
+	TokenAddress string `json:"token_address"`
 }
 
 type Response struct {
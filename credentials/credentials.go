@@ -0,0 +1,112 @@
+// Package credentials provides the API keys used to sign requests. Clients
+// ask their Provider for credentials every time they sign a request or
+// authenticate a stream, so keys can be rotated without restarting.
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrMissing is returned when a provider has no API key to offer.
+var ErrMissing = errors.New("credentials are not set")
+
+// Credentials is an API key and the secret it is signed with.
+type Credentials struct {
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret"`
+}
+
+// Provider supplies the current credentials.
+type Provider interface {
+	Credentials() (Credentials, error)
+}
+
+// ProviderFunc adapts a function to a Provider.
+type ProviderFunc func() (Credentials, error)
+
+// Credentials calls f.
+func (f ProviderFunc) Credentials() (Credentials, error) {
+	return f()
+}
+
+// Static returns a provider that always returns the given key and secret.
+func Static(apiKey, apiSecret string) Provider {
+	return ProviderFunc(func() (Credentials, error) {
+		return Credentials{APIKey: apiKey, APISecret: apiSecret}, nil
+	})
+}
+
+// Env returns a provider that reads the key and secret from the environment
+// variables keyVar and secretVar on every call. secretVar may be empty for
+// APIs that only need a key.
+func Env(keyVar, secretVar string) Provider {
+	return ProviderFunc(func() (Credentials, error) {
+		c := Credentials{APIKey: os.Getenv(keyVar)}
+		if c.APIKey == "" {
+			return Credentials{}, fmt.Errorf("%w: environment variable %s is empty", ErrMissing, keyVar)
+		}
+		if secretVar != "" {
+			if c.APISecret = os.Getenv(secretVar); c.APISecret == "" {
+				return Credentials{}, fmt.Errorf("%w: environment variable %s is empty", ErrMissing, secretVar)
+			}
+		}
+		return c, nil
+	})
+}
+
+// File returns a provider that reads a JSON file holding "api_key" and
+// "api_secret". The file is read again whenever it changes.
+func File(path string) Provider {
+	return &fileProvider{path: path, decode: decodeJSON}
+}
+
+func decodeJSON(data []byte) (Credentials, error) {
+	var c Credentials
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Credentials{}, fmt.Errorf("failed to decode credentials: %w", err)
+	}
+	return c, nil
+}
+
+// fileProvider caches the credentials decoded from a file until the file changes.
+type fileProvider struct {
+	path   string
+	decode func([]byte) (Credentials, error)
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	cached  Credentials
+}
+
+func (p *fileProvider) Credentials() (Credentials, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cached.APIKey != "" && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.cached, nil
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	c, err := p.decode(data)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if c.APIKey == "" {
+		return Credentials{}, fmt.Errorf("%w: %s has no api_key", ErrMissing, p.path)
+	}
+	p.cached, p.modTime, p.size = c, info.ModTime(), info.Size()
+	return c, nil
+}
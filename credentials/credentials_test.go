@@ -0,0 +1,64 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv(t *testing.T) {
+	t.Setenv("TEST_API_KEY", "key")
+	t.Setenv("TEST_API_SECRET", "")
+
+	_, err := Env("TEST_API_KEY", "TEST_API_SECRET").Credentials()
+	assert.ErrorIs(t, err, ErrMissing)
+
+	c, err := Env("TEST_API_KEY", "").Credentials()
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "key"}, c)
+
+	t.Setenv("TEST_API_SECRET", "secret")
+	c, err = Env("TEST_API_KEY", "TEST_API_SECRET").Credentials()
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "key", APISecret: "secret"}, c)
+}
+
+func TestFile_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"api_key":"old","api_secret":"s1"}`), 0o600))
+
+	p := File(path)
+	c, err := p.Credentials()
+	require.NoError(t, err)
+	assert.Equal(t, "old", c.APIKey)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"api_key":"new","api_secret":"s2"}`), 0o600))
+	// Make the change visible on filesystems with a coarse modification time.
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	c, err = p.Credentials()
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "new", APISecret: "s2"}, c)
+}
+
+func TestKeystore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	want := Credentials{APIKey: "key", APISecret: "secret"}
+	require.NoError(t, WriteKeystore(path, []byte("passphrase"), want))
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret")
+
+	c, err := Keystore(path, []byte("passphrase")).Credentials()
+	require.NoError(t, err)
+	assert.Equal(t, want, c)
+
+	_, err = Keystore(path, []byte("wrong")).Credentials()
+	assert.ErrorIs(t, err, ErrPassphrase)
+}
@@ -0,0 +1,120 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreVersion = 1
+	keyLength       = 32 // AES-256
+	saltLength      = 16
+
+	// scrypt parameters recommended for interactive logins.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrPassphrase is returned when a keystore cannot be decrypted with the given passphrase.
+var ErrPassphrase = errors.New("wrong passphrase or corrupted keystore")
+
+// keystore is the on-disk format of an encrypted credentials file.
+type keystore struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keystore returns a provider that decrypts the keystore at path, written by
+// WriteKeystore, with passphrase. The file is decrypted again whenever it changes.
+func Keystore(path string, passphrase []byte) Provider {
+	return &fileProvider{path: path, decode: func(data []byte) (Credentials, error) {
+		return decryptKeystore(data, passphrase)
+	}}
+}
+
+// WriteKeystore encrypts c with a key derived from passphrase and writes it to
+// path, readable by the owner only. The file is replaced atomically, so a
+// running Keystore provider picks up the new credentials on its next call.
+func WriteKeystore(path string, passphrase []byte, c Credentials) error {
+	ks := keystore{Version: keystoreVersion, KDF: "scrypt", N: scryptN, R: scryptR, P: scryptP}
+	ks.Salt = make([]byte, saltLength)
+	if _, err := rand.Read(ks.Salt); err != nil {
+		return err
+	}
+	gcm, err := newGCM(passphrase, ks)
+	if err != nil {
+		return err
+	}
+	ks.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(ks.Nonce); err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	ks.Ciphertext = gcm.Seal(nil, ks.Nonce, plaintext, nil)
+
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	return nil
+}
+
+func decryptKeystore(data, passphrase []byte) (Credentials, error) {
+	var ks keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return Credentials{}, fmt.Errorf("failed to decode keystore: %w", err)
+	}
+	if ks.Version != keystoreVersion || ks.KDF != "scrypt" {
+		return Credentials{}, fmt.Errorf("unsupported keystore version %d (%s)", ks.Version, ks.KDF)
+	}
+	gcm, err := newGCM(passphrase, ks)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if len(ks.Nonce) != gcm.NonceSize() {
+		return Credentials{}, ErrPassphrase
+	}
+	plaintext, err := gcm.Open(nil, ks.Nonce, ks.Ciphertext, nil)
+	if err != nil {
+		return Credentials{}, ErrPassphrase
+	}
+	return decodeJSON(plaintext)
+}
+
+// newGCM derives the keystore's AES-GCM cipher from passphrase.
+func newGCM(passphrase []byte, ks keystore) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, ks.Salt, ks.N, ks.R, ks.P, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive keystore key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,405 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures/market"
+	"github.com/shopspring/decimal"
+)
+
+// Defaults of Binance's limit parameters, sent when the caller passes 0.
+const (
+	binanceDefaultDepth  = 500
+	binanceDefaultTrades = 500
+	binanceDefaultKlines = 500
+)
+
+// binanceErrors classifies Binance's error codes.
+var binanceErrors = map[int]error{
+	-1003: ErrRateLimited,
+	-1015: ErrRateLimited,
+	-1021: ErrInvalidRequest,
+	-1022: ErrUnauthorized,
+	-1102: ErrInvalidRequest,
+	-1111: ErrInvalidRequest,
+	-1121: ErrInvalidRequest,
+	-2011: ErrNotFound,
+	-2013: ErrNotFound,
+	-2014: ErrUnauthorized,
+	-2015: ErrUnauthorized,
+	-2018: ErrInsufficientFunds,
+	-2019: ErrInsufficientFunds,
+}
+
+// binanceErr returns the Error for an error returned by the Binance API, or
+// err itself for other errors.
+func binanceErr(err error) error {
+	var apiErr *client.BinanceAPIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.Code == 0 {
+		// Errors without a code, such as 429 and 418, only carry the status.
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusTeapot:
+			return &Error{Venue: VenueBinanceFutures, Code: apiErr.StatusCode, Message: apiErr.Message, Kind: ErrRateLimited}
+		case http.StatusUnauthorized:
+			return &Error{Venue: VenueBinanceFutures, Code: apiErr.StatusCode, Message: apiErr.Message, Kind: ErrUnauthorized}
+		}
+	}
+	return newError(VenueBinanceFutures, apiErr.Code, apiErr.Message, binanceErrors)
+}
+
+// binanceFutures adapts the binance/futures module to Exchange.
+type binanceFutures struct {
+	api futures.Futures
+}
+
+// NewBinanceFutures returns an Exchange trading USDⓈ-M futures on api.
+func NewBinanceFutures(api futures.Futures) Exchange {
+	return &binanceFutures{api: api}
+}
+
+func (b *binanceFutures) Venue() Venue           { return VenueBinanceFutures }
+func (b *binanceFutures) MarketData() MarketData { return b }
+func (b *binanceFutures) Orders() Orders         { return b }
+func (b *binanceFutures) Account() Account       { return b }
+
+func (b *binanceFutures) Ticker(symbol string) (*Ticker, error) {
+	stats, err := b.api.Market().Ticker24hr(symbol)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	book, err := b.api.Market().BookTicker(symbol)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	ticker := &Ticker{Symbol: stats.Symbol, Time: time.UnixMilli(max(stats.CloseTime, book.Time))}
+	err = decimals([]*decimal.Decimal{
+		&ticker.LastPrice, &ticker.BidPrice, &ticker.BidQty, &ticker.AskPrice, &ticker.AskQty,
+		&ticker.High24h, &ticker.Low24h, &ticker.Volume24h,
+	}, stats.LastPrice, book.BidPrice, book.BidQty, book.AskPrice, book.AskQty,
+		stats.HighPrice, stats.LowPrice, stats.Volume)
+	if err != nil {
+		return nil, err
+	}
+	return ticker, nil
+}
+
+func (b *binanceFutures) OrderBook(symbol string, depth int) (*OrderBook, error) {
+	if depth <= 0 {
+		depth = binanceDefaultDepth
+	}
+	res, err := b.api.Market().OrderBook(symbol, depth)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	book := &OrderBook{Symbol: symbol, Time: time.UnixMilli(res.TransactionTime)}
+	if book.Bids, err = parseLevels(res.Bids); err != nil {
+		return nil, err
+	}
+	if book.Asks, err = parseLevels(res.Asks); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+func (b *binanceFutures) Klines(symbol string, interval Interval, limit int) ([]Kline, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = binanceDefaultKlines
+	}
+	res, err := b.api.Market().KlineCandlestickData(symbol, market.Interval(interval), -1, -1, limit)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	klines := make([]Kline, 0, len(res))
+	for _, k := range res {
+		klines = append(klines, Kline{
+			OpenTime:    k.OpenTime,
+			Open:        k.Open,
+			High:        k.High,
+			Low:         k.Low,
+			Close:       k.Close,
+			Volume:      k.Volume,
+			QuoteVolume: k.QuoteVolume,
+		})
+	}
+	return klines, nil
+}
+
+func (b *binanceFutures) Trades(symbol string, limit int) ([]Trade, error) {
+	if limit <= 0 {
+		limit = binanceDefaultTrades
+	}
+	res, err := b.api.Market().RecentTradesList(symbol, limit)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	trades := make([]Trade, 0, len(res))
+	for _, t := range res {
+		trade := Trade{
+			ID:     strconv.FormatInt(t.ID, 10),
+			Symbol: symbol,
+			Side:   Buy,
+			Time:   time.UnixMilli(t.Time),
+		}
+		// The buyer being the maker means the seller took liquidity.
+		if t.IsBuyerMaker {
+			trade.Side = Sell
+		}
+		if err := decimals([]*decimal.Decimal{&trade.Price, &trade.Qty}, t.Price, t.Qty); err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+var binanceTimeInForce = map[TimeInForce]futures.TimeInForce{
+	GTC:      futures.TimeInForceGTC,
+	IOC:      futures.TimeInForceIOC,
+	FOK:      futures.TimeInForceFOK,
+	PostOnly: futures.TimeInForceGTX,
+}
+
+var binanceOrderStatuses = map[futures.OrderStatus]OrderStatus{
+	futures.OrderStatusNew:             StatusNew,
+	futures.OrderStatusPartiallyFilled: StatusPartiallyFilled,
+	futures.OrderStatusFilled:          StatusFilled,
+	futures.OrderStatusCanceled:        StatusCanceled,
+	futures.OrderStatusRejected:        StatusRejected,
+	futures.OrderStatusExpired:         StatusExpired,
+	futures.OrderStatusExpiredInMatch:  StatusExpired,
+}
+
+func (b *binanceFutures) PlaceOrder(req OrderRequest) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	order := futures.NewOrderRequest{
+		Symbol:           req.Symbol,
+		Side:             futures.Side(req.Side),
+		Type:             futures.OrderTypeMarket,
+		Quantity:         req.Qty.String(),
+		ReduceOnly:       req.ReduceOnly,
+		NewClientOrderID: req.ClientOrderID,
+		NewOrderRespType: futures.ResponseTypeResult,
+	}
+	if req.Type == Limit {
+		order.Type = futures.OrderTypeLimit
+		order.Price = req.Price.String()
+		if req.TimeInForce == "" {
+			req.TimeInForce = GTC
+		}
+	}
+	if req.TimeInForce != "" {
+		tif, ok := binanceTimeInForce[req.TimeInForce]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported time in force %q", ErrInvalidRequest, string(req.TimeInForce))
+		}
+		order.TimeInForce = tif
+	}
+
+	res, err := b.api.Orders().NewOrder(&order)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	return binanceOrder(res)
+}
+
+func (b *binanceFutures) AmendOrder(req AmendRequest) (*Order, error) {
+	// Binance requires the side, price and quantity of the modified order,
+	// so the unchanged ones are taken from the order itself.
+	current, err := b.GetOrder(req.OrderRef)
+	if err != nil {
+		return nil, err
+	}
+	modify := futures.ModifyOrderRequest{
+		Symbol:            req.Symbol,
+		OrigClientOrderID: req.ClientOrderID,
+		Side:              futures.Side(current.Side),
+		Quantity:          current.Qty.String(),
+		Price:             current.Price.String(),
+	}
+	if modify.OrderID, err = binanceOrderID(req.OrderID); err != nil {
+		return nil, err
+	}
+	if !req.Qty.IsZero() {
+		modify.Quantity = req.Qty.String()
+	}
+	if !req.Price.IsZero() {
+		modify.Price = req.Price.String()
+	}
+
+	res, err := b.api.Orders().ModifyOrder(&modify)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	return binanceOrder(res)
+}
+
+func (b *binanceFutures) CancelOrder(ref OrderRef) error {
+	query, err := binanceQuery(ref)
+	if err != nil {
+		return err
+	}
+	if _, err = b.api.Orders().CancelOrder(query); err != nil {
+		return binanceErr(err)
+	}
+	return nil
+}
+
+func (b *binanceFutures) GetOrder(ref OrderRef) (*Order, error) {
+	query, err := binanceQuery(ref)
+	if err != nil {
+		return nil, err
+	}
+	res, err := b.api.Orders().QueryOrder(query)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	return binanceOrder(res)
+}
+
+func (b *binanceFutures) OpenOrders(symbol string) ([]Order, error) {
+	res, err := b.api.Orders().OpenOrders(symbol)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	orders := make([]Order, 0, len(res))
+	for i := range res {
+		order, err := binanceOrder(&res[i])
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, *order)
+	}
+	return orders, nil
+}
+
+func binanceQuery(ref OrderRef) (futures.OrderQuery, error) {
+	if err := ref.Validate(); err != nil {
+		return futures.OrderQuery{}, err
+	}
+	id, err := binanceOrderID(ref.OrderID)
+	return futures.OrderQuery{Symbol: ref.Symbol, OrderID: id, OrigClientOrderID: ref.ClientOrderID}, err
+}
+
+// binanceOrderID parses an order ID; empty IDs are 0.
+func binanceOrderID(id string) (int64, error) {
+	if id == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed order ID %q", ErrInvalidRequest, id)
+	}
+	return n, nil
+}
+
+func binanceOrder(o *futures.Order) (*Order, error) {
+	status, ok := binanceOrderStatuses[o.Status]
+	if !ok {
+		status = StatusUnknown
+	}
+	order := &Order{
+		Symbol:        o.Symbol,
+		OrderID:       strconv.FormatInt(o.OrderID, 10),
+		ClientOrderID: o.ClientOrderID,
+		Side:          Side(o.Side),
+		Type:          Market,
+		Status:        status,
+		ReduceOnly:    o.ReduceOnly,
+		CreatedAt:     time.UnixMilli(o.Time),
+		UpdatedAt:     time.UnixMilli(o.UpdateTime),
+	}
+	if o.Type == futures.OrderTypeLimit {
+		order.Type = Limit
+	}
+	for tif, name := range binanceTimeInForce {
+		if name == o.TimeInForce {
+			order.TimeInForce = tif
+		}
+	}
+	err := decimals([]*decimal.Decimal{&order.Price, &order.Qty, &order.FilledQty, &order.AvgPrice},
+		o.Price, o.OrigQty, o.ExecutedQty, o.AvgPrice)
+	return order, err
+}
+
+func (b *binanceFutures) Positions(symbol string) ([]Position, error) {
+	res, err := b.api.Account().PositionRisk(symbol)
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	var positions []Position
+	for _, p := range res {
+		pos := Position{Symbol: p.Symbol, Side: Buy}
+		err := decimals([]*decimal.Decimal{&pos.Size, &pos.EntryPrice, &pos.MarkPrice, &pos.LiqPrice, &pos.Leverage, &pos.UnrealizedPnL},
+			p.PositionAmt, p.EntryPrice, p.MarkPrice, p.LiquidationPrice, p.Leverage, p.UnRealizedProfit)
+		if err != nil {
+			return nil, err
+		}
+		if pos.Size.IsZero() {
+			continue
+		}
+		// One-way mode positions are short when their amount is negative.
+		if p.PositionSide == futures.PositionSideShort || pos.Size.IsNegative() {
+			pos.Side = Sell
+		}
+		pos.Size = pos.Size.Abs()
+		positions = append(positions, pos)
+	}
+	return positions, nil
+}
+
+func (b *binanceFutures) Balances() ([]Balance, error) {
+	res, err := b.api.Account().Balances()
+	if err != nil {
+		return nil, binanceErr(err)
+	}
+	balances := make([]Balance, 0, len(res))
+	for _, c := range res {
+		balance := Balance{Asset: c.Asset}
+		err := decimals([]*decimal.Decimal{&balance.Total, &balance.Available, &balance.UnrealizedPnL},
+			c.Balance, c.AvailableBalance, c.CrossUnPnl)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+// LoadBinanceFutures adds the USDⓈ-M futures Binance lists. Symbols that are
+// not trading are skipped.
+func (r *Registry) LoadBinanceFutures(m market.Market) error {
+	info, err := m.GetExchangeInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get exchange info: %w", err)
+	}
+	for _, s := range info.Symbols {
+		if s.Status != "TRADING" && s.Status != "PENDING_TRADING" {
+			continue
+		}
+		i := Instrument{Base: s.BaseAsset, Quote: s.QuoteAsset, Settle: s.MarginAsset, Kind: KindFuture}
+		switch s.ContractType {
+		case "PERPETUAL", "TRADIFI_PERPETUAL":
+			i.Kind = KindPerpetual
+		case "CURRENT_QUARTER", "NEXT_QUARTER", "CURRENT_MONTH", "NEXT_MONTH":
+			i.Expiry = time.UnixMilli(s.DeliveryDate)
+		default:
+			continue
+		}
+		r.Add(Listing{Venue: VenueBinanceFutures, Symbol: s.Symbol, Instrument: i})
+	}
+	return nil
+}
@@ -0,0 +1,616 @@
+package exchange
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/position"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/trade"
+	"github.com/shopspring/decimal"
+)
+
+// bybitIntervals maps intervals to the values of Bybit's interval parameter.
+// Bybit has no 8h and 3d klines.
+var bybitIntervals = map[Interval]string{
+	Interval1m:  "1",
+	Interval3m:  "3",
+	Interval5m:  "5",
+	Interval15m: "15",
+	Interval30m: "30",
+	Interval1h:  "60",
+	Interval2h:  "120",
+	Interval4h:  "240",
+	Interval6h:  "360",
+	Interval12h: "720",
+	Interval1d:  "D",
+	Interval1w:  "W",
+	Interval1M:  "M",
+}
+
+// bybitErrors classifies Bybit's return codes.
+var bybitErrors = map[int]error{
+	10001:  ErrInvalidRequest,
+	10003:  ErrUnauthorized,
+	10004:  ErrUnauthorized,
+	10005:  ErrUnauthorized,
+	10006:  ErrRateLimited,
+	10018:  ErrRateLimited,
+	110001: ErrNotFound,
+	110003: ErrInvalidRequest,
+	110004: ErrInsufficientFunds,
+	110007: ErrInsufficientFunds,
+	110012: ErrInsufficientFunds,
+	110044: ErrInsufficientFunds,
+	170131: ErrInsufficientFunds,
+	170213: ErrNotFound,
+}
+
+var bybitOrderStatuses = map[string]OrderStatus{
+	"New":                     StatusNew,
+	"Created":                 StatusNew,
+	"Active":                  StatusNew,
+	"Triggered":               StatusNew,
+	"PartiallyFilled":         StatusPartiallyFilled,
+	"Filled":                  StatusFilled,
+	"Cancelled":               StatusCanceled,
+	"PartiallyFilledCanceled": StatusCanceled,
+	"Deactivated":             StatusCanceled,
+	"Rejected":                StatusRejected,
+	"Untriggered":             StatusUntriggered,
+}
+
+var bybitTimeInForce = map[TimeInForce]string{
+	GTC:      "GTC",
+	IOC:      "IOC",
+	FOK:      "FOK",
+	PostOnly: "PostOnly",
+}
+
+// bybitSettleCoins are the settle coins positions and orders of every linear
+// symbol are listed with when no symbol is given, one request each.
+var bybitSettleCoins = []string{"USDT", "USDC"}
+
+// bybitExchange adapts the bybit module to Exchange.
+type bybitExchange struct {
+	api      bybit.Bybit
+	category client.Category
+}
+
+// NewBybit returns an Exchange trading category on api.
+func NewBybit(api bybit.Bybit, category client.Category) Exchange {
+	return &bybitExchange{api: api, category: category}
+}
+
+func (b *bybitExchange) Venue() Venue           { return VenueBybit }
+func (b *bybitExchange) MarketData() MarketData { return b }
+func (b *bybitExchange) Orders() Orders         { return b }
+func (b *bybitExchange) Account() Account       { return b }
+
+// err returns the Error for a Bybit return code, or nil on success.
+func (b *bybitExchange) err(code int, msg string) error {
+	if code == 0 {
+		return nil
+	}
+	return newError(VenueBybit, code, msg, bybitErrors)
+}
+
+// settleCoins returns the settle coins to list the positions or orders of
+// symbol with: every linear settle coin when no symbol is given, and nil,
+// which leaves the coin out, otherwise.
+func (b *bybitExchange) settleCoins(symbol string) []*string {
+	if symbol != "" || b.category != client.CategoryLinear {
+		return []*string{nil}
+	}
+	coins := make([]*string, len(bybitSettleCoins))
+	for i, coin := range bybitSettleCoins {
+		coins[i] = optional(coin)
+	}
+	return coins
+}
+
+// params returns request parameters for symbol in the exchange's category.
+func (b *bybitExchange) params(symbol string) *client.Params {
+	return &client.Params{"category": b.category, "symbol": symbol}
+}
+
+func (b *bybitExchange) Ticker(symbol string) (*Ticker, error) {
+	res, err := b.api.Market().Tickers(b.params(symbol))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticker: %w", err)
+	}
+	if err := b.err(res.RetCode, res.RetMsg); err != nil {
+		return nil, err
+	}
+	if len(res.Result.List) == 0 {
+		return nil, fmt.Errorf("%w: ticker of %s", ErrNotFound, symbol)
+	}
+	t := res.Result.List[0]
+	ticker := &Ticker{Symbol: t.Symbol, Time: time.UnixMilli(res.Time)}
+	err = decimals([]*decimal.Decimal{
+		&ticker.LastPrice, &ticker.BidPrice, &ticker.BidQty, &ticker.AskPrice, &ticker.AskQty,
+		&ticker.MarkPrice, &ticker.High24h, &ticker.Low24h, &ticker.Volume24h,
+	}, t.LastPrice, t.Bid1Price, t.Bid1Size, t.Ask1Price, t.Ask1Size,
+		t.MarkPrice, t.HighPrice24H, t.LowPrice24H, t.Volume24H)
+	if err != nil {
+		return nil, err
+	}
+	return ticker, nil
+}
+
+func (b *bybitExchange) OrderBook(symbol string, depth int) (*OrderBook, error) {
+	params := b.params(symbol)
+	if depth > 0 {
+		(*params)["limit"] = depth
+	}
+	res, err := b.api.Market().OrderBook(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+	if err := b.err(res.RetCode, res.RetMsg); err != nil {
+		return nil, err
+	}
+	book := &OrderBook{Symbol: res.Result.S, Time: time.UnixMilli(res.Result.TS)}
+	if book.Bids, err = parseLevels(res.Result.B); err != nil {
+		return nil, err
+	}
+	if book.Asks, err = parseLevels(res.Result.A); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+func (b *bybitExchange) Klines(symbol string, interval Interval, limit int) ([]Kline, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+	bybitInterval, ok := bybitIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: unsupported interval %q", ErrInvalidRequest, VenueBybit, string(interval))
+	}
+	params := b.params(symbol)
+	(*params)["interval"] = bybitInterval
+	if limit > 0 {
+		(*params)["limit"] = limit
+	}
+	res, err := b.api.Market().Kline(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get klines: %w", err)
+	}
+	if err := b.err(res.RetCode, res.RetMsg); err != nil {
+		return nil, err
+	}
+
+	// Bybit lists klines newest first: [start, open, high, low, close, volume, turnover].
+	const fields = 7
+	klines := make([]Kline, len(res.Result.List))
+	for i, row := range res.Result.List {
+		if len(row) < fields {
+			return nil, fmt.Errorf("failed to parse kline: %v", row)
+		}
+		start, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse kline start: %w", err)
+		}
+		k := Kline{OpenTime: time.UnixMilli(start)}
+		err = decimals([]*decimal.Decimal{&k.Open, &k.High, &k.Low, &k.Close, &k.Volume, &k.QuoteVolume}, row[1:fields]...)
+		if err != nil {
+			return nil, err
+		}
+		klines[len(klines)-1-i] = k
+	}
+	return klines, nil
+}
+
+func (b *bybitExchange) Trades(symbol string, limit int) ([]Trade, error) {
+	params := b.params(symbol)
+	if limit > 0 {
+		(*params)["limit"] = limit
+	}
+	res, err := b.api.Market().RecentTrade(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+	if err := b.err(res.RetCode, res.RetMsg); err != nil {
+		return nil, err
+	}
+
+	trades := make([]Trade, 0, len(res.Result.List))
+	for _, t := range res.Result.List {
+		trade := Trade{ID: t.ExecID, Symbol: t.Symbol, Side: bybitSide(t.Side), Time: msTime(t.Time)}
+		if err := decimals([]*decimal.Decimal{&trade.Price, &trade.Qty}, t.Price, t.Size); err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].Time.Before(trades[j].Time) })
+	return trades, nil
+}
+
+func (b *bybitExchange) PlaceOrder(req OrderRequest) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	order := trade.PlaceOrderRequest{
+		Category:    b.category,
+		Symbol:      req.Symbol,
+		Side:        bybitSideName(req.Side),
+		OrderType:   "Market",
+		Qty:         req.Qty.String(),
+		OrderLinkID: req.ClientOrderID,
+	}
+	tif := req.TimeInForce
+	if req.Type == Limit {
+		order.OrderType = "Limit"
+		order.Price = req.Price.String()
+		if tif == "" {
+			tif = GTC
+		}
+	}
+	if tif != "" {
+		name, ok := bybitTimeInForce[tif]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported time in force %q", ErrInvalidRequest, string(tif))
+		}
+		order.TimeInForce = name
+	}
+	if req.ReduceOnly {
+		order.ReduceOnly = &req.ReduceOnly
+	}
+
+	res, err := b.api.Trade().PlaceOrder(&order)
+	if res != nil && res.RetCode != 0 {
+		return nil, b.err(res.RetCode, res.RetMsg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to place order: %w", err)
+	}
+	return &Order{
+		Symbol:        req.Symbol,
+		OrderID:       res.Result.OrderID,
+		ClientOrderID: res.Result.OrderLinkID,
+		Side:          req.Side,
+		Type:          req.Type,
+		Status:        StatusNew,
+		TimeInForce:   tif,
+		Price:         req.Price,
+		Qty:           req.Qty,
+		ReduceOnly:    req.ReduceOnly,
+	}, nil
+}
+
+func (b *bybitExchange) AmendOrder(req AmendRequest) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	amend := trade.AmendOrderRequest{Category: b.category, Symbol: req.Symbol}
+	amend.OrderID, amend.OrderLinkID = optional(req.OrderID), optional(req.ClientOrderID)
+	if !req.Qty.IsZero() {
+		amend.Qty = optional(req.Qty.String())
+	}
+	if !req.Price.IsZero() {
+		amend.Price = optional(req.Price.String())
+	}
+
+	res, err := b.api.Trade().AmendOrder(&amend)
+	if res != nil && res.RetCode != 0 {
+		return nil, b.err(res.RetCode, res.RetMsg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to amend order: %w", err)
+	}
+	return b.GetOrder(OrderRef{Symbol: req.Symbol, OrderID: res.Result.OrderID})
+}
+
+func (b *bybitExchange) CancelOrder(ref OrderRef) error {
+	if err := ref.Validate(); err != nil {
+		return err
+	}
+	res, err := b.api.Trade().CancelOrder(&trade.CancelOrderRequest{
+		Category:    b.category,
+		Symbol:      ref.Symbol,
+		OrderID:     optional(ref.OrderID),
+		OrderLinkID: optional(ref.ClientOrderID),
+	})
+	if res != nil && res.RetCode != 0 {
+		return b.err(res.RetCode, res.RetMsg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+	return nil
+}
+
+func (b *bybitExchange) GetOrder(ref OrderRef) (*Order, error) {
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+	// The realtime endpoint lists open orders with openOnly=0 and the most
+	// recent closed ones with openOnly=1; older orders are only in the
+	// order history.
+	for _, openOnly := range []int{0, 1} {
+		openOnly := openOnly
+		orders, err := b.orders(&trade.GetOpenOrdersRequest{
+			Category:    b.category,
+			Symbol:      &ref.Symbol,
+			OrderID:     optional(ref.OrderID),
+			OrderLinkID: optional(ref.ClientOrderID),
+			OpenOnly:    &openOnly,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(orders) > 0 {
+			return &orders[0], nil
+		}
+	}
+
+	res, err := b.api.Trade().GetOrderHistory(&trade.GetOrderHistoryRequest{
+		Category:    b.category,
+		Symbol:      &ref.Symbol,
+		OrderID:     optional(ref.OrderID),
+		OrderLinkID: optional(ref.ClientOrderID),
+	})
+	if res != nil && res.RetCode != 0 {
+		return nil, b.err(res.RetCode, res.RetMsg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order history: %w", err)
+	}
+	if len(res.Result.List) == 0 {
+		return nil, fmt.Errorf("%w: order %s%s of %s", ErrNotFound, ref.OrderID, ref.ClientOrderID, ref.Symbol)
+	}
+	order, err := bybitOrder(res.Result.List[0])
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (b *bybitExchange) OpenOrders(symbol string) ([]Order, error) {
+	var orders []Order
+	for _, coin := range b.settleCoins(symbol) {
+		req := &trade.GetOpenOrdersRequest{Category: b.category, Symbol: optional(symbol), SettleCoin: coin}
+		list, err := b.orders(req)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, list...)
+	}
+	return orders, nil
+}
+
+// orders returns every order matching req, following the pagination cursor.
+func (b *bybitExchange) orders(req *trade.GetOpenOrdersRequest) ([]Order, error) {
+	var orders []Order
+	for {
+		res, err := b.api.Trade().GetOpenOrders(req)
+		if res != nil && res.RetCode != 0 {
+			return nil, b.err(res.RetCode, res.RetMsg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get orders: %w", err)
+		}
+		for _, o := range res.Result.List {
+			order, err := bybitOrder(o)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
+		}
+		if res.Result.NextPageCursor == "" || len(res.Result.List) == 0 {
+			return orders, nil
+		}
+		req.Cursor = &res.Result.NextPageCursor
+	}
+}
+
+func bybitOrder(o trade.OrderDetails) (Order, error) {
+	status, ok := bybitOrderStatuses[o.OrderStatus]
+	if !ok {
+		status = StatusUnknown
+	}
+	order := Order{
+		Symbol:        o.Symbol,
+		OrderID:       o.OrderID,
+		ClientOrderID: o.OrderLinkID,
+		Side:          bybitSide(o.Side),
+		Type:          Market,
+		Status:        status,
+		ReduceOnly:    o.ReduceOnly,
+		CreatedAt:     msTime(o.CreatedTime),
+		UpdatedAt:     msTime(o.UpdatedTime),
+	}
+	if o.OrderType == "Limit" {
+		order.Type = Limit
+	}
+	for tif, name := range bybitTimeInForce {
+		if name == o.TimeInForce {
+			order.TimeInForce = tif
+		}
+	}
+	err := decimals([]*decimal.Decimal{&order.Price, &order.Qty, &order.FilledQty, &order.AvgPrice},
+		o.Price, o.Qty, o.CumExecQty, o.AvgPrice)
+	return order, err
+}
+
+func (b *bybitExchange) Positions(symbol string) ([]Position, error) {
+	var positions []Position
+	for _, coin := range b.settleCoins(symbol) {
+		req := &position.RequestParams{Category: b.category, Symbol: symbol, SettleCoin: coin}
+		list, err := b.positions(req)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, list...)
+	}
+	return positions, nil
+}
+
+// positions returns the open positions matching req, following the
+// pagination cursor.
+func (b *bybitExchange) positions(req *position.RequestParams) ([]Position, error) {
+	var positions []Position
+	for {
+		res, err := b.api.Position().GetPositionInfo(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get positions: %w", err)
+		}
+		if err := b.err(res.RetCode, res.RetMsg); err != nil {
+			return nil, err
+		}
+		for _, p := range res.Result.List {
+			pos := Position{Symbol: p.Symbol, Side: bybitSide(p.Side)}
+			err := decimals([]*decimal.Decimal{&pos.Size, &pos.EntryPrice, &pos.MarkPrice, &pos.LiqPrice, &pos.Leverage, &pos.UnrealizedPnL},
+				p.Size, p.AvgPrice, p.MarkPrice, p.LiqPrice, p.Leverage, p.UnrealisedPnl)
+			if err != nil {
+				return nil, err
+			}
+			if !pos.Size.IsZero() {
+				positions = append(positions, pos)
+			}
+		}
+		if res.Result.NextPageCursor == "" || len(res.Result.List) == 0 {
+			return positions, nil
+		}
+		req.Cursor = &res.Result.NextPageCursor
+	}
+}
+
+func (b *bybitExchange) Balances() ([]Balance, error) {
+	res, err := b.api.Account().Wallet().GetAllUnifiedWalletBalance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balances: %w", err)
+	}
+	if err := b.err(res.RetCode, res.RetMsg); err != nil {
+		return nil, err
+	}
+
+	var balances []Balance
+	for _, account := range res.Result.List {
+		for _, c := range account.Coin {
+			balance := Balance{Asset: c.Coin}
+			err := decimals([]*decimal.Decimal{&balance.Total, &balance.Available, &balance.UnrealizedPnL},
+				c.WalletBalance, c.AvailableToWithdraw, c.UnrealisedPnl)
+			if err != nil {
+				return nil, err
+			}
+			balances = append(balances, balance)
+		}
+	}
+	return balances, nil
+}
+
+func bybitSide(s string) Side {
+	switch s {
+	case "Buy":
+		return Buy
+	case "Sell":
+		return Sell
+	default:
+		return ""
+	}
+}
+
+func bybitSideName(s Side) string {
+	if s == Sell {
+		return "Sell"
+	}
+	return "Buy"
+}
+
+// msTime parses a timestamp in milliseconds sent as a string. Invalid
+// timestamps are the zero time.
+func msTime(s string) time.Time {
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// optional returns a pointer to s, or nil if s is empty.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// bybitInstrumentsLimit is the largest page of instruments Bybit serves.
+const bybitInstrumentsLimit = 1000
+
+// LoadBybit adds the instruments Bybit lists in categories, every category
+// if none are given. Instruments that are closed or being delivered are skipped.
+func (r *Registry) LoadBybit(m market.Market, categories ...client.Category) error {
+	if len(categories) == 0 {
+		categories = client.Categories()
+	}
+	for _, category := range categories {
+		params := client.Params{"category": category, "limit": bybitInstrumentsLimit}
+		for {
+			res, err := m.InstrumentsInfo(&params)
+			if err != nil {
+				return fmt.Errorf("failed to get %s instruments: %w", category, err)
+			}
+			if res.RetCode != 0 {
+				return newError(VenueBybit, res.RetCode, res.RetMsg, bybitErrors)
+			}
+			for _, info := range res.Result.List {
+				if info.Status != "Trading" && info.Status != "PreLaunch" {
+					continue
+				}
+				instrument, err := bybitInstrument(category, info)
+				if err != nil {
+					return err
+				}
+				r.Add(Listing{Venue: VenueBybit, Category: category, Symbol: info.Symbol, Instrument: instrument})
+			}
+			if res.Result.NextPageCursor == "" || len(res.Result.List) == 0 {
+				break
+			}
+			params["cursor"] = res.Result.NextPageCursor
+		}
+	}
+	return nil
+}
+
+// bybitInstrument returns the canonical instrument of a Bybit instrument.
+func bybitInstrument(category client.Category, info market.InstrumentInfo) (Instrument, error) {
+	i := Instrument{Base: info.BaseCoin, Quote: info.QuoteCoin, Settle: info.SettleCoin}
+	switch {
+	case category == client.CategorySpot:
+		i.Kind, i.Settle = KindSpot, ""
+		return i, nil
+	case i.Settle == "" && category == client.CategoryInverse:
+		i.Settle = i.Base
+	case i.Settle == "":
+		i.Settle = i.Quote
+	}
+
+	switch {
+	case category == client.CategoryOption:
+		// Option symbols are BASE-DDMMMYY-STRIKE-C|P, optionally followed by the settle coin.
+		parts := strings.Split(info.Symbol, "-")
+		const minParts = 4
+		if len(parts) < minParts {
+			return i, fmt.Errorf("failed to parse option symbol %q", info.Symbol)
+		}
+		strike, err := decimal.NewFromString(parts[2])
+		if err != nil {
+			return i, fmt.Errorf("failed to parse option symbol %q: %w", info.Symbol, err)
+		}
+		i.Kind, i.Strike, i.OptionType = KindOption, strike, OptionType(parts[3])
+		i.Expiry = msTime(info.DeliveryTime)
+	case strings.HasSuffix(info.ContractType, "Futures"):
+		i.Kind, i.Expiry = KindFuture, msTime(info.DeliveryTime)
+	default:
+		i.Kind = KindPerpetual
+	}
+	return i, nil
+}
@@ -0,0 +1,34 @@
+package exchange
+
+import (
+	"fmt"
+
+	idmap "github.com/cploutarchou/crypto-sdk-suite/coinmarketcap/cryptocurrency/map"
+)
+
+// cmcMapLimit is the largest page of the CoinMarketCap ID map.
+const cmcMapLimit = 5000
+
+// LoadCoinMarketCap sets the CoinMarketCap ID of every active asset. Several
+// assets can share a symbol; the one listed first, with the lowest ID, wins.
+func (r *Registry) LoadCoinMarketCap(m *idmap.Map) error {
+	ids := make(map[string]int)
+	for start := 1; ; start += cmcMapLimit {
+		page, err := m.GetID(&idmap.Params{ListingStatus: "active", Start: start, Limit: cmcMapLimit, Sort: "id"})
+		if err != nil {
+			return fmt.Errorf("failed to get CoinMarketCap ID map: %w", err)
+		}
+		for _, asset := range page {
+			if id, ok := ids[asset.Symbol]; !ok || asset.ID < id {
+				ids[asset.Symbol] = asset.ID
+			}
+		}
+		if len(page) < cmcMapLimit {
+			break
+		}
+	}
+	for asset, id := range ids {
+		r.SetAssetID(asset, id)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by every venue. Errors reported by an exchange
+// are *Error values wrapping one of them, so they can be tested with errors.Is.
+var (
+	ErrNotSupported      = errors.New("not supported by the venue")
+	ErrNotFound          = errors.New("not found")
+	ErrInvalidRequest    = errors.New("invalid request")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrRejected          = errors.New("rejected")
+)
+
+// Error is an error reported by an exchange.
+type Error struct {
+	Venue   Venue
+	Code    int    // Code is the venue's own error code.
+	Message string // Message is the venue's own error message.
+	Kind    error  // Kind is one of the Err variables of this package.
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s (code %d)", e.Venue, e.Message, e.Code)
+}
+
+// Unwrap returns the kind of the error.
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// newError returns the Error for a venue's code, classified with kinds.
+// Codes missing from kinds are ErrRejected.
+func newError(venue Venue, code int, message string, kinds map[int]error) *Error {
+	kind, ok := kinds[code]
+	if !ok {
+		kind = ErrRejected
+	}
+	return &Error{Venue: venue, Code: code, Message: message, Kind: kind}
+}
+
+// notSupported returns the error of an operation venue does not offer.
+func notSupported(venue Venue, op string) error {
+	return fmt.Errorf("%s: %s: %w", venue, op, ErrNotSupported)
+}
@@ -0,0 +1,105 @@
+// Package exchange provides venue-agnostic interfaces for market data, order
+// management, positions and balances, with adapters for the bybit and
+// binance/futures modules. A strategy written against Exchange can switch
+// venues by changing its Config.
+package exchange
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// Venue names a supported exchange.
+type Venue string
+
+const (
+	VenueBybit          Venue = "bybit"
+	VenueBinanceFutures Venue = "binance-futures"
+)
+
+// Venues returns every supported venue.
+func Venues() []Venue {
+	return []Venue{VenueBybit, VenueBinanceFutures}
+}
+
+// ParseVenue returns the venue named s, ignoring case.
+func ParseVenue(s string) (Venue, error) {
+	for _, v := range Venues() {
+		if strings.EqualFold(s, string(v)) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported venue %q", s)
+}
+
+// Exchange is a trading venue.
+type Exchange interface {
+	Venue() Venue
+	MarketData() MarketData
+	Orders() Orders
+	Account() Account
+}
+
+// MarketData serves public market data.
+type MarketData interface {
+	Ticker(symbol string) (*Ticker, error)
+	// OrderBook returns up to depth levels per side; 0 uses the venue's default.
+	OrderBook(symbol string, depth int) (*OrderBook, error)
+	// Klines returns up to limit klines, oldest first; 0 uses the venue's default.
+	Klines(symbol string, interval Interval, limit int) ([]Kline, error)
+	// Trades returns up to limit recent trades, oldest first; 0 uses the venue's default.
+	Trades(symbol string, limit int) ([]Trade, error)
+}
+
+// Orders places and manages orders.
+type Orders interface {
+	// PlaceOrder places an order. The returned order may only carry its IDs
+	// and the request; use GetOrder for its current state.
+	PlaceOrder(req OrderRequest) (*Order, error)
+	AmendOrder(req AmendRequest) (*Order, error)
+	CancelOrder(ref OrderRef) error
+	GetOrder(ref OrderRef) (*Order, error)
+	// OpenOrders returns the open orders of symbol, or of every symbol if it is empty.
+	OpenOrders(symbol string) ([]Order, error)
+}
+
+// Account serves positions and balances.
+type Account interface {
+	// Positions returns the open positions of symbol, or of every symbol if it is empty.
+	Positions(symbol string) ([]Position, error)
+	Balances() ([]Balance, error)
+}
+
+// Config selects and configures a venue.
+type Config struct {
+	Venue     Venue
+	APIKey    string
+	APISecret string
+	Testnet   bool
+	// Category is the Bybit product the exchange trades; it defaults to
+	// linear. It is ignored by the other venues.
+	Category client.Category
+}
+
+// New creates the Exchange described by cfg.
+func New(cfg Config) (Exchange, error) {
+	switch cfg.Venue {
+	case VenueBybit:
+		category := cfg.Category
+		if category == "" {
+			category = client.CategoryLinear
+		}
+		if err := category.Validate(); err != nil {
+			return nil, err
+		}
+		return NewBybit(bybit.New(cfg.APIKey, cfg.APISecret, cfg.Testnet, category), category), nil
+	case VenueBinanceFutures:
+		return NewBinanceFutures(futures.New(cfg.APIKey, cfg.APISecret, cfg.Testnet)), nil
+	default:
+		return nil, fmt.Errorf("unsupported venue %q", cfg.Venue)
+	}
+}
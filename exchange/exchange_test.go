@@ -0,0 +1,227 @@
+package exchange
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/binance/futures"
+	binanceclient "github.com/cploutarchou/crypto-sdk-suite/binance/futures/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBybit returns a Bybit exchange whose REST requests are answered
+// with the bodies of responses, by path.
+func newTestBybit(t *testing.T, responses map[string]string) Exchange {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	env := client.Environment{Name: "test", RESTURL: srv.URL}
+	return NewBybit(bybit.NewWithEnvironment("key", "secret", env, client.CategoryLinear), client.CategoryLinear)
+}
+
+func TestParseVenue(t *testing.T) {
+	v, err := ParseVenue("Binance-Futures")
+	require.NoError(t, err)
+	assert.Equal(t, VenueBinanceFutures, v)
+
+	_, err = ParseVenue("kraken")
+	assert.Error(t, err)
+
+	_, err = New(Config{Venue: "kraken"})
+	assert.Error(t, err)
+}
+
+func TestOrderRequest_Validate(t *testing.T) {
+	req := OrderRequest{Symbol: "BTCUSDT", Side: Buy, Type: Limit, Qty: decimal.NewFromInt(1)}
+	assert.ErrorIs(t, req.Validate(), ErrInvalidRequest)
+
+	req.Price = decimal.NewFromInt(100)
+	assert.NoError(t, req.Validate())
+
+	req.Type, req.Price = Market, decimal.Zero
+	assert.NoError(t, req.Validate())
+}
+
+func TestInterval(t *testing.T) {
+	for i := range intervals {
+		assert.NoError(t, i.Validate())
+		if _, ok := bybitIntervals[i]; !ok {
+			assert.Contains(t, []Interval{Interval8h, Interval3d}, i)
+		}
+	}
+	for i := range bybitIntervals {
+		assert.NoError(t, i.Validate())
+	}
+	assert.Equal(t, 4*time.Hour, Interval4h.Duration())
+	assert.ErrorIs(t, Interval("7m").Validate(), ErrInvalidRequest)
+}
+
+func TestBybit_Klines(t *testing.T) {
+	ex := newTestBybit(t, map[string]string{
+		"/v5/market/kline": `{"retCode":0,"retMsg":"OK","result":{"symbol":"BTCUSDT","category":"linear","list":[
+			["1700000060000","101","103","100","102","5","510"],
+			["1700000000000","100","102","99","101","4","404"]]}}`,
+	})
+
+	klines, err := ex.MarketData().Klines("BTCUSDT", Interval1m, 2)
+	require.NoError(t, err)
+	require.Len(t, klines, 2)
+	assert.Equal(t, int64(1700000000000), klines[0].OpenTime.UnixMilli())
+	assert.True(t, klines[1].Close.Equal(decimal.NewFromInt(102)))
+	assert.True(t, klines[0].QuoteVolume.Equal(decimal.NewFromInt(404)))
+
+	_, err = ex.MarketData().Klines("BTCUSDT", "7m", 2)
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+	_, err = ex.MarketData().Klines("BTCUSDT", Interval8h, 2)
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestBybit_PositionsOfEverySettleCoin(t *testing.T) {
+	var coins []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v5/position/list", r.URL.Path)
+		coin := r.URL.Query().Get("settleCoin")
+		coins = append(coins, coin)
+		list := `[]`
+		if coin == "USDC" {
+			list = `[{"symbol":"BTCPERP","side":"Buy","size":"0.5","avgPrice":"60000","markPrice":"61000",
+				"liqPrice":"40000","leverage":"5","unrealisedPnl":"500"}]`
+		}
+		_, _ = w.Write([]byte(`{"retCode":0,"result":{"list":` + list + `}}`))
+	}))
+	t.Cleanup(srv.Close)
+	env := client.Environment{Name: "test", RESTURL: srv.URL}
+	ex := NewBybit(bybit.NewWithEnvironment("key", "secret", env, client.CategoryLinear), client.CategoryLinear)
+
+	positions, err := ex.Account().Positions("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"USDT", "USDC"}, coins)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "BTCPERP", positions[0].Symbol)
+	assert.Equal(t, Buy, positions[0].Side)
+	assert.True(t, positions[0].Size.Equal(decimal.RequireFromString("0.5")))
+}
+
+func TestBybit_GetClosedOrder(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, r.URL.Path+" "+q.Get("openOnly"))
+		order := `{"orderId":"` + q.Get("orderId") + `","symbol":"BTCUSDT","side":"Buy","orderType":"Limit",
+			"price":"100","qty":"1","cumExecQty":"1","avgPrice":"100","orderStatus":"Filled"}`
+		list := `[]`
+		switch {
+		case r.URL.Path == "/v5/order/realtime" && q.Get("openOnly") == "1" && q.Get("orderId") == "1",
+			r.URL.Path == "/v5/order/history" && q.Get("orderId") == "2":
+			list = `[` + order + `]`
+		}
+		_, _ = w.Write([]byte(`{"retCode":0,"result":{"list":` + list + `}}`))
+	}))
+	t.Cleanup(srv.Close)
+	env := client.Environment{Name: "test", RESTURL: srv.URL}
+	ex := NewBybit(bybit.NewWithEnvironment("key", "secret", env, client.CategoryLinear), client.CategoryLinear)
+
+	// A recently filled order is only listed with openOnly=1.
+	order, err := ex.Orders().GetOrder(OrderRef{Symbol: "BTCUSDT", OrderID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, StatusFilled, order.Status)
+	assert.Equal(t, []string{"/v5/order/realtime 0", "/v5/order/realtime 1"}, queries)
+
+	// Older orders are only in the order history.
+	queries = nil
+	order, err = ex.Orders().GetOrder(OrderRef{Symbol: "BTCUSDT", OrderID: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, "2", order.OrderID)
+	assert.Equal(t, []string{"/v5/order/realtime 0", "/v5/order/realtime 1", "/v5/order/history "}, queries)
+
+	_, err = ex.Orders().GetOrder(OrderRef{Symbol: "BTCUSDT", OrderID: "3"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestBybit_PlaceOrderError(t *testing.T) {
+	ex := newTestBybit(t, map[string]string{
+		"/v5/order/create": `{"retCode":110007,"retMsg":"ab not enough for new order","result":{}}`,
+	})
+
+	_, err := ex.Orders().PlaceOrder(OrderRequest{
+		Symbol: "BTCUSDT",
+		Side:   Buy,
+		Type:   Market,
+		Qty:    decimal.RequireFromString("0.01"),
+	})
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	var exErr *Error
+	require.True(t, errors.As(err, &exErr))
+	assert.Equal(t, VenueBybit, exErr.Venue)
+	assert.Equal(t, 110007, exErr.Code)
+}
+
+func TestBinanceFutures_InvalidOrderID(t *testing.T) {
+	ex, err := New(Config{Venue: VenueBinanceFutures, Testnet: true})
+	require.NoError(t, err)
+
+	_, err = ex.Orders().GetOrder(OrderRef{Symbol: "BTCUSDT", OrderID: "not a number"})
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestBinanceFutures_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":-2019,"msg":"Margin is insufficient."}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := binanceclient.NewClient(binanceclient.Config{APIKey: "key", APISecret: "secret", BaseURL: srv.URL})
+	ex := NewBinanceFutures(futures.NewWithClient(c))
+
+	_, err := ex.Orders().PlaceOrder(OrderRequest{
+		Symbol: "BTCUSDT",
+		Side:   Buy,
+		Type:   Market,
+		Qty:    decimal.RequireFromString("0.01"),
+	})
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	var exErr *Error
+	require.True(t, errors.As(err, &exErr))
+	assert.Equal(t, VenueBinanceFutures, exErr.Venue)
+	assert.Equal(t, -2019, exErr.Code)
+}
+
+func TestBinanceFutures_Ticker(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/ticker/24hr":
+			_, _ = w.Write([]byte(`{"symbol":"BTCUSDT","lastPrice":"100.5","highPrice":"110","lowPrice":"90","volume":"1234","closeTime":1700000000000}`))
+		case "/fapi/v1/ticker/bookTicker":
+			_, _ = w.Write([]byte(`{"symbol":"BTCUSDT","bidPrice":"100.4","bidQty":"2","askPrice":"100.6","askQty":"3","time":1700000000500}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	ex := NewBinanceFutures(futures.NewWithClient(binanceclient.NewClient(binanceclient.Config{BaseURL: srv.URL})))
+
+	ticker, err := ex.MarketData().Ticker("BTCUSDT")
+	require.NoError(t, err)
+	assert.True(t, ticker.LastPrice.Equal(decimal.RequireFromString("100.5")))
+	assert.True(t, ticker.AskQty.Equal(decimal.NewFromInt(3)))
+	assert.True(t, ticker.Volume24h.Equal(decimal.NewFromInt(1234)))
+	assert.True(t, ticker.MarkPrice.IsZero())
+	assert.Equal(t, int64(1700000000500), ticker.Time.UnixMilli())
+}
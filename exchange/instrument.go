@@ -0,0 +1,117 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Kind is the kind of instrument.
+type Kind string
+
+const (
+	KindSpot      Kind = "spot"
+	KindPerpetual Kind = "perpetual"
+	KindFuture    Kind = "future"
+	KindOption    Kind = "option"
+)
+
+// OptionType is whether an option is a call or a put.
+type OptionType string
+
+const (
+	Call OptionType = "C"
+	Put  OptionType = "P"
+)
+
+// expiryLayout is how expiry dates are written in canonical symbols.
+const expiryLayout = "060102"
+
+// Instrument is a venue-independent description of a tradable instrument.
+type Instrument struct {
+	Base       string
+	Quote      string
+	Kind       Kind
+	Settle     string          // Settle is the asset derivatives are margined and settled in; empty for spot.
+	Expiry     time.Time       // Expiry is when futures and options are delivered; zero otherwise.
+	Strike     decimal.Decimal // Strike is the strike price of options.
+	OptionType OptionType      // OptionType is set for options only.
+}
+
+// String returns the canonical symbol of i: BASE/QUOTE for spot,
+// BASE/QUOTE:SETTLE for perpetuals, BASE/QUOTE:SETTLE-YYMMDD for futures and
+// BASE/QUOTE:SETTLE-YYMMDD-STRIKE-C|P for options.
+func (i Instrument) String() string {
+	s := i.Base + "/" + i.Quote
+	if i.Kind == KindSpot {
+		return s
+	}
+	s += ":" + i.Settle
+	switch i.Kind {
+	case KindFuture:
+		s += "-" + i.Expiry.UTC().Format(expiryLayout)
+	case KindOption:
+		s += "-" + i.Expiry.UTC().Format(expiryLayout) + "-" + i.Strike.String() + "-" + string(i.OptionType)
+	}
+	return s
+}
+
+// ParseInstrument parses a canonical symbol as written by Instrument.String.
+// Expiry dates are parsed as 08:00 UTC, when most venues deliver.
+func ParseInstrument(s string) (Instrument, error) {
+	var i Instrument
+	pair, rest, derivative := strings.Cut(s, ":")
+	base, quote, ok := strings.Cut(pair, "/")
+	if !ok || base == "" || quote == "" {
+		return i, fmt.Errorf("%w: malformed symbol %q", ErrInvalidRequest, s)
+	}
+	i.Base, i.Quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if !derivative {
+		i.Kind = KindSpot
+		return i, nil
+	}
+
+	parts := strings.Split(rest, "-")
+	i.Settle = strings.ToUpper(parts[0])
+	if i.Settle == "" {
+		return i, fmt.Errorf("%w: malformed symbol %q", ErrInvalidRequest, s)
+	}
+	switch len(parts) {
+	case 1:
+		i.Kind = KindPerpetual
+		return i, nil
+	case 2, 4:
+		expiry, err := time.Parse(expiryLayout, parts[1])
+		if err != nil {
+			return i, fmt.Errorf("%w: malformed expiry in %q", ErrInvalidRequest, s)
+		}
+		i.Expiry = expiry.Add(deliveryHour)
+	default:
+		return i, fmt.Errorf("%w: malformed symbol %q", ErrInvalidRequest, s)
+	}
+	if len(parts) == 2 {
+		i.Kind = KindFuture
+		return i, nil
+	}
+
+	i.Kind = KindOption
+	strike, err := decimal.NewFromString(parts[2])
+	if err != nil {
+		return i, fmt.Errorf("%w: malformed strike in %q", ErrInvalidRequest, s)
+	}
+	i.Strike = strike
+	switch OptionType(strings.ToUpper(parts[3])) {
+	case Call:
+		i.OptionType = Call
+	case Put:
+		i.OptionType = Put
+	default:
+		return i, fmt.Errorf("%w: malformed option type in %q", ErrInvalidRequest, s)
+	}
+	return i, nil
+}
+
+// deliveryHour is the time of day, in UTC, futures and options are delivered at.
+const deliveryHour = 8 * time.Hour
@@ -0,0 +1,258 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// Listing is an instrument as listed by a venue.
+type Listing struct {
+	Venue      Venue           `json:"venue"`
+	Category   client.Category `json:"category,omitempty"` // Category is the Bybit product the symbol belongs to.
+	Symbol     string          `json:"symbol"`             // Symbol is the venue's native symbol.
+	Instrument Instrument      `json:"instrument"`
+}
+
+// listingKey identifies a native symbol. Bybit reuses symbols across
+// categories, so the category is part of the key.
+type listingKey struct {
+	venue    Venue
+	category client.Category
+	symbol   string
+}
+
+// Registry resolves canonical instruments to and from the native symbols of
+// every venue, and assets to their CoinMarketCap IDs. It is safe for
+// concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	listings  map[listingKey]Listing
+	canonical map[string][]listingKey
+	assetIDs  map[string]int
+	updatedAt time.Time
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		listings:  make(map[listingKey]Listing),
+		canonical: make(map[string][]listingKey),
+		assetIDs:  make(map[string]int),
+	}
+}
+
+// Add adds or replaces a listing.
+func (r *Registry) Add(l Listing) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.add(l)
+}
+
+// add adds l. r.mu must be held.
+func (r *Registry) add(l Listing) {
+	key := listingKey{l.Venue, l.Category, l.Symbol}
+	if old, ok := r.listings[key]; ok {
+		r.remove(old.Instrument.String(), key)
+	}
+	r.listings[key] = l
+	name := l.Instrument.String()
+	r.canonical[name] = append(r.canonical[name], key)
+	r.updatedAt = time.Now()
+}
+
+// remove drops key from the listings of the canonical symbol name. r.mu must be held.
+func (r *Registry) remove(name string, key listingKey) {
+	keys := r.canonical[name]
+	for i, k := range keys {
+		if k == key {
+			r.canonical[name] = append(keys[:i:i], keys[i+1:]...)
+			break
+		}
+	}
+	if len(r.canonical[name]) == 0 {
+		delete(r.canonical, name)
+	}
+}
+
+// SetAssetID sets the CoinMarketCap ID of asset.
+func (r *Registry) SetAssetID(asset string, id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.assetIDs[strings.ToUpper(asset)] = id
+	r.updatedAt = time.Now()
+}
+
+// Resolve returns the listing of a venue's native symbol. category is only
+// used for Bybit, whose symbols are unique per category.
+func (r *Registry) Resolve(venue Venue, category client.Category, symbol string) (Listing, error) {
+	if venue != VenueBybit {
+		category = ""
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	l, ok := r.listings[listingKey{venue, category, symbol}]
+	if !ok {
+		return Listing{}, fmt.Errorf("%w: %s symbol %s", ErrNotFound, venue, symbol)
+	}
+	return l, nil
+}
+
+// Native returns the listing of instrument on venue.
+func (r *Registry) Native(venue Venue, instrument Instrument) (Listing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.canonical[instrument.String()] {
+		if key.venue == venue {
+			return r.listings[key], nil
+		}
+	}
+	return Listing{}, fmt.Errorf("%w: %s on %s", ErrNotFound, instrument, venue)
+}
+
+// Listings returns every listing of instrument, on any venue.
+func (r *Registry) Listings(instrument Instrument) []Listing {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := r.canonical[instrument.String()]
+	listings := make([]Listing, 0, len(keys))
+	for _, key := range keys {
+		listings = append(listings, r.listings[key])
+	}
+	return listings
+}
+
+// Venue returns every listing of venue, sorted by symbol.
+func (r *Registry) Venue(venue Venue) []Listing {
+	r.mu.RLock()
+	var listings []Listing
+	for key, l := range r.listings {
+		if key.venue == venue {
+			listings = append(listings, l)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(listings, func(i, j int) bool {
+		if listings[i].Symbol != listings[j].Symbol {
+			return listings[i].Symbol < listings[j].Symbol
+		}
+		return listings[i].Category < listings[j].Category
+	})
+	return listings
+}
+
+// AssetID returns the CoinMarketCap ID of asset.
+func (r *Registry) AssetID(asset string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.assetIDs[strings.ToUpper(asset)]
+	return id, ok
+}
+
+// Asset returns the asset with CoinMarketCap ID id.
+func (r *Registry) Asset(id int) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for asset, assetID := range r.assetIDs {
+		if assetID == id {
+			return asset, true
+		}
+	}
+	return "", false
+}
+
+// UpdatedAt returns when the registry was last changed.
+func (r *Registry) UpdatedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.updatedAt
+}
+
+// registryFile is the on-disk format of a Registry.
+type registryFile struct {
+	UpdatedAt time.Time      `json:"updated_at"`
+	Listings  []Listing      `json:"listings"`
+	AssetIDs  map[string]int `json:"asset_ids"`
+}
+
+// Save writes the registry to path, replacing it atomically.
+func (r *Registry) Save(path string) error {
+	r.mu.RLock()
+	file := registryFile{UpdatedAt: r.updatedAt, AssetIDs: r.assetIDs}
+	for _, l := range r.listings {
+		file.Listings = append(file.Listings, l)
+	}
+	data, err := json.Marshal(file)
+	r.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode registry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to save registry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save registry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save registry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save registry: %w", err)
+	}
+	return nil
+}
+
+// LoadRegistry reads a registry written by Save.
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load registry: %w", err)
+	}
+	var file registryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode registry: %w", err)
+	}
+
+	r := NewRegistry()
+	for _, l := range file.Listings {
+		r.add(l)
+	}
+	for asset, id := range file.AssetIDs {
+		r.assetIDs[asset] = id
+	}
+	r.updatedAt = file.UpdatedAt
+	return r, nil
+}
+
+// OpenRegistry returns the registry cached at path if it is younger than
+// maxAge. Otherwise it builds a new registry with refresh, typically calling
+// the Load functions of this package, and caches it at path.
+func OpenRegistry(path string, maxAge time.Duration, refresh func(*Registry) error) (*Registry, error) {
+	if r, err := LoadRegistry(path); err == nil && time.Since(r.UpdatedAt()) < maxAge {
+		return r, nil
+	}
+
+	r := NewRegistry()
+	if err := refresh(r); err != nil {
+		return nil, err
+	}
+	if err := r.Save(path); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,102 @@
+package exchange
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstrument(t *testing.T) {
+	for _, s := range []string{
+		"BTC/USDT",
+		"BTC/USDT:USDT",
+		"BTC/USD:BTC-240628",
+		"ETH/USD:USDC-241227-3500-P",
+	} {
+		i, err := ParseInstrument(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, i.String())
+	}
+
+	for _, s := range []string{"BTCUSDT", "BTC/USDT:", "BTC/USD:BTC-2406", "BTC/USD:BTC-240628-1-X"} {
+		_, err := ParseInstrument(s)
+		assert.ErrorIs(t, err, ErrInvalidRequest, s)
+	}
+}
+
+func TestBybitInstrument(t *testing.T) {
+	i, err := bybitInstrument(client.CategoryOption, market.InstrumentInfo{
+		Symbol:       "BTC-28JUN24-60000-C",
+		BaseCoin:     "BTC",
+		QuoteCoin:    "USD",
+		SettleCoin:   "USDC",
+		DeliveryTime: "1719561600000",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "BTC/USD:USDC-240628-60000-C", i.String())
+
+	i, err = bybitInstrument(client.CategoryInverse, market.InstrumentInfo{
+		Symbol:       "BTCUSD",
+		ContractType: "InversePerpetual",
+		BaseCoin:     "BTC",
+		QuoteCoin:    "USD",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "BTC/USD:BTC", i.String())
+}
+
+func TestRegistry(t *testing.T) {
+	perp := Instrument{Base: "BTC", Quote: "USDT", Kind: KindPerpetual, Settle: "USDT"}
+	r := NewRegistry()
+	r.Add(Listing{Venue: VenueBybit, Category: client.CategoryLinear, Symbol: "BTCUSDT", Instrument: perp})
+	r.Add(Listing{Venue: VenueBybit, Category: client.CategorySpot, Symbol: "BTCUSDT", Instrument: Instrument{Base: "BTC", Quote: "USDT", Kind: KindSpot}})
+	r.Add(Listing{Venue: VenueBinanceFutures, Symbol: "BTCUSDT", Instrument: perp})
+	r.SetAssetID("btc", 1)
+
+	l, err := r.Resolve(VenueBybit, client.CategorySpot, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, KindSpot, l.Instrument.Kind)
+
+	l, err = r.Native(VenueBinanceFutures, perp)
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", l.Symbol)
+	assert.Len(t, r.Listings(perp), 2)
+
+	_, err = r.Resolve(VenueBinanceFutures, "", "ETHUSDT")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	id, ok := r.AssetID("BTC")
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+
+	path := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, r.Save(path))
+
+	refreshed := false
+	cached, err := OpenRegistry(path, time.Hour, func(*Registry) error {
+		refreshed = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, refreshed)
+	assert.Len(t, cached.Venue(VenueBybit), 2)
+	l, err = cached.Native(VenueBybit, perp)
+	require.NoError(t, err)
+	assert.Equal(t, client.CategoryLinear, l.Category)
+	asset, ok := cached.Asset(1)
+	assert.True(t, ok)
+	assert.Equal(t, "BTC", asset)
+
+	_, err = OpenRegistry(path, 0, func(*Registry) error {
+		refreshed = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, refreshed)
+}
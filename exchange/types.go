@@ -0,0 +1,294 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Side is the side of an order, trade or position.
+type Side string
+
+const (
+	Buy  Side = "BUY"
+	Sell Side = "SELL"
+)
+
+// OrderType is the type of order.
+type OrderType string
+
+const (
+	Limit  OrderType = "LIMIT"
+	Market OrderType = "MARKET"
+)
+
+// TimeInForce is how long an order remains active.
+type TimeInForce string
+
+const (
+	GTC      TimeInForce = "GTC" // Good till cancelled.
+	IOC      TimeInForce = "IOC" // Immediate or cancel.
+	FOK      TimeInForce = "FOK" // Fill or kill.
+	PostOnly TimeInForce = "POST_ONLY"
+)
+
+// OrderStatus is the state of an order.
+type OrderStatus string
+
+const (
+	StatusNew             OrderStatus = "NEW"
+	StatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	StatusFilled          OrderStatus = "FILLED"
+	StatusCanceled        OrderStatus = "CANCELED"
+	StatusRejected        OrderStatus = "REJECTED"
+	StatusExpired         OrderStatus = "EXPIRED"
+	StatusUntriggered     OrderStatus = "UNTRIGGERED"
+	StatusUnknown         OrderStatus = "UNKNOWN"
+)
+
+// Active reports whether an order in status s can still be filled.
+func (s OrderStatus) Active() bool {
+	return s == StatusNew || s == StatusPartiallyFilled || s == StatusUntriggered
+}
+
+// Interval is the length of a kline, written the way Binance does.
+type Interval string
+
+const (
+	Interval1m  Interval = "1m"
+	Interval3m  Interval = "3m"
+	Interval5m  Interval = "5m"
+	Interval15m Interval = "15m"
+	Interval30m Interval = "30m"
+	Interval1h  Interval = "1h"
+	Interval2h  Interval = "2h"
+	Interval4h  Interval = "4h"
+	Interval6h  Interval = "6h"
+	Interval8h  Interval = "8h"
+	Interval12h Interval = "12h"
+	Interval1d  Interval = "1d"
+	Interval3d  Interval = "3d"
+	Interval1w  Interval = "1w"
+	Interval1M  Interval = "1M"
+)
+
+// intervals maps the supported intervals to their length. A month is
+// counted as 30 days.
+var intervals = map[Interval]time.Duration{
+	Interval1m:  time.Minute,
+	Interval3m:  3 * time.Minute,
+	Interval5m:  5 * time.Minute,
+	Interval15m: 15 * time.Minute,
+	Interval30m: 30 * time.Minute,
+	Interval1h:  time.Hour,
+	Interval2h:  2 * time.Hour,
+	Interval4h:  4 * time.Hour,
+	Interval6h:  6 * time.Hour,
+	Interval8h:  8 * time.Hour,
+	Interval12h: 12 * time.Hour,
+	Interval1d:  24 * time.Hour,
+	Interval3d:  3 * 24 * time.Hour,
+	Interval1w:  7 * 24 * time.Hour,
+	Interval1M:  30 * 24 * time.Hour,
+}
+
+// Validate returns an error if i is not a supported interval. Supported
+// intervals may still be missing on some venues, such as 8h and 3d on Bybit.
+func (i Interval) Validate() error {
+	if _, ok := intervals[i]; !ok {
+		return fmt.Errorf("%w: unsupported interval %q", ErrInvalidRequest, string(i))
+	}
+	return nil
+}
+
+// Duration returns the length of i, or 0 if it is not supported.
+func (i Interval) Duration() time.Duration {
+	return intervals[i]
+}
+
+// Level is a price level of an order book.
+type Level struct {
+	Price decimal.Decimal
+	Qty   decimal.Decimal
+}
+
+// OrderBook is a snapshot of the best bids and asks of a symbol. Bids are
+// sorted from the highest price, asks from the lowest.
+type OrderBook struct {
+	Symbol string
+	Bids   []Level
+	Asks   []Level
+	Time   time.Time
+}
+
+// Ticker is the latest price summary of a symbol.
+type Ticker struct {
+	Symbol    string
+	LastPrice decimal.Decimal
+	BidPrice  decimal.Decimal
+	BidQty    decimal.Decimal
+	AskPrice  decimal.Decimal
+	AskQty    decimal.Decimal
+	MarkPrice decimal.Decimal // MarkPrice is zero on venues that do not report it with the ticker.
+	High24h   decimal.Decimal
+	Low24h    decimal.Decimal
+	Volume24h decimal.Decimal
+	Time      time.Time
+}
+
+// Kline is a candlestick.
+type Kline struct {
+	OpenTime    time.Time
+	Open        decimal.Decimal
+	High        decimal.Decimal
+	Low         decimal.Decimal
+	Close       decimal.Decimal
+	Volume      decimal.Decimal // Volume is in the base asset, or in contracts for inverse symbols.
+	QuoteVolume decimal.Decimal
+}
+
+// Trade is a public trade.
+type Trade struct {
+	ID     string
+	Symbol string
+	Side   Side // Side is the side of the taker.
+	Price  decimal.Decimal
+	Qty    decimal.Decimal
+	Time   time.Time
+}
+
+// OrderRequest is a new order.
+type OrderRequest struct {
+	Symbol        string
+	Side          Side
+	Type          OrderType
+	Qty           decimal.Decimal
+	Price         decimal.Decimal // Price is required for limit orders and ignored for market orders.
+	TimeInForce   TimeInForce     // TimeInForce defaults to GTC for limit orders.
+	ReduceOnly    bool
+	ClientOrderID string
+}
+
+// Validate returns an error if r is missing a required field.
+func (r OrderRequest) Validate() error {
+	switch {
+	case r.Symbol == "":
+		return fmt.Errorf("%w: symbol is required", ErrInvalidRequest)
+	case r.Side != Buy && r.Side != Sell:
+		return fmt.Errorf("%w: unsupported side %q", ErrInvalidRequest, string(r.Side))
+	case r.Type != Limit && r.Type != Market:
+		return fmt.Errorf("%w: unsupported order type %q", ErrInvalidRequest, string(r.Type))
+	case !r.Qty.IsPositive():
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidRequest)
+	case r.Type == Limit && !r.Price.IsPositive():
+		return fmt.Errorf("%w: limit orders require a positive price", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// OrderRef identifies an order by its exchange ID or by its client order ID.
+type OrderRef struct {
+	Symbol        string
+	OrderID       string
+	ClientOrderID string
+}
+
+// Validate returns an error if r identifies no order.
+func (r OrderRef) Validate() error {
+	if r.Symbol == "" {
+		return fmt.Errorf("%w: symbol is required", ErrInvalidRequest)
+	}
+	if r.OrderID == "" && r.ClientOrderID == "" {
+		return fmt.Errorf("%w: order ID or client order ID is required", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// AmendRequest changes the price or quantity of an open order. Zero values
+// are left unchanged.
+type AmendRequest struct {
+	OrderRef
+	Qty   decimal.Decimal
+	Price decimal.Decimal
+}
+
+// Order is the state of an order.
+type Order struct {
+	Symbol        string
+	OrderID       string
+	ClientOrderID string
+	Side          Side
+	Type          OrderType
+	Status        OrderStatus
+	TimeInForce   TimeInForce
+	Price         decimal.Decimal
+	Qty           decimal.Decimal
+	FilledQty     decimal.Decimal
+	AvgPrice      decimal.Decimal
+	ReduceOnly    bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Position is an open position. Size is always positive; Side tells the
+// direction.
+type Position struct {
+	Symbol        string
+	Side          Side
+	Size          decimal.Decimal
+	EntryPrice    decimal.Decimal
+	MarkPrice     decimal.Decimal
+	LiqPrice      decimal.Decimal
+	Leverage      decimal.Decimal
+	UnrealizedPnL decimal.Decimal
+}
+
+// Balance is the balance of an asset.
+type Balance struct {
+	Asset         string
+	Total         decimal.Decimal // Total is the wallet balance, excluding unrealized PnL.
+	Available     decimal.Decimal
+	UnrealizedPnL decimal.Decimal
+}
+
+// parseDecimal parses a decimal sent by an exchange. Empty strings are zero.
+func parseDecimal(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+	d, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to parse decimal %q: %w", s, err)
+	}
+	return d, nil
+}
+
+// decimals parses several decimals into dst, stopping at the first error.
+func decimals(dst []*decimal.Decimal, src ...string) error {
+	for i, s := range src {
+		d, err := parseDecimal(s)
+		if err != nil {
+			return err
+		}
+		*dst[i] = d
+	}
+	return nil
+}
+
+// parseLevels parses order book levels of [price, qty] pairs.
+func parseLevels(raw [][]string) ([]Level, error) {
+	levels := make([]Level, 0, len(raw))
+	for _, l := range raw {
+		if len(l) < 2 {
+			return nil, fmt.Errorf("failed to parse level: %v", l)
+		}
+		var level Level
+		if err := decimals([]*decimal.Decimal{&level.Price, &level.Qty}, l[0], l[1]); err != nil {
+			return nil, err
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
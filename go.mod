@@ -4,8 +4,11 @@ go 1.21.0
 
 require (
 	github.com/gorilla/websocket v1.5.1
+	github.com/shopspring/decimal v1.4.0
 	github.com/sirupsen/logrus v1.9.3
 	github.com/stretchr/testify v1.9.0
+	go.uber.org/goleak v1.3.0
+	golang.org/x/crypto v0.23.0
 	golang.org/x/time v0.5.0
 )
 
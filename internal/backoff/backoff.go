@@ -0,0 +1,84 @@
+// Package backoff is the reconnection policy shared by the websocket
+// clients: exponential delays with jitter, and the loop retrying with them.
+package backoff
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// ErrStopped is returned by Retry when it is stopped before succeeding.
+var ErrStopped = errors.New("retry stopped")
+
+// ErrGaveUp is returned by Retry when every attempt failed.
+var ErrGaveUp = errors.New("retries exhausted")
+
+// Policy configures how a connection is retried after it drops.
+// The delay before attempt n is InitialDelay * Multiplier^(n-1), capped at
+// MaxDelay and randomized by ±Jitter (a fraction between 0 and 1).
+type Policy struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	Jitter       float64
+	// MaxRetries is the number of attempts before giving up; 0 retries forever.
+	MaxRetries int
+}
+
+// DefaultPolicy is the policy used by new clients.
+var DefaultPolicy = Policy{
+	InitialDelay: time.Second,
+	MaxDelay:     time.Minute,
+	Multiplier:   2,
+	Jitter:       0.2,
+	MaxRetries:   10,
+}
+
+// Delay returns the delay before the given attempt, starting at 1.
+func (p Policy) Delay(attempt int) time.Duration {
+	delay := float64(p.InitialDelay)
+	for i := 1; i < attempt; i++ {
+		delay *= p.Multiplier
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1) //nolint:gosec // jitter does not need a secure source
+	}
+	return time.Duration(delay)
+}
+
+// Retry calls attempt following p until it succeeds, returning the number of
+// the successful attempt. wait is called with the delay before each attempt
+// is made, and may be nil. If immediate is set, the first attempt is not
+// delayed. Retry returns ErrStopped once done is closed, and ErrGaveUp when
+// p.MaxRetries attempts failed.
+func (p Policy) Retry(done <-chan struct{}, immediate bool, wait func(attempt int, delay time.Duration), attempt func(attempt int) error) (int, error) {
+	for n := 1; p.MaxRetries <= 0 || n <= p.MaxRetries; n++ {
+		delay := p.Delay(n)
+		if immediate && n == 1 {
+			delay = 0
+		}
+		if wait != nil {
+			wait(n, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-done:
+			timer.Stop()
+			return n, ErrStopped
+		case <-timer.C:
+		}
+
+		if err := attempt(n); err == nil {
+			return n, nil
+		}
+	}
+	return p.MaxRetries, ErrGaveUp
+}
@@ -0,0 +1,51 @@
+package backoff
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Delay(t *testing.T) {
+	p := Policy{InitialDelay: time.Second, MaxDelay: 5 * time.Second, Multiplier: 2}
+
+	assert.Equal(t, time.Second, p.Delay(1))
+	assert.Equal(t, 2*time.Second, p.Delay(2))
+	assert.Equal(t, 4*time.Second, p.Delay(3))
+	assert.Equal(t, 5*time.Second, p.Delay(4))
+	assert.Equal(t, 5*time.Second, p.Delay(100))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.Delay(2)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, 3*time.Second)
+	}
+}
+
+func TestPolicy_Retry(t *testing.T) {
+	p := Policy{InitialDelay: time.Millisecond, Multiplier: 1, MaxRetries: 3}
+	fail := errors.New("fail")
+
+	var delays []time.Duration
+	n, err := p.Retry(nil, true, func(_ int, d time.Duration) { delays = append(delays, d) }, func(n int) error {
+		if n < 2 {
+			return fail
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []time.Duration{0, time.Millisecond}, delays)
+
+	n, err = p.Retry(nil, false, nil, func(int) error { return fail })
+	assert.ErrorIs(t, err, ErrGaveUp)
+	assert.Equal(t, 3, n)
+
+	done := make(chan struct{})
+	close(done)
+	_, err = p.Retry(done, false, nil, func(int) error { return nil })
+	assert.ErrorIs(t, err, ErrStopped)
+}
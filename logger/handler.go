@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Handler is a slog.Handler that writes records through a Logger, so slog
+// users and the SDK share one sink and format.
+type Handler struct {
+	logger *Logger
+	group  string // group is the prefix of attribute keys, ending with a dot.
+}
+
+// NewHandler returns a slog.Handler writing to l.
+func NewHandler(l *Logger) *Handler {
+	return &Handler{logger: l}
+}
+
+// Handler returns a slog.Handler writing to l.
+func (l *Logger) Handler() slog.Handler {
+	return NewHandler(l)
+}
+
+// Enabled reports whether records at level are written.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Enabled(fromSlogLevel(level))
+}
+
+// Handle writes the record.
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	args := make([]any, 0, 2*r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		args = appendAttr(args, h.group, a)
+		return true
+	})
+	h.logger.write(r.Time, fromSlogLevel(r.Level), r.Message, args)
+	return nil
+}
+
+// WithAttrs returns a handler whose records carry attrs.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	args := make([]any, 0, 2*len(attrs))
+	for _, a := range attrs {
+		args = appendAttr(args, h.group, a)
+	}
+	return &Handler{logger: h.logger.With(args...), group: h.group}
+}
+
+// WithGroup returns a handler that prefixes the keys of later attributes with name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{logger: h.logger, group: h.group + name + "."}
+}
+
+// appendAttr appends a as a key and value pair, flattening groups into dotted keys.
+func appendAttr(args []any, prefix string, a slog.Attr) []any {
+	value := a.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, attr := range value.Group() {
+			args = appendAttr(args, prefix, attr)
+		}
+		return args
+	}
+	if a.Equal(slog.Attr{}) {
+		return args
+	}
+	return append(args, prefix+a.Key, value.Any())
+}
+
+// fromSlogLevel maps a slog level onto the closest LogLevel.
+func fromSlogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelWarn:
+		return INFO
+	case level < slog.LevelError:
+		return WARNING
+	default:
+		return ERROR
+	}
+}
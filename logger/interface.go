@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// Interface is the logger the SDK clients write to. Messages are constant
+// strings followed by alternating keys and values, as with log/slog.
+type Interface interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// Nop is a logger that discards everything.
+var Nop Interface = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// NewSlog adapts a *slog.Logger. A nil l uses slog.Default().
+func NewSlog(l *slog.Logger) Interface {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogAdapter{l}
+}
+
+type slogAdapter struct {
+	l *slog.Logger
+}
+
+func (a slogAdapter) Debug(msg string, args ...any) { a.l.Debug(msg, args...) }
+func (a slogAdapter) Info(msg string, args ...any)  { a.l.Info(msg, args...) }
+func (a slogAdapter) Warn(msg string, args ...any)  { a.l.Warn(msg, args...) }
+func (a slogAdapter) Error(msg string, args ...any) { a.l.Error(msg, args...) }
+
+// Adapt adapts a *Logger. Keys and values are written as the logger's fields.
+func Adapt(l *Logger) Interface {
+	return loggerAdapter{l}
+}
+
+type loggerAdapter struct {
+	l *Logger
+}
+
+func (a loggerAdapter) Debug(msg string, args ...any) { a.l.logw(DEBUG, msg, args) }
+func (a loggerAdapter) Info(msg string, args ...any)  { a.l.logw(INFO, msg, args) }
+func (a loggerAdapter) Warn(msg string, args ...any)  { a.l.logw(WARNING, msg, args) }
+func (a loggerAdapter) Error(msg string, args ...any) { a.l.logw(ERROR, msg, args) }
+
+// NewStd adapts a standard library *log.Logger, writing the messages at or
+// above level.
+func NewStd(l *log.Logger, level LogLevel) Interface {
+	return stdAdapter{l: l, level: level}
+}
+
+type stdAdapter struct {
+	l     *log.Logger
+	level LogLevel
+}
+
+func (a stdAdapter) print(level LogLevel, msg string, args []any) {
+	if level >= a.level {
+		a.l.Printf("[%s] %s", levelStrings[level], format(msg, args))
+	}
+}
+
+func (a stdAdapter) Debug(msg string, args ...any) { a.print(DEBUG, msg, args) }
+func (a stdAdapter) Info(msg string, args ...any)  { a.print(INFO, msg, args) }
+func (a stdAdapter) Warn(msg string, args ...any)  { a.print(WARNING, msg, args) }
+func (a stdAdapter) Error(msg string, args ...any) { a.print(ERROR, msg, args) }
+
+// WithLevel returns a logger that passes on to l only the messages at or above level.
+func WithLevel(l Interface, level LogLevel) Interface {
+	return levelFilter{l: l, level: level}
+}
+
+type levelFilter struct {
+	l     Interface
+	level LogLevel
+}
+
+func (f levelFilter) Debug(msg string, args ...any) {
+	if DEBUG >= f.level {
+		f.l.Debug(msg, args...)
+	}
+}
+
+func (f levelFilter) Info(msg string, args ...any) {
+	if INFO >= f.level {
+		f.l.Info(msg, args...)
+	}
+}
+
+func (f levelFilter) Warn(msg string, args ...any) {
+	if WARNING >= f.level {
+		f.l.Warn(msg, args...)
+	}
+}
+
+func (f levelFilter) Error(msg string, args ...any) {
+	if ERROR >= f.level {
+		f.l.Error(msg, args...)
+	}
+}
+
+// SlogLevel returns the slog level matching level, for use with slog handlers.
+func SlogLevel(level LogLevel) slog.Level {
+	switch level {
+	case DEBUG:
+		return slog.LevelDebug
+	case INFO:
+		return slog.LevelInfo
+	case WARNING:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// format appends the key and value pairs in args to msg.
+func format(msg string, args []any) string {
+	if len(args) == 0 {
+		return msg
+	}
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		b.WriteByte(' ')
+		if i+1 == len(args) {
+			fmt.Fprintf(&b, "!BADKEY=%v", args[i])
+			break
+		}
+		fmt.Fprintf(&b, "%v=%v", args[i], args[i+1])
+	}
+	return b.String()
+}
@@ -1,9 +1,10 @@
 package logger
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
+	"io"
 	"os"
 	"sync"
 	"time"
@@ -38,11 +39,38 @@ var levelColors = []string{
 
 const resetColor = "\033[0m"
 
+// String returns the name of the level.
+func (l LogLevel) String() string {
+	if l < DEBUG || l > FATAL {
+		return fmt.Sprintf("LogLevel(%d)", int(l))
+	}
+	return levelStrings[l]
+}
+
+// Logger writes leveled messages, with the key and value fields attached
+// through With, to a sink. Child loggers created by With share the sink,
+// level and format of their parent.
 type Logger struct {
-	mu       sync.Mutex  // Mutex for serializing access to the logger
-	logLevel LogLevel    // The level of logging to use
-	logger   *log.Logger // The logger to use
-	jsonMode bool        // Whether to output in JSON format
+	core    *core    // The sink, level and format shared with child loggers
+	fields  []any    // Key and value pairs written with every message
+	sampler *Sampler // Optional sampler for hot paths
+}
+
+// core is the state shared by a logger and its children.
+type core struct {
+	mu       sync.Mutex // Mutex for serializing writes to the sink
+	logLevel LogLevel   // The level of logging to use
+	sink     io.Writer  // Where log lines are written
+	jsonMode bool       // Whether to output in JSON format
+	colors   bool       // Whether to wrap text lines in ANSI colours
+}
+
+// Config configures a Logger.
+type Config struct {
+	Level  LogLevel
+	JSON   bool      // JSON writes one JSON object per line instead of text.
+	Sink   io.Writer // Sink receives the log lines; nil writes to stdout.
+	Colors bool      // Colors wraps text lines in ANSI colours by level.
 }
 
 // LogMessage defines a single log message
@@ -52,46 +80,136 @@ type LogMessage struct {
 	Message   string `json:"message"`
 }
 
-// NewLogger creates a new Logger instance
+// NewLogger creates a new Logger instance writing to stdout, in colour unless jsonMode is set.
 func NewLogger(level LogLevel, jsonMode bool) *Logger {
-	return &Logger{
-		logLevel: level,
-		logger:   log.New(os.Stdout, "", 0),
-		jsonMode: jsonMode,
+	return New(Config{Level: level, JSON: jsonMode, Colors: !jsonMode})
+}
+
+// New creates a Logger from config.
+func New(config Config) *Logger {
+	sink := config.Sink
+	if sink == nil {
+		sink = os.Stdout
 	}
+	return &Logger{core: &core{
+		logLevel: config.Level,
+		sink:     sink,
+		jsonMode: config.JSON,
+		colors:   config.Colors && !config.JSON,
+	}}
+}
+
+// With returns a child logger that writes args, alternating keys and values,
+// with every message.
+func (l *Logger) With(args ...any) *Logger {
+	child := *l
+	child.fields = append(append([]any(nil), l.fields...), args...)
+	return &child
+}
+
+// WithSampler returns a child logger whose DEBUG, INFO and WARNING messages
+// are thinned out by s. Errors are never sampled.
+func (l *Logger) WithSampler(s *Sampler) *Logger {
+	child := *l
+	child.sampler = s
+	return &child
+}
+
+// SetLevel changes the level of the logger and of every logger sharing its sink.
+func (l *Logger) SetLevel(level LogLevel) {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+	l.core.logLevel = level
+}
+
+// Level returns the minimum level the logger writes.
+func (l *Logger) Level() LogLevel {
+	l.core.mu.Lock()
+	defer l.core.mu.Unlock()
+	return l.core.logLevel
+}
+
+// Enabled reports whether messages at level are written.
+func (l *Logger) Enabled(level LogLevel) bool {
+	return level >= l.Level()
 }
 
 func (l *Logger) log(level LogLevel, format string, v ...any) {
-	if level >= l.logLevel {
-		l.mu.Lock()
-		defer l.mu.Unlock()
-
-		prefix := levelStrings[level]
-		color := levelColors[level]
-
-		message := fmt.Sprintf(format, v...)
-		timestamp := time.Now().Format(time.RFC3339)
-		if l.jsonMode {
-			logMsg := LogMessage{
-				Timestamp: timestamp,
-				Level:     prefix,
-				Message:   message,
-			}
-			logData, _ := json.Marshal(logMsg)
-			l.outputLog(string(logData))
-		} else {
-			logLine := fmt.Sprintf("%s[%s] [%s] %s%s", color, timestamp, prefix, message, resetColor)
-			l.outputLog(logLine)
-		}
+	if !l.Enabled(level) {
+		return
+	}
+	l.write(time.Now(), level, fmt.Sprintf(format, v...), nil)
+}
 
-		if level == FATAL {
-			os.Exit(1)
+// logw writes msg with args as fields in addition to the logger's own.
+func (l *Logger) logw(level LogLevel, msg string, args []any) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.write(time.Now(), level, msg, args)
+}
+
+// write formats and writes one entry. The level must already have been checked.
+func (l *Logger) write(at time.Time, level LogLevel, message string, args []any) {
+	if l.sampler != nil && level < ERROR && !l.sampler.allow(level, message, at) {
+		return
+	}
+
+	fields := l.fields
+	if len(args) > 0 {
+		fields = append(append(make([]any, 0, len(fields)+len(args)), fields...), args...)
+	}
+	timestamp := at.Format(time.RFC3339)
+
+	c := l.core
+	var line string
+	if c.jsonMode {
+		line = formatJSON(timestamp, level, message, fields)
+	} else {
+		line = fmt.Sprintf("[%s] [%s] %s", timestamp, levelStrings[level], format(message, fields))
+		if c.colors {
+			line = levelColors[level] + line + resetColor
 		}
 	}
+
+	c.mu.Lock()
+	l.outputLog(line)
+	c.mu.Unlock()
+
+	if level == FATAL {
+		os.Exit(1)
+	}
 }
 
 func (l *Logger) outputLog(logLine string) {
-	l.logger.Println(logLine)
+	_, _ = io.WriteString(l.core.sink, logLine+"\n")
+}
+
+// formatJSON returns the entry as a JSON object whose fields follow timestamp, level and message.
+func formatJSON(timestamp string, level LogLevel, message string, fields []any) string {
+	var b bytes.Buffer
+	head, _ := json.Marshal(LogMessage{Timestamp: timestamp, Level: levelStrings[level], Message: message})
+	b.Write(head[:len(head)-1])
+	for i := 0; i < len(fields); i += 2 {
+		key, value := fmt.Sprint(fields[i]), any("!MISSING")
+		if i+1 < len(fields) {
+			value = fields[i+1]
+		}
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(value)
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(value))
+		}
+		b.WriteByte(',')
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.String()
 }
 
 // Debug logs a message at DEBUG level
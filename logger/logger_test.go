@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingSink struct{}
+
+func (failingSink) Write([]byte) (int, error) { return 0, errors.New("sink failed") }
+
+func TestLogger_WithFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Level: INFO, Sink: &buf})
+
+	child := l.With("symbol", "BTCUSDT")
+	child.With("side", "Buy").Info("order %s", "placed")
+	l.Info("no fields")
+	l.Debug("hidden")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "[INFO] order placed symbol=BTCUSDT side=Buy")
+	assert.True(t, strings.HasSuffix(lines[1], "[INFO] no fields"))
+}
+
+func TestLogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Level: DEBUG, JSON: true, Sink: &buf}).With("symbol", "BTCUSDT", "qty", 2)
+
+	Adapt(l).Error("rejected", "error", errors.New("insufficient balance"))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Equal(t, "rejected", entry["message"])
+	assert.Equal(t, "BTCUSDT", entry["symbol"])
+	assert.Equal(t, float64(2), entry["qty"])
+	assert.Equal(t, "insufficient balance", entry["error"])
+}
+
+func TestMultiSink(t *testing.T) {
+	var a, b bytes.Buffer
+	l := New(Config{Level: INFO, Sink: MultiSink(&a, failingSink{}, &b)})
+
+	l.Info("hello")
+
+	assert.Contains(t, a.String(), "hello")
+	assert.Contains(t, b.String(), "hello")
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdk.log")
+	sink, err := NewRotatingFile(path, 64, 2)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	l := New(Config{Level: INFO, Sink: sink})
+	for i := 0; i < 10; i++ {
+		l.Info("message number %d", i)
+	}
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), "message number 9")
+	assert.FileExists(t, path+".1")
+	assert.FileExists(t, path+".2")
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestSampler(t *testing.T) {
+	var buf bytes.Buffer
+	sampler := NewSampler(time.Minute, 2, 5)
+	l := New(Config{Level: DEBUG, Sink: &buf}).WithSampler(sampler)
+
+	for i := 0; i < 12; i++ {
+		l.Debug("tick")
+	}
+	l.Error("failure")
+
+	// The first two, then the 7th and 12th.
+	assert.Equal(t, 4, strings.Count(buf.String(), "tick"))
+	assert.Equal(t, uint64(8), sampler.Dropped())
+	assert.Contains(t, buf.String(), "failure")
+}
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Level: INFO, JSON: true, Sink: &buf})
+	s := slog.New(NewHandler(l)).With("exchange", "bybit").WithGroup("order")
+
+	s.Debug("hidden")
+	s.Warn("amended", "id", "42", slog.Group("price", "old", "1", "new", "2"))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "WARNING", entry["level"])
+	assert.Equal(t, "bybit", entry["exchange"])
+	assert.Equal(t, "42", entry["order.id"])
+	assert.Equal(t, "2", entry["order.price.new"])
+}
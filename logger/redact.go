@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// visibleChars is the number of leading characters Mask leaves readable.
+const visibleChars = 4
+
+// sensitiveKeys are the keys whose values are always masked, compared case-insensitively.
+var sensitiveKeys = map[string]bool{
+	"apikey":         true,
+	"api_key":        true,
+	"apisecret":      true,
+	"api_secret":     true,
+	"secret":         true,
+	"secretkey":      true,
+	"signature":      true,
+	"sign":           true,
+	"password":       true,
+	"token":          true,
+	"listenkey":      true,
+	"x-bapi-api-key": true,
+	"x-bapi-sign":    true,
+	"x-mbx-apikey":   true,
+}
+
+// Mask hides all but the first characters of a credential, so log lines can
+// tell keys apart without revealing them.
+func Mask(s string) string {
+	if len(s) <= visibleChars {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:visibleChars] + strings.Repeat("*", len(s)-visibleChars)
+}
+
+// Redact returns a logger that masks secrets wherever they appear in the
+// messages and values passed to l, as well as the values of well-known
+// credential keys such as "apiKey" and "signature". Empty secrets are ignored.
+func Redact(l Interface, secrets ...string) Interface {
+	r := redactor{l: l}
+	for _, s := range secrets {
+		if s != "" {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+	return r
+}
+
+type redactor struct {
+	l       Interface
+	secrets []string
+}
+
+func (r redactor) Debug(msg string, args ...any) { r.l.Debug(r.scrub(msg), r.args(args)...) }
+func (r redactor) Info(msg string, args ...any)  { r.l.Info(r.scrub(msg), r.args(args)...) }
+func (r redactor) Warn(msg string, args ...any)  { r.l.Warn(r.scrub(msg), r.args(args)...) }
+func (r redactor) Error(msg string, args ...any) { r.l.Error(r.scrub(msg), r.args(args)...) }
+
+// scrub masks every secret found in s.
+func (r redactor) scrub(s string) string {
+	for _, secret := range r.secrets {
+		if strings.Contains(s, secret) {
+			s = strings.ReplaceAll(s, secret, Mask(secret))
+		}
+	}
+	return s
+}
+
+// args returns a copy of args with sensitive values masked. Values are
+// formatted as strings only when they may contain a secret.
+func (r redactor) args(args []any) []any {
+	if len(args) == 0 {
+		return args
+	}
+	out := make([]any, len(args))
+	copy(out, args)
+	for i := 1; i < len(out); i += 2 {
+		key, _ := out[i-1].(string)
+		switch v := out[i].(type) {
+		case string:
+			if sensitiveKeys[strings.ToLower(key)] {
+				out[i] = Mask(v)
+			} else {
+				out[i] = r.scrub(v)
+			}
+		case error, fmt.Stringer:
+			s := fmt.Sprint(v)
+			if sensitiveKeys[strings.ToLower(key)] {
+				out[i] = Mask(s)
+			} else if scrubbed := r.scrub(s); scrubbed != s {
+				out[i] = scrubbed
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "abcd****", Mask("abcdefgh"))
+	assert.Equal(t, "***", Mask("abc"))
+	assert.Equal(t, "", Mask(""))
+}
+
+func TestRedact(t *testing.T) {
+	var buf bytes.Buffer
+	l := Redact(NewStd(log.New(&buf, "", 0), DEBUG), "my-api-key", "my-api-secret")
+
+	l.Debug("authenticating", "apiKey", "my-api-key", "signature", "f00ba4")
+	l.Error("failed to dial wss://host?key=my-api-key", "error", errors.New("bad secret my-api-secret"))
+
+	out := buf.String()
+	assert.NotContains(t, out, "my-api-key")
+	assert.NotContains(t, out, "my-api-secret")
+	assert.NotContains(t, out, "f00ba4")
+	assert.Contains(t, out, "apiKey=my-a******")
+}
+
+func TestWithLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := WithLevel(NewSlog(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))), WARNING)
+
+	l.Info("hidden")
+	l.Warn("shown", "attempt", 2)
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "attempt=2")
+}
+
+func TestAdapt(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(Config{Level: DEBUG, Sink: &buf})
+
+	Adapt(l).Warn("reconnecting", "attempt", 1, "delay", "1s")
+
+	assert.Contains(t, buf.String(), "[WARNING] reconnecting attempt=1 delay=1s")
+}
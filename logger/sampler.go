@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler limits how often the same message is logged: within each tick, the
+// first First occurrences of a message at a level are written and after that
+// only every Thereafter-th one.
+type Sampler struct {
+	tick       time.Duration
+	first      uint64
+	thereafter uint64
+
+	mu      sync.Mutex
+	counts  map[sampleKey]*sampleCount
+	dropped atomic.Uint64
+}
+
+type sampleKey struct {
+	level   LogLevel
+	message string
+}
+
+type sampleCount struct {
+	start time.Time
+	n     uint64
+}
+
+// NewSampler creates a Sampler. A thereafter of 0 drops every message past the first ones in a tick.
+func NewSampler(tick time.Duration, first, thereafter int) *Sampler {
+	return &Sampler{
+		tick:       tick,
+		first:      uint64(max(first, 0)),
+		thereafter: uint64(max(thereafter, 0)),
+		counts:     make(map[sampleKey]*sampleCount),
+	}
+}
+
+// Dropped returns the number of messages the sampler has discarded.
+func (s *Sampler) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// allow reports whether a message should be written.
+func (s *Sampler) allow(level LogLevel, message string, at time.Time) bool {
+	s.mu.Lock()
+	key := sampleKey{level: level, message: message}
+	count, ok := s.counts[key]
+	if !ok || at.Sub(count.start) >= s.tick {
+		count = &sampleCount{start: at}
+		s.counts[key] = count
+	}
+	count.n++
+	n := count.n
+	s.mu.Unlock()
+
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		return true
+	}
+	s.dropped.Add(1)
+	return false
+}